@@ -48,12 +48,21 @@ performing the basic boot and power operations on the bare metal host(s).
 
 	flagAll            = "all"
 	flagAllDescription = "specify this to target all hosts in the site inventory"
+
+	flagConcurrency            = "concurrency"
+	flagConcurrencyDescription = "maximum number of bare metal hosts the action is performed against at the same time"
+
+	flagFailFast            = "fail-fast"
+	flagFailFastDescription = "stop performing the action against the remaining hosts once it fails against any host"
+
+	defaultConcurrency = 10
 )
 
 var (
 	selectorsDescription = fmt.Sprintf(`The command will target bare metal hosts from airship site inventory based on the
 --%s, --%s and --%s flags provided. If no flags are provided, airshipctl will select all bare metal hosts in the site
-inventory.`, flagName, flagNamespace, flagLabel)
+inventory. Up to --%s hosts are processed at the same time, the result of the action against every host
+is printed once all of them are processed.`, flagName, flagNamespace, flagLabel, flagConcurrency)
 
 	bmhActionExampleTemplate = `
 Perform %[1]s action against hosts with name rdm9r3s3 in all namespaces where the host is found
@@ -67,6 +76,9 @@ Perform %[1]s action against all hosts defined in inventory
 
 Perform %[1]s action against hosts with a label 'foo=bar'
 # airshipctl baremetal %[1]s --labels "foo=bar"
+
+Perform %[1]s action against all hosts, 20 hosts at a time, stopping once it fails against any host
+# airshipctl baremetal %[1]s --all --concurrency 20 --fail-fast
 `
 )
 
@@ -79,7 +91,10 @@ func NewBaremetalCommand(cfgFactory config.Factory) *cobra.Command {
 		Long:  baremetalLong[1:],
 	}
 
+	baremetalRootCmd.AddCommand(NewConfigureBIOSCommand(cfgFactory, options))
 	baremetalRootCmd.AddCommand(NewEjectMediaCommand(cfgFactory, options))
+	baremetalRootCmd.AddCommand(NewFirmwareCommand(cfgFactory, options))
+	baremetalRootCmd.AddCommand(NewInventoryCommand(cfgFactory, options))
 	baremetalRootCmd.AddCommand(NewPowerOffCommand(cfgFactory, options))
 	baremetalRootCmd.AddCommand(NewPowerOnCommand(cfgFactory, options))
 	baremetalRootCmd.AddCommand(NewPowerStatusCommand(cfgFactory, options))
@@ -98,6 +113,9 @@ func initFlags(options *inventory.CommandOptions, cmd *cobra.Command) {
 	flags.DurationVar(&options.Timeout, flagTimeout, 10*time.Minute, flagTimeoutDescription)
 }
 
-func initAllFlag(options *inventory.CommandOptions, cmd *cobra.Command) {
-	cmd.Flags().BoolVar(&options.All, flagAll, false, flagAllDescription)
+func initBatchFlags(options *inventory.CommandOptions, cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(&options.All, flagAll, false, flagAllDescription)
+	flags.IntVar(&options.Concurrency, flagConcurrency, defaultConcurrency, flagConcurrencyDescription)
+	flags.BoolVar(&options.FailFast, flagFailFast, false, flagFailFastDescription)
 }
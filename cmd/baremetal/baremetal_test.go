@@ -29,11 +29,26 @@ func TestBaremetal(t *testing.T) {
 			CmdLine: "-h",
 			Cmd:     baremetal.NewBaremetalCommand(nil),
 		},
+		{
+			Name:    "baremetal-configurebios-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewConfigureBIOSCommand(nil, &inventory.CommandOptions{}),
+		},
 		{
 			Name:    "baremetal-ejectmedia-with-help",
 			CmdLine: "-h",
 			Cmd:     baremetal.NewEjectMediaCommand(nil, &inventory.CommandOptions{}),
 		},
+		{
+			Name:    "baremetal-firmware-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewFirmwareCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-inventory-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewInventoryCommand(nil, &inventory.CommandOptions{}),
+		},
 		{
 			Name:    "baremetal-poweroff-with-help",
 			CmdLine: "-h",
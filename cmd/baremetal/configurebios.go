@@ -0,0 +1,73 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+)
+
+var (
+	configureBIOSCommand = "configure-bios"
+
+	configureBIOSLong = fmt.Sprintf(`
+Apply BIOS settings to bare metal host(s). The desired BIOS attributes and persistent boot order are read from
+BIOSConfiguration document and compared with the current settings of every host, only the settings that differ
+are changed and the host is rebooted for BIOS to apply them. Use --dry-run to print the settings that differ
+without changing them. %s
+`, selectorsDescription)
+
+	configureBIOSExample = `
+Print BIOS settings of all hosts that differ from the ones in bios.yaml
+# airshipctl baremetal configure-bios --all --config bios.yaml --dry-run
+
+Apply BIOS settings from BIOSConfiguration document 'example-bios' of hardware profile function to hosts with
+a label 'foo=bar'
+# airshipctl baremetal configure-bios --labels "foo=bar" \
+  --config manifests/function/hardwareprofile-example/bios --config-name example-bios
+`
+)
+
+// NewConfigureBIOSCommand provides a command to apply BIOS settings to baremetal hosts.
+func NewConfigureBIOSCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	c := inventory.NewConfigureBIOSCommand(options)
+	cmd := &cobra.Command{
+		Use:     configureBIOSCommand,
+		Short:   "Airshipctl command to apply BIOS settings to bare metal host(s)",
+		Long:    configureBIOSLong[1:],
+		Example: configureBIOSExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.Writer = cmd.OutOrStdout()
+			return c.RunE()
+		},
+	}
+
+	initFlags(options, cmd)
+	initBatchFlags(options, cmd)
+	flags := cmd.Flags()
+	flags.StringVar(&c.ConfigPath, "config", "",
+		"path to YAML file or kustomization directory with BIOSConfiguration document")
+	flags.StringVar(&c.ConfigName, "config-name", "",
+		"name of BIOSConfiguration document, required if the path contains several of them")
+	flags.BoolVar(&c.DryRun, "dry-run", false, "print BIOS settings that differ from the desired ones without "+
+		"changing them")
+
+	return cmd
+}
@@ -43,12 +43,12 @@ func NewEjectMediaCommand(cfgFactory config.Factory, options *inventory.CommandO
 		Example: ejectMediaExample,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationEjectVirtualMedia)
+			return options.BMHAction(ifc.BaremetalOperationEjectVirtualMedia, cmd.OutOrStdout())
 		},
 	}
 
 	initFlags(options, cmd)
-	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
@@ -0,0 +1,75 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+)
+
+var (
+	firmwareCommand = "firmware"
+
+	firmwareLong = fmt.Sprintf(`
+Update firmware of bare metal host(s) to known versions. The desired versions of the firmware components, e.g.
+BMC, BIOS or NIC firmware, and the images providing them are read from FirmwareConfiguration document and
+compared with the firmware inventory of every host. Only the components which versions differ are updated, the
+BMC downloads every image and the command waits until the BMC completes the update. Some components apply the new
+firmware on the next reboot of the host only. Firmware updates take a while, so --timeout should be big enough
+for all the hosts. Use --dry-run to print the components to update without updating them. %s
+`, selectorsDescription)
+
+	firmwareExample = `
+Print firmware components of all hosts which versions differ from the ones in firmware.yaml
+# airshipctl baremetal firmware --all --config firmware.yaml --dry-run
+
+Update firmware of hosts with a label 'foo=bar' to the versions from FirmwareConfiguration document
+'example-firmware' of hardware profile function, 5 hosts at a time
+# airshipctl baremetal firmware --labels "foo=bar" --concurrency 5 --timeout 2h \
+  --config manifests/function/hardwareprofile-example/firmware --config-name example-firmware
+`
+)
+
+// NewFirmwareCommand provides a command to update firmware of baremetal hosts.
+func NewFirmwareCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	c := inventory.NewFirmwareUpdateCommand(options)
+	cmd := &cobra.Command{
+		Use:     firmwareCommand,
+		Short:   "Airshipctl command to update firmware of bare metal host(s)",
+		Long:    firmwareLong[1:],
+		Example: firmwareExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.Writer = cmd.OutOrStdout()
+			return c.RunE()
+		},
+	}
+
+	initFlags(options, cmd)
+	initBatchFlags(options, cmd)
+	flags := cmd.Flags()
+	flags.StringVar(&c.ConfigPath, "config", "",
+		"path to YAML file or kustomization directory with FirmwareConfiguration document")
+	flags.StringVar(&c.ConfigName, "config-name", "",
+		"name of FirmwareConfiguration document, required if the path contains several of them")
+	flags.BoolVar(&c.DryRun, "dry-run", false, "print firmware components which versions differ from the "+
+		"desired ones without updating them")
+
+	return cmd
+}
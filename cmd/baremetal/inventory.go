@@ -0,0 +1,68 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+)
+
+var (
+	inventoryCommand = "inventory"
+	inventoryLong    = `
+Retrieve hardware inventory of bare metal host(s) from their BMCs: model, serial number, BIOS and BMC firmware
+versions, processors, memory, network interfaces, disks and health. The MAC addresses of the network interfaces
+are compared with the bootMACAddress of the BareMetalHost document, the command fails if the boot MAC address
+doesn't belong to the host, which usually means that the BMC address of another host is specified in the document
+or the host is cabled wrong. The table output summarizes the hardware, use 'yaml' or 'json' output to get the
+details of every network interface and disk.
+`
+	inventoryExample = `
+Retrieve hardware inventory of all hosts defined in inventory
+# airshipctl baremetal inventory --all
+
+Retrieve hardware inventory of hosts with a label 'foo=bar' in yaml format
+# airshipctl baremetal inventory --labels "foo=bar" --output yaml
+
+Retrieve hardware inventory of host with name rdm9r3s3 in metal3 namespace in json format
+# airshipctl baremetal inventory --name rdm9r3s3 --namespace metal3 -o json
+`
+)
+
+// NewInventoryCommand provides a command to retrieve hardware inventory of baremetal hosts.
+func NewInventoryCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	c := inventory.NewHardwareInventoryCommand(options)
+	cmd := &cobra.Command{
+		Use:     inventoryCommand,
+		Short:   "Airshipctl command to retrieve hardware inventory of bare metal host(s)",
+		Long:    inventoryLong[1:],
+		Example: inventoryExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.Writer = cmd.OutOrStdout()
+			return c.RunE()
+		},
+	}
+
+	initFlags(options, cmd)
+	initBatchFlags(options, cmd)
+	flags := cmd.Flags()
+	flags.StringVarP(&c.OutputFormat, "output", "o", inventory.TableOutputFormat,
+		"output formats. Supported options are 'table', 'yaml' and 'json'")
+
+	return cmd
+}
@@ -43,12 +43,12 @@ func NewPowerOffCommand(cfgFactory config.Factory, options *inventory.CommandOpt
 		Example: powerOffExample,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationPowerOff)
+			return options.BMHAction(ifc.BaremetalOperationPowerOff, cmd.OutOrStdout())
 		},
 	}
 
 	initFlags(options, cmd)
-	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
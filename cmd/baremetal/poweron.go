@@ -43,12 +43,12 @@ func NewPowerOnCommand(cfgFactory config.Factory, options *inventory.CommandOpti
 		Example: powerOnExample,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationPowerOn)
+			return options.BMHAction(ifc.BaremetalOperationPowerOn, cmd.OutOrStdout())
 		},
 	}
 
 	initFlags(options, cmd)
-	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
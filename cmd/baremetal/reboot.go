@@ -43,12 +43,12 @@ func NewRebootCommand(cfgFactory config.Factory, options *inventory.CommandOptio
 		Example: rebootExample,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationReboot)
+			return options.BMHAction(ifc.BaremetalOperationReboot, cmd.OutOrStdout())
 		},
 	}
 
 	initFlags(options, cmd)
-	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
@@ -0,0 +1,40 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+)
+
+const bundleLong = `
+Provides commands to export site manifests along with everything needed to deploy them into a single
+tarball and to import the tarball on a site without network access.
+`
+
+// NewBundleCommand creates a command for exporting and importing offline bundles
+func NewBundleCommand(cfgFactory config.Factory) *cobra.Command {
+	bundleRootCmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Airshipctl command to export and import offline bundles of site manifests",
+		Long:  bundleLong[1:],
+	}
+
+	bundleRootCmd.AddCommand(NewExportCommand(cfgFactory))
+	bundleRootCmd.AddCommand(NewImportCommand(cfgFactory))
+
+	return bundleRootCmd
+}
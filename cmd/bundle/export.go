@@ -0,0 +1,63 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/bundle"
+	"opendev.org/airship/airshipctl/pkg/config"
+)
+
+const (
+	exportLong = `
+Exports the manifest of the current context into the gzipped tarball specified by BUNDLE_PATH.
+The tarball contains:
+  metadata.yaml   manifest of the current context without repository credentials
+  repositories/   manifest repositories checked out under the target path, including git metadata
+  phases/         documents rendered from the document entry point of each phase
+  providers/      cluster API provider components built by Clusterctl executors
+  images.txt      container images required by the phases, see 'airshipctl phase images'
+Repositories must be pulled with 'airshipctl document pull' before the export. Encrypted documents
+are exported as is.
+`
+
+	exportExample = `
+Export the manifest of the current context
+# airshipctl bundle export /tmp/site-bundle.tar.gz
+`
+)
+
+// NewExportCommand creates a command to export the manifest of the current context into a tarball
+func NewExportCommand(cfgFactory config.Factory) *cobra.Command {
+	c := &bundle.ExportCommand{
+		Options: bundle.ExportFlags{},
+		Factory: cfgFactory,
+	}
+	exportCmd := &cobra.Command{
+		Use:     "export BUNDLE_PATH",
+		Short:   "Airshipctl command to export site manifests into offline bundle",
+		Long:    exportLong[1:],
+		Args:    cobra.ExactArgs(1),
+		Example: exportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.Options.Path = args[0]
+			c.Writer = cmd.OutOrStdout()
+			return c.RunE()
+		},
+	}
+
+	return exportCmd
+}
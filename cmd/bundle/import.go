@@ -0,0 +1,66 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/bundle"
+	"opendev.org/airship/airshipctl/pkg/config"
+)
+
+const (
+	importLong = `
+Imports the tarball created by 'airshipctl bundle export' and specified by BUNDLE_PATH. Manifest
+repositories are extracted under the target path the same way 'airshipctl document pull' lays them
+out, the rest of the bundle is extracted into the airship-bundle directory of the target path.
+The exported manifest is added to the airshipctl config with the repositories pointing to the
+extracted directories and the current context is switched to it, so the phases can be run with no
+network access. Container images listed in airship-bundle/images.txt are expected to be available
+in the local registry, see 'airshipctl plan run --image-registry'.
+`
+
+	importExample = `
+Import the bundle under the target path of the current context
+# airshipctl bundle import /tmp/site-bundle.tar.gz
+
+Import the bundle under the /opt/airship directory
+# airshipctl bundle import /tmp/site-bundle.tar.gz --target-path /opt/airship
+`
+)
+
+// NewImportCommand creates a command to import offline bundle and point the current context to it
+func NewImportCommand(cfgFactory config.Factory) *cobra.Command {
+	c := &bundle.ImportCommand{
+		Options: bundle.ImportFlags{},
+		Factory: cfgFactory,
+	}
+	importCmd := &cobra.Command{
+		Use:     "import BUNDLE_PATH",
+		Short:   "Airshipctl command to import offline bundle of site manifests",
+		Long:    importLong[1:],
+		Args:    cobra.ExactArgs(1),
+		Example: importExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.Options.Path = args[0]
+			c.Writer = cmd.OutOrStdout()
+			return c.RunE()
+		},
+	}
+
+	importCmd.Flags().StringVar(&c.Options.TargetPath, "target-path", "",
+		"directory to import the bundle into, defaults to the target path of the current context")
+	return importCmd
+}
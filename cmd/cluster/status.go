@@ -17,28 +17,54 @@ package cluster
 import (
 	"github.com/spf13/cobra"
 
+	"opendev.org/airship/airshipctl/pkg/cluster"
 	"opendev.org/airship/airshipctl/pkg/config"
-	"opendev.org/airship/airshipctl/pkg/errors"
+)
+
+const (
+	statusLong = `
+Retrieves statuses of the components deployed to the clusters defined in the cluster map.
+Kubeconfig of the clusters is built from the sources specified in the cluster map.
+
+The status includes readiness of Cluster API objects: Clusters, KubeadmControlPlanes and MachineDeployments,
+phases of Machines, readiness of Nodes and provisioning states of BareMetalHosts.
+A cluster which can't be reached is reported with an error, it doesn't affect other clusters.
+`
+	statusExample = `
+Retrieve statuses of all clusters of the site
+# airshipctl cluster status
+
+Retrieve status of the target-cluster in yaml format
+# airshipctl cluster status --cluster target-cluster -o yaml
+`
 )
 
 // NewStatusCommand creates a command which reports the statuses of a cluster's deployed components.
 func NewStatusCommand(cfgFactory config.Factory) *cobra.Command {
-	var kubeconfig string
+	opts := &cluster.StatusCommand{}
 	statusCmd := &cobra.Command{
-		Use:   "status",
-		Short: "Retrieve statuses of deployed cluster components",
-		RunE:  clusterStatusRunE,
+		Use:     "status",
+		Short:   "Retrieve statuses of deployed cluster components",
+		Long:    statusLong[1:],
+		Example: statusExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.RunE(cfgFactory, cmd.OutOrStdout())
+		},
 	}
 
-	statusCmd.Flags().StringVar(
-		&kubeconfig,
-		"kubeconfig",
-		"",
-		"Path to kubeconfig associated with cluster being managed")
+	flags := statusCmd.Flags()
+	flags.StringSliceVar(
+		&opts.ClusterNames,
+		"cluster",
+		nil,
+		"name of the cluster to retrieve status of, can be repeated. Statuses of all clusters are retrieved if not set")
+	flags.StringVarP(
+		&opts.Format,
+		"output",
+		"o",
+		"table",
+		"output format. Supported options are 'table' and 'yaml'")
 
 	return statusCmd
 }
-
-func clusterStatusRunE(cmd *cobra.Command, args []string) error {
-	return errors.ErrNotImplemented{What: "Cluster Status"}
-}
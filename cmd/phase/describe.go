@@ -0,0 +1,58 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	describeLong = `
+Describes the phase specified by the mandatory parameter PHASE_NAME. The output combines the description
+of the phase with the description provided by its executor, e.g. the resources applied to the cluster,
+the providers installed by clusterctl, the hosts targeted by baremetal operation or the container image.
+To list the phases associated with a site, run 'airshipctl phase list'.
+`
+
+	describeExample = `
+Describe initinfra phase
+# airshipctl phase describe initinfra
+`
+)
+
+// NewDescribeCommand creates a command to describe the phase and the actions performed by its executor
+func NewDescribeCommand(cfgFactory config.Factory) *cobra.Command {
+	p := &phase.DescribeCommand{
+		Options: phase.DescribeFlags{},
+		Factory: cfgFactory,
+	}
+	describeCmd := &cobra.Command{
+		Use:     "describe PHASE_NAME",
+		Short:   "Airshipctl command to describe phase and the actions performed by its executor",
+		Long:    describeLong[1:],
+		Args:    cobra.ExactArgs(1),
+		Example: describeExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p.Options.PhaseID.Name = args[0]
+			p.Writer = cmd.OutOrStdout()
+			return p.RunE()
+		},
+	}
+
+	return describeCmd
+}
@@ -0,0 +1,81 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	imagesLong = `
+Lists container images used by the phase specified by PHASE_NAME or by all phases of the plan
+specified by the --plan flag, including the hook phases of the plan. Phases are rendered from their
+document entry points without accessing clusters, images are collected from containers of workloads,
+GenericContainer executors, containers running Clusterctl, KubernetesApply and HelmRelease executors,
+image overrides of Clusterctl executors and VersionsCatalogues. The list is sorted and contains every
+image once, so it can be used to populate a private registry for air-gapped deployments. If
+--image-registry flag is set, each image is followed by its reference in that registry, the same
+reference 'airshipctl phase render', 'airshipctl phase run' and 'airshipctl plan run' use with this
+flag. Images built locally, i.e. referenced from localhost, and images already in the registry
+aren't copied there, so they are left out.
+`
+
+	imagesExample = `
+List images of the initinfra phase
+# airshipctl phase images initinfra
+
+List images of all phases of the deploy-gating plan
+# airshipctl phase images --plan deploy-gating
+
+List images of the plan along with their references in the private registry
+# airshipctl phase images --plan deploy-gating --image-registry registry.local:5000
+`
+)
+
+// NewImagesCommand creates a command to list container images used by the phase or plan
+func NewImagesCommand(cfgFactory config.Factory) *cobra.Command {
+	p := &phase.ImagesCommand{
+		Options: phase.ImagesFlags{},
+		Factory: cfgFactory,
+	}
+	imagesCmd := &cobra.Command{
+		Use:     "images [PHASE_NAME]",
+		Short:   "Airshipctl command to list container images used by phase or plan",
+		Long:    imagesLong[1:],
+		Args:    cobra.MaximumNArgs(1),
+		Example: imagesExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				p.Options.PhaseID.Name = args[0]
+			}
+			p.Writer = cmd.OutOrStdout()
+			return p.RunE()
+		},
+	}
+	addImagesFlags(&p.Options, imagesCmd)
+	return imagesCmd
+}
+
+// addImagesFlags adds flags for phase images sub-command
+func addImagesFlags(options *phase.ImagesFlags, cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.StringVar(&options.PlanID.Name, "plan", "", "list images of all phases of the plan")
+	flags.StringVar(&options.ImageRegistry, "image-registry", "",
+		"print reference of each image in this registry next to the image")
+}
@@ -40,6 +40,8 @@ func NewPhaseCommand(cfgFactory config.Factory) *cobra.Command {
 	phaseRootCmd.AddCommand(NewTreeCommand(cfgFactory))
 	phaseRootCmd.AddCommand(NewValidateCommand(cfgFactory))
 	phaseRootCmd.AddCommand(NewStatusCommand(cfgFactory))
+	phaseRootCmd.AddCommand(NewDescribeCommand(cfgFactory))
+	phaseRootCmd.AddCommand(NewImagesCommand(cfgFactory))
 
 	return phaseRootCmd
 }
@@ -38,6 +38,9 @@ Get all documents from config bundle
 
 Get all documents executor rendered documents for a phase
 # airshipctl phase render initinfra --source executor
+
+Get all 'initinfra' phase documents with container images pulled from the private registry
+# airshipctl phase render initinfra --image-registry registry.local:5000
 `
 )
 
@@ -73,6 +76,8 @@ func addRenderFlags(filterOptions *phase.RenderCommand, cmd *cobra.Command) {
 			"config: this will render bundle containing phase and executor documents")
 	flags.BoolVarP(&filterOptions.FailOnDecryptionError, "decrypt", "d", false,
 		"ensure that decryption of encrypted documents has finished successfully")
+	flags.StringVar(&filterOptions.ImageRegistry, "image-registry", "",
+		"rewrite container image references of rendered documents to pull images from this registry")
 }
 
 // RenderArgs returns an error if there are not exactly n args.
@@ -32,6 +32,9 @@ To list the phases associated with a site, run 'airshipctl phase list'.
 	runExample = `
 Run initinfra phase
 # airshipctl phase run ephemeral-control-plane
+
+Run initinfra phase pulling container images from a private registry
+# airshipctl phase run initinfra --image-registry registry.local:5000
 `
 )
 
@@ -55,6 +58,8 @@ func NewRunCommand(cfgFactory config.Factory) *cobra.Command {
 					p.Options.DryRun = f.DryRun
 				case "wait-timeout":
 					p.Options.Timeout = &f.Timeout
+				case "image-registry":
+					p.Options.ImageRegistry = f.ImageRegistry
 				}
 			}
 			cmd.Flags().Visit(fn)
@@ -64,5 +69,7 @@ func NewRunCommand(cfgFactory config.Factory) *cobra.Command {
 	flags := runCmd.Flags()
 	flags.BoolVar(&f.DryRun, "dry-run", false, "simulate phase execution")
 	flags.DurationVar(&f.Timeout, "wait-timeout", 0, "wait timeout")
+	flags.StringVar(&f.ImageRegistry, "image-registry", "",
+		"pull container images of the deployed documents and executors from this registry")
 	return runCmd
 }
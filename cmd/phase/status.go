@@ -29,6 +29,9 @@ To list the phases associated with a site, run 'airshipctl phase list'.
 	statusExample = `
 Status of initinfra phase
 # airshipctl phase status ephemeral-control-plane
+
+Status of initinfra phase in json format
+# airshipctl phase status ephemeral-control-plane -o json
 `
 )
 
@@ -47,8 +50,12 @@ func NewStatusCommand(cfgFactory config.Factory) *cobra.Command {
 		Example: statusExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ph.Options.PhaseID.Name = args[0]
+			ph.Writer = cmd.OutOrStdout()
 			return ph.RunE()
 		},
 	}
+	flags := statusCmd.Flags()
+	flags.StringVarP(&ph.Options.FormatType, "output", "o", "table",
+		"output format. Supported formats are 'table', 'yaml' and 'json'")
 	return statusCmd
 }
@@ -36,6 +36,7 @@ func NewPlanCommand(cfgFactory config.Factory) *cobra.Command {
 
 	planRootCmd.AddCommand(NewListCommand(cfgFactory))
 	planRootCmd.AddCommand(NewRunCommand(cfgFactory))
+	planRootCmd.AddCommand(NewStatusCommand(cfgFactory))
 	planRootCmd.AddCommand(NewValidateCommand(cfgFactory))
 
 	return planRootCmd
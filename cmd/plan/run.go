@@ -33,6 +33,15 @@ Run plan named iso
 
 Perform a dry run of a plan
 # airshipctl plan run iso --dry-run
+
+Resume plan named iso from the first phase that didn't succeed during the last run
+# airshipctl plan run iso --resume
+
+Run plan named iso executing at most two independent phases at a time
+# airshipctl plan run iso --max-parallel 2
+
+Run plan named iso pulling container images from a private registry
+# airshipctl plan run iso --image-registry registry.local:5000
 `
 )
 
@@ -55,8 +64,16 @@ func NewRunCommand(cfgFactory config.Factory) *cobra.Command {
 					r.Options.DryRun = f.DryRun
 				case "wait-timeout":
 					r.Options.Timeout = &f.Timeout
+				case "image-registry":
+					r.Options.ImageRegistry = f.ImageRegistry
 				case "resume-from":
 					r.Options.ResumeFromPhase = f.ResumeFromPhase
+				case "resume":
+					r.Options.Resume = f.Resume
+				case "force":
+					r.Options.Force = f.Force
+				case "max-parallel":
+					r.Options.MaxParallel = f.MaxParallel
 				}
 			}
 			cmd.Flags().Visit(fn)
@@ -66,7 +83,15 @@ func NewRunCommand(cfgFactory config.Factory) *cobra.Command {
 
 	flags := runCmd.Flags()
 	flags.StringVar(&f.ResumeFromPhase, "resume-from", "", "skip all phases before the specified one")
+	flags.BoolVar(&f.Resume, "resume", false,
+		"resume execution from the first phase that didn't succeed during the last run")
+	flags.BoolVar(&f.Force, "force", false,
+		"resume even if the plan or documents of completed phases have changed since the last run")
+	flags.IntVar(&f.MaxParallel, "max-parallel", 0,
+		"maximum number of independent phases executed concurrently, 0 means no limit")
 	flags.BoolVar(&f.DryRun, "dry-run", false, "simulate phase execution")
 	flags.DurationVar(&f.Timeout, "wait-timeout", 0, "wait timeout")
+	flags.StringVar(&f.ImageRegistry, "image-registry", "",
+		"pull container images of the deployed documents and executors from this registry")
 	return runCmd
 }
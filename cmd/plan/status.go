@@ -0,0 +1,63 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plan
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/phase"
+)
+
+const (
+	statusLong = `
+Show the execution state of the phases recorded during the last run of the plan.
+Specify the plan using the mandatory parameter PLAN_NAME.
+To get list of plans associated for a site, run 'airshipctl plan list'.
+`
+
+	statusExample = `
+Show status of plan named iso
+# airshipctl plan status iso
+
+Show status of plan named iso(yaml output format)
+# airshipctl plan status iso -o yaml
+
+Show status of plan named iso(json output format)
+# airshipctl plan status iso -o json
+`
+)
+
+// NewStatusCommand creates a command which prints execution state of the phase plan
+func NewStatusCommand(cfgFactory config.Factory) *cobra.Command {
+	s := &phase.PlanStatusCommand{Factory: cfgFactory}
+
+	statusCmd := &cobra.Command{
+		Use:     "status PLAN_NAME",
+		Short:   "Airshipctl command to show status of the plan",
+		Long:    statusLong[1:],
+		Example: statusExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s.Options.PlanID.Name = args[0]
+			s.Writer = cmd.OutOrStdout()
+			return s.RunE()
+		},
+	}
+	flags := statusCmd.Flags()
+	flags.StringVarP(&s.Options.FormatType, "output", "o", "table",
+		"output format. Supported formats are 'table', 'yaml' and 'json'")
+	return statusCmd
+}
@@ -23,6 +23,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"opendev.org/airship/airshipctl/cmd/baremetal"
+	"opendev.org/airship/airshipctl/cmd/bundle"
 	"opendev.org/airship/airshipctl/cmd/cluster"
 	"opendev.org/airship/airshipctl/cmd/completion"
 	"opendev.org/airship/airshipctl/cmd/config"
@@ -77,6 +78,7 @@ func NewRootCommand(out io.Writer) (*cobra.Command, *RootOptions) {
 // default commands to airshipctl
 func AddDefaultAirshipCTLCommands(cmd *cobra.Command, factory cfg.Factory) *cobra.Command {
 	cmd.AddCommand(baremetal.NewBaremetalCommand(factory))
+	cmd.AddCommand(bundle.NewBundleCommand(factory))
 	cmd.AddCommand(cluster.NewClusterCommand(factory))
 	cmd.AddCommand(completion.NewCompletionCommand())
 	cmd.AddCommand(document.NewDocumentCommand(factory))
@@ -45,6 +45,8 @@ import (
 
 const (
 	airshipNamespace = "airshipit"
+	// actionDelete destroys objects of the inventory instead of applying the documents
+	actionDelete = "delete"
 )
 
 // Config is an extension of ApplyConfig struct with added streams
@@ -104,7 +106,6 @@ func (c *Config) Run(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
 		return nil, err
 	}
 
-	applier, err := apply.NewApplier(f, invClient, statusPoller)
 	inv, obj, err := inventory.SplitUnstructureds(objs)
 	if err != nil {
 		klog.V(2).Infoln("injecting auto generated inventory object")
@@ -114,6 +115,15 @@ func (c *Config) Run(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
 		}
 	}
 
+	if c.Action == actionDelete {
+		return nil, c.destroy(f, invClient, statusPoller, inv)
+	}
+
+	applier, err := apply.NewApplier(f, invClient, statusPoller)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := c.toCliOptions()
 	err = printers.GetPrinter(printers.DefaultPrinter(), c.Streams).Print(
 		applier.Run(context.Background(), inventory.WrapInventoryInfoObj(inv), obj, opts), opts.DryRunStrategy, true)
@@ -122,7 +132,48 @@ func (c *Config) Run(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
 	return nil, err
 }
 
+// destroy deletes all objects stored in the inventory in reverse dependency order, the inventory is
+// looked up in the cluster by its label, so the auto generated one matches the inventory created by apply
+func (c *Config) destroy(f cmdutil.Factory, invClient inventory.InventoryClient, statusPoller *poller.StatusPoller,
+	inv *unstructured.Unstructured) error {
+	destroyer, err := apply.NewDestroyer(f, invClient, statusPoller)
+	if err != nil {
+		return err
+	}
+
+	opts := c.toDestroyerOptions()
+	err = printers.GetPrinter(printers.DefaultPrinter(), c.Streams).Print(
+		destroyer.Run(context.Background(), inventory.WrapInventoryInfoObj(inv), opts), opts.DryRunStrategy, true)
+	klog.V(2).Infoln("destroyer channel closed")
+	errors.CheckErr(c.Streams.ErrOut, err, "destroyer")
+	return err
+}
+
 func (c *Config) toCliOptions() apply.Options {
+	dryRunStrategy, timeout, pollInterval, emitStatusEvents, inventoryPolicy := c.commonOptions()
+	return apply.Options{
+		DryRunStrategy:   dryRunStrategy,
+		NoPrune:          !c.PruneOptions.Prune,
+		EmitStatusEvents: emitStatusEvents,
+		ReconcileTimeout: timeout,
+		PollInterval:     pollInterval,
+		InventoryPolicy:  inventoryPolicy,
+	}
+}
+
+func (c *Config) toDestroyerOptions() apply.DestroyerOptions {
+	dryRunStrategy, timeout, pollInterval, emitStatusEvents, inventoryPolicy := c.commonOptions()
+	return apply.DestroyerOptions{
+		DryRunStrategy:   dryRunStrategy,
+		EmitStatusEvents: emitStatusEvents,
+		DeleteTimeout:    timeout,
+		PollInterval:     pollInterval,
+		InventoryPolicy:  inventoryPolicy,
+	}
+}
+
+func (c *Config) commonOptions() (common.DryRunStrategy, time.Duration, time.Duration, bool,
+	inventory.InventoryPolicy) {
 	dryRunStrategy := common.DryRunNone
 	if c.DryRun {
 		dryRunStrategy = common.DryRunClient
@@ -141,15 +192,7 @@ func (c *Config) toCliOptions() apply.Options {
 	if err != nil {
 		klog.V(2).Infof("%s or force-adopt, using the default one (strict)", err.Error())
 	}
-
-	return apply.Options{
-		DryRunStrategy:   dryRunStrategy,
-		NoPrune:          !c.PruneOptions.Prune,
-		EmitStatusEvents: emitStatusEvents,
-		ReconcileTimeout: timeout,
-		PollInterval:     pollInterval,
-		InventoryPolicy:  inventoryPolicy,
-	}
+	return dryRunStrategy, timeout, pollInterval, emitStatusEvents, inventoryPolicy
 }
 
 func main() {
@@ -21,7 +21,11 @@ import (
 )
 
 // An Expression is used to find information about a kubernetes resource. It
-// evaluates to a boolean when matched against a resource.
+// evaluates to a boolean when matched against a resource. Values of any type are
+// compared using their default format, so booleans and numbers can be matched too.
+//
+// Expression is copied to pkg/k8s/kstatus of airshipctl to compute status of the
+// resources outside of the applier container, both copies must be kept in sync.
 type Expression struct {
 	// A Condition describes a JSONPath filter which is matched against an
 	// array containing a single resource.
@@ -54,7 +58,7 @@ func (e *Expression) Match(obj map[string]interface{}) (bool, error) {
 	}
 
 	if e.Value != "" {
-		return len(results[0]) == 1 && fmt.Sprintf("%s", results[0][0].Interface()) == e.Value, nil
+		return len(results[0]) == 1 && fmt.Sprintf("%v", results[0][0].Interface()) == e.Value, nil
 	}
 
 	return len(results[0]) == 1, nil
@@ -28,6 +28,7 @@ type ApplyConfig struct {
 	Debug           bool              `json:"debug,omitempty"`
 	PhaseName       string            `json:"phaseName,omitempty"`
 	InventoryPolicy string            `json:"inventoryPolicy,omitempty"`
+	Action          string            `json:"action,omitempty"`
 }
 
 // ApplyWaitOptions provides instructions how to wait for kubernetes resources
@@ -0,0 +1,5 @@
+module opendev.org/airship/airshipctl/krm-functions/helm/image
+
+go 1.16
+
+require sigs.k8s.io/kustomize/kyaml v0.11.0
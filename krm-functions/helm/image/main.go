@@ -0,0 +1,88 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/fn/framework"
+	"sigs.k8s.io/kustomize/kyaml/fn/framework/command"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	helm       = "helm"
+	valuesFile = "values.yaml"
+
+	filePerm = 0644
+)
+
+// HelmOptions holds all necessary data to run helm inside of KRM
+type HelmOptions struct {
+	CmdOptions []string `json:"cmd-options,omitempty"`
+	Values     []byte   `json:"values,omitempty"`
+	Template   bool     `json:"template,omitempty"`
+}
+
+// Run writes values of the release and executes helm with appropriate options, documents printed by helm
+// are returned if template is requested
+func (h *HelmOptions) Run([]*yaml.RNode) ([]*yaml.RNode, error) {
+	if len(h.Values) > 0 {
+		if err := ioutil.WriteFile(valuesFile, h.Values, filePerm); err != nil {
+			return nil, err
+		}
+	}
+
+	if !h.Template {
+		return nil, runCmd(helm, h.CmdOptions, os.Stderr)
+	}
+
+	out := &bytes.Buffer{}
+	if err := runCmd(helm, h.CmdOptions, out); err != nil {
+		return nil, err
+	}
+	return (&kio.ByteReader{Reader: out, OmitReaderAnnotations: true}).Read()
+}
+
+func runCmd(cmd string, opts []string, out io.Writer) error {
+	printMsg("#%s %s\n", cmd, strings.Join(opts, " "))
+	c := exec.Command(cmd, opts...)
+	c.Stdout = out
+	// allows to observe realtime output from script
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// printMsg is a convenient function to print output to stderr
+func printMsg(format string, a ...interface{}) {
+	if _, err := fmt.Fprintf(os.Stderr, format, a...); err != nil {
+	}
+}
+
+func main() {
+	cfg := &HelmOptions{}
+	if err := command.Build(framework.SimpleProcessor{Filter: kio.FilterFunc(cfg.Run), Config: cfg},
+		command.StandaloneDisabled, false).Execute(); err != nil {
+		printMsg("\n")
+		os.Exit(1)
+	}
+}
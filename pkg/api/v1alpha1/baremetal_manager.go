@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,11 +36,17 @@ type BaremetalManagerSpec struct {
 	OperationOptions BaremetalOperationOptions `json:"operationOptions"`
 	// Timeout in seconds
 	Timeout int `json:"timeout"`
+	// Concurrency is the maximum number of hosts the operation is performed against at the same time
+	Concurrency int `json:"concurrency,omitempty"`
+	// FailFast stops performing the operation against the remaining hosts once it fails against any host
+	FailFast bool `json:"failFast,omitempty"`
 }
 
 // BaremetalOperationOptions hold operation options
 type BaremetalOperationOptions struct {
-	RemoteDirect RemoteDirectOptions `json:"remoteDirect"`
+	RemoteDirect   RemoteDirectOptions   `json:"remoteDirect"`
+	ConfigureBIOS  ConfigureBIOSOptions  `json:"configureBIOS,omitempty"`
+	FirmwareUpdate FirmwareUpdateOptions `json:"firmwareUpdate,omitempty"`
 }
 
 // RemoteDirectOptions holds configuration for remote direct operation
@@ -47,6 +54,19 @@ type RemoteDirectOptions struct {
 	ISOURL string `json:"isoURL"`
 }
 
+// ConfigureBIOSOptions holds configuration for configure bios operation
+type ConfigureBIOSOptions struct {
+	// ConfigRef references BIOSConfiguration document with the desired BIOS settings among the phase documents
+	ConfigRef *v1.ObjectReference `json:"configRef,omitempty"`
+}
+
+// FirmwareUpdateOptions holds configuration for firmware update operation
+type FirmwareUpdateOptions struct {
+	// ConfigRef references FirmwareConfiguration document with the desired firmware versions among the phase
+	// documents
+	ConfigRef *v1.ObjectReference `json:"configRef,omitempty"`
+}
+
 // BaremetalHostSelector allows to select a host by label selector, by name and namespace
 type BaremetalHostSelector struct {
 	LabelSelector string `json:"labelSelector"`
@@ -68,9 +88,13 @@ const (
 	BaremetalOperationRemoteDirect BaremetalOperation = "remote-direct"
 	// BaremetalOperationEjectVirtualMedia eject virtual media
 	BaremetalOperationEjectVirtualMedia BaremetalOperation = "eject-virtual-media"
+	// BaremetalOperationConfigureBIOS apply BIOS settings and reboot
+	BaremetalOperationConfigureBIOS BaremetalOperation = "configure-bios"
+	// BaremetalOperationFirmwareUpdate update firmware components to the desired versions
+	BaremetalOperationFirmwareUpdate BaremetalOperation = "firmware-update"
 )
 
 // DefaultBaremetalManager returns BaremetalManager executor document with default values
 func DefaultBaremetalManager() *BaremetalManager {
-	return &BaremetalManager{Spec: BaremetalManagerSpec{Timeout: 300}}
+	return &BaremetalManager{Spec: BaremetalManagerSpec{Timeout: 300, Concurrency: 10}}
 }
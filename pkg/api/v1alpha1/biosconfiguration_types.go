@@ -0,0 +1,50 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// BIOSConfiguration describes desired BIOS settings of baremetal hosts, it's applied by configure-bios
+// operation of BaremetalManager executor
+type BIOSConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BIOSConfigurationSpec `json:"spec"`
+}
+
+// BIOSConfigurationSpec holds desired BIOS attributes and persistent boot order
+type BIOSConfigurationSpec struct {
+	// NOTE: controller-gen doesn't support map[string]interface{},
+	// so we'll need to allow arbitrary JSON here and unmarshal it
+	// in the operation
+
+	// Attributes maps names of BIOS attributes to their desired values. The names and the values are vendor
+	// specific, they are the same as in the Attributes of Redfish Bios resource of the host
+	Attributes *v1.JSON `json:"attributes,omitempty"`
+	// BootOrder is the desired persistent boot order as reported by the BMC, e.g. Boot0001,
+	// the boot order is left intact if it's empty
+	BootOrder []string `json:"bootOrder,omitempty"`
+}
+
+// DefaultBIOSConfiguration can be used to safely unmarshal BIOSConfiguration object without nil pointers
+func DefaultBIOSConfiguration() *BIOSConfiguration {
+	return &BIOSConfiguration{}
+}
@@ -29,6 +29,12 @@ type Clusterctl struct {
 	Action      ActionType   `json:"action,omitempty"`
 	InitOptions *InitOptions `json:"init-options,omitempty"`
 	MoveOptions *MoveOptions `json:"move-options,omitempty"`
+	// DeleteOptions are used by delete action, providers to delete are taken from init options
+	DeleteOptions *DeleteOptions `json:"delete-options,omitempty"`
+	// DescribeOptions are used by describe action
+	DescribeOptions *DescribeOptions `json:"describe-options,omitempty"`
+	// ConfigClusterOptions are used by config-cluster action
+	ConfigClusterOptions *ConfigClusterOptions `json:"config-cluster-options,omitempty"`
 	// AdditionalComponentVariables are variables that will be available to clusterctl
 	// when reading provider components
 	AdditionalComponentVariables map[string]string    `json:"additional-vars,omitempty"`
@@ -92,6 +98,16 @@ type ActionType string
 const (
 	Init ActionType = "init"
 	Move ActionType = "move"
+	// Upgrade upgrades providers to the versions from init options, versions of the providers
+	// built from kustomize entrypoints are taken from the entrypoint path if init options omit them
+	Upgrade ActionType = "upgrade"
+	// Delete deletes providers listed in init options from the cluster
+	Delete ActionType = "delete"
+	// Describe shows Cluster API objects of the workload cluster, it doesn't change the cluster
+	Describe ActionType = "describe"
+	// ConfigCluster prints the workload cluster rendered from the cluster template of the infrastructure
+	// provider listed in init options, it doesn't change the cluster
+	ConfigCluster ActionType = "config-cluster"
 )
 
 // MoveOptions carries the options supported by move.
@@ -101,13 +117,56 @@ type MoveOptions struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// DeleteOptions carries the options supported by delete.
+type DeleteOptions struct {
+	// All deletes all the providers installed in the cluster instead of the ones listed in init options
+	All bool `json:"all,omitempty"`
+	// IncludeNamespace deletes namespaces the providers are installed in
+	IncludeNamespace bool `json:"include-namespace,omitempty"`
+	// IncludeCRDs deletes CRDs of the providers along with all the objects of those kinds
+	IncludeCRDs bool `json:"include-crd,omitempty"`
+}
+
+// DescribeOptions carries the options supported by describe.
+type DescribeOptions struct {
+	// Cluster is the name of the Cluster API cluster to describe, the name of the phase cluster is used
+	// if it's empty
+	Cluster string `json:"cluster,omitempty"`
+	// Namespace of the Cluster API cluster. If unspecified, the current namespace will be used.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ConfigClusterOptions carries the options supported by config cluster.
+type ConfigClusterOptions struct {
+	// Cluster is the name of the rendered Cluster API cluster, the name of the phase cluster is used
+	// if it's empty
+	Cluster string `json:"cluster,omitempty"`
+	// Template is a kustomize entrypoint relative to target path of the manifest, it's put into the
+	// provider repositories next to the components of the infrastructure provider as its cluster template.
+	// If unspecified, the template is taken from the repository of the provider.
+	Template string `json:"template,omitempty"`
+	// Flavor of the cluster template, the template without flavor is used if unspecified
+	Flavor string `json:"flavor,omitempty"`
+	// KubernetesVersion of the workload cluster, e.g. v1.21.2
+	KubernetesVersion string `json:"kubernetes-version,omitempty"`
+	// TargetNamespace of the workload cluster objects. If unspecified, the current namespace will be used.
+	TargetNamespace string `json:"target-namespace,omitempty"`
+	// ControlPlaneMachineCount is the number of control plane machines, clusterctl default is used if it's 0
+	ControlPlaneMachineCount int `json:"control-plane-machine-count,omitempty"`
+	// WorkerMachineCount is the number of worker machines, clusterctl default is used if it's 0
+	WorkerMachineCount int `json:"worker-machine-count,omitempty"`
+}
+
 // DefaultClusterctl can be used to safely unmarshal Clusterctl object without nil pointers
 func DefaultClusterctl() *Clusterctl {
 	return &Clusterctl{
-		InitOptions: &InitOptions{},
-		MoveOptions: &MoveOptions{},
-		Providers:   make([]*Provider, 0),
-		ImageMetas:  make(map[string]ImageMeta),
+		InitOptions:          &InitOptions{},
+		MoveOptions:          &MoveOptions{},
+		DeleteOptions:        &DeleteOptions{},
+		DescribeOptions:      &DescribeOptions{},
+		ConfigClusterOptions: &ConfigClusterOptions{},
+		Providers:            make([]*Provider, 0),
+		ImageMetas:           make(map[string]ImageMeta),
 	}
 }
 
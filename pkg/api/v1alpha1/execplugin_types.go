@@ -0,0 +1,98 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// ExecPlugin executor delegates phase operations to an external binary, every operation is a single
+// invocation of the binary with ExecPluginRequest written to its stdin and ExecPluginResponse read from its stdout
+type ExecPlugin struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExecPluginSpec `json:"spec"`
+
+	// Config is passed to the plugin as is with every request
+	Config *v1.JSON `json:"config,omitempty"`
+}
+
+// ExecPluginSpec defines the plugin binary and its environment
+type ExecPluginSpec struct {
+	// Command is the plugin binary, relative path is resolved against the target path of the site,
+	// command name without path separators is looked up in PATH
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	// EnvVars are added to the plugin environment in KEY=VALUE format
+	EnvVars []string `json:"envVars,omitempty"`
+	// Timeout of a single plugin invocation in seconds, 0 means no timeout
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// ExecPluginOperation is the phase operation requested from the plugin
+type ExecPluginOperation string
+
+const (
+	// ExecPluginRun runs the phase
+	ExecPluginRun ExecPluginOperation = "run"
+	// ExecPluginRender returns documents of the phase
+	ExecPluginRender ExecPluginOperation = "render"
+	// ExecPluginValidate validates the plugin config and documents
+	ExecPluginValidate ExecPluginOperation = "validate"
+	// ExecPluginStatus returns status of the phase
+	ExecPluginStatus ExecPluginOperation = "status"
+	// ExecPluginDescribe returns human readable description of the phase actions
+	ExecPluginDescribe ExecPluginOperation = "describe"
+)
+
+// +kubebuilder:object:generate=false
+
+// ExecPluginRequest is written to the plugin stdin as JSON
+type ExecPluginRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Operation ExecPluginOperation `json:"operation"`
+	// PhaseName and ClusterName identify the phase the plugin is run for
+	PhaseName   string `json:"phaseName"`
+	ClusterName string `json:"clusterName,omitempty"`
+	// Config of the ExecPlugin executor document
+	Config *v1.JSON `json:"config,omitempty"`
+	// Documents is the executor bundle of the phase in YAML format
+	Documents string `json:"documents,omitempty"`
+	// Kubeconfig is the path to the kubeconfig file, it's passed to run and status operations of the phases
+	// that have cluster name defined
+	Kubeconfig  string `json:"kubeconfig,omitempty"`
+	KubeContext string `json:"kubeContext,omitempty"`
+	DryRun      bool   `json:"dryRun,omitempty"`
+	// Timeout of the run operation in seconds
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+
+// ExecPluginResponse is read from the plugin stdout as JSON
+type ExecPluginResponse struct {
+	// Error fails the operation if it's not empty
+	Error string `json:"error,omitempty"`
+	// Output is written to the output of the run operation, contains documents in YAML format for the render
+	// operation and description of the phase for the describe operation
+	Output string `json:"output,omitempty"`
+	// Status is returned by the status operation
+	Status *ExecutorStatus `json:"status,omitempty"`
+}
@@ -0,0 +1,53 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// FirmwareConfiguration describes desired firmware versions of baremetal hosts, it's applied by firmware-update
+// operation of BaremetalManager executor
+type FirmwareConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FirmwareConfigurationSpec `json:"spec"`
+}
+
+// FirmwareConfigurationSpec holds desired versions of firmware components
+type FirmwareConfigurationSpec struct {
+	// Firmware lists the firmware components to keep at the desired versions, the components which versions
+	// differ are updated in the listed order
+	Firmware []FirmwareTarget `json:"firmware"`
+}
+
+// FirmwareTarget is the desired version of a firmware component and the image providing it
+type FirmwareTarget struct {
+	// Component is either ID or name of the firmware component as reported in the firmware inventory of the
+	// BMC, e.g. BIOS
+	Component string `json:"component"`
+	// Version is the desired version of the component as reported in the firmware inventory of the BMC
+	Version string `json:"version"`
+	// ImageURL is the URL the BMC downloads the firmware image from, it must be reachable by the BMC
+	ImageURL string `json:"imageURL"`
+}
+
+// DefaultFirmwareConfiguration can be used to safely unmarshal FirmwareConfiguration object without nil pointers
+func DefaultFirmwareConfiguration() *FirmwareConfiguration {
+	return &FirmwareConfiguration{}
+}
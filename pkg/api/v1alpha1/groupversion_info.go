@@ -53,6 +53,11 @@ func init() {
 		&BootConfiguration{},
 		&GenericContainer{},
 		&BaremetalManager{},
+		&BIOSConfiguration{},
+		&FirmwareConfiguration{},
+		&ExecPlugin{},
+		&HelmRelease{},
+		&Wait{},
 		&ManifestMetadata{},
 	)
 	_ = AddToScheme(Scheme) //nolint:errcheck
@@ -0,0 +1,102 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// HelmRelease installs, upgrades or uninstalls a release of the Helm chart defined in the versions catalogue
+type HelmRelease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HelmReleaseSpec `json:"spec"`
+}
+
+// HelmReleaseSpec defines the release and the action performed when the phase is run
+type HelmReleaseSpec struct {
+	// Action is performed when the phase is run, install upgrades the release if it's already installed
+	Action HelmReleaseAction `json:"action,omitempty"`
+	// ReleaseName is the name of the release, name of the executor document is used if it's empty
+	ReleaseName     string `json:"releaseName,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	CreateNamespace bool   `json:"createNamespace,omitempty"`
+	// Chart references the chart in the versions catalogue
+	Chart HelmChartReference `json:"chart"`
+	// ValuesFrom references ConfigMaps and Secrets of the phase bundle containing values of the release,
+	// values are merged in the order of the references
+	ValuesFrom []HelmValuesReference `json:"valuesFrom,omitempty"`
+	// Values take precedence over the values of the referenced documents
+	Values *v1.JSON `json:"values,omitempty"`
+	// Wait for the resources of the release to become ready
+	Wait bool `json:"wait,omitempty"`
+	// Timeout of the helm operation in seconds
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// HelmChartReference references the chart in the versions catalogue
+type HelmChartReference struct {
+	// Catalogue is the name of the VersionsCatalogue document in the phase bundle
+	Catalogue string `json:"catalogue,omitempty"`
+	// Name is the key of the chart in the charts section of the catalogue. The chart repository is
+	// the helm_repositories entry named after the source reference of the chart, or after the chart key
+	// if the source reference isn't set
+	Name string `json:"name"`
+}
+
+// HelmValuesReference references a document of the phase bundle containing values of the release
+type HelmValuesReference struct {
+	// Kind is either ConfigMap or Secret
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// ValuesKey is the data key holding the values, values.yaml is used by default
+	ValuesKey string `json:"valuesKey,omitempty"`
+}
+
+// HelmReleaseAction is the action performed with the release
+type HelmReleaseAction string
+
+const (
+	// HelmReleaseInstall installs the release or upgrades it if it's already installed
+	HelmReleaseInstall HelmReleaseAction = "install"
+	// HelmReleaseUpgrade upgrades the installed release
+	HelmReleaseUpgrade HelmReleaseAction = "upgrade"
+	// HelmReleaseUninstall uninstalls the release
+	HelmReleaseUninstall HelmReleaseAction = "uninstall"
+)
+
+// HelmOptions holds all necessary data to run helm inside of KRM
+type HelmOptions struct {
+	CmdOptions []string `json:"cmd-options,omitempty"`
+	// Values are written to the values file referenced by the command options
+	Values []byte `json:"values,omitempty"`
+	// Template makes the function return documents printed by helm
+	Template bool `json:"template,omitempty"`
+}
+
+// DefaultHelmRelease can be used to safely unmarshal HelmRelease object without nil pointers
+func DefaultHelmRelease() *HelmRelease {
+	return &HelmRelease{
+		Spec: HelmReleaseSpec{
+			Action:  HelmReleaseInstall,
+			Timeout: 300,
+			Chart:   HelmChartReference{Catalogue: "versions-airshipctl"},
+		},
+	}
+}
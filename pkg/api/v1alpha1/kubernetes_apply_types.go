@@ -36,7 +36,12 @@ type ApplyConfig struct {
 	Context      string            `json:"context,omitempty"`
 	DryRun       bool              `json:"druRun,omitempty"`
 	Debug        bool              `json:"debug,omitempty"`
-	PhaseName    string            `json:"phaseName,omitempty"`
+	// PhaseName identifies the inventory created by the applier if the bundle doesn't define one,
+	// the name of the phase is used if it's empty. Delete phases set it to the name of the phase
+	// which applied the resources to destroy them
+	PhaseName string `json:"phaseName,omitempty"`
+	// Action is performed when the phase is run, apply is used if it's empty
+	Action ApplyAction `json:"action,omitempty"`
 
 	// InventoryPolicy defines if an inventory object can take over objects that belong to another
 	// inventory object or don't belong to any inventory object. Possible values are:
@@ -44,6 +49,17 @@ type ApplyConfig struct {
 	InventoryPolicy string `json:"inventoryPolicy,omitempty"`
 }
 
+// ApplyAction is the action performed with resources of the phase
+type ApplyAction string
+
+const (
+	// ApplyActionApply applies resources of the bundle and records them in the inventory
+	ApplyActionApply ApplyAction = "apply"
+	// ApplyActionDelete destroys all resources stored in the inventory in reverse dependency order
+	// and the inventory itself, wait options define how long to wait for the resources to be deleted
+	ApplyActionDelete ApplyAction = "delete"
+)
+
 // ApplyWaitOptions provides instructions how to wait for kubernetes resources
 type ApplyWaitOptions struct {
 	// Timeout in seconds
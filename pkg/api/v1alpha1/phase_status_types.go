@@ -0,0 +1,119 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StatusState describes state of the phase or of a single resource managed by the phase
+type StatusState string
+
+const (
+	// StatusCurrent means that the desired state is reached
+	StatusCurrent StatusState = "Current"
+	// StatusInProgress means that the desired state is not reached yet
+	StatusInProgress StatusState = "InProgress"
+	// StatusFailed means that the desired state can't be reached
+	StatusFailed StatusState = "Failed"
+	// StatusTerminating means that the resource is being deleted
+	StatusTerminating StatusState = "Terminating"
+	// StatusNotFound means that nothing has been deployed by the phase yet
+	StatusNotFound StatusState = "NotFound"
+	// StatusUnknown means that the state can't be determined
+	StatusUnknown StatusState = "Unknown"
+)
+
+// DriftStatus shows whether the resource differs from the rendered document bundle of the phase
+type DriftStatus string
+
+const (
+	// DriftInSync means that the resource matches the document from the bundle
+	DriftInSync DriftStatus = "InSync"
+	// DriftDrifted means that the resource differs from the document from the bundle
+	DriftDrifted DriftStatus = "Drifted"
+	// DriftNotInBundle means that the resource was deployed earlier but is not present in the bundle anymore
+	DriftNotInBundle DriftStatus = "NotInBundle"
+	// DriftMissing means that the document from the bundle isn't deployed
+	DriftMissing DriftStatus = "Missing"
+)
+
+// StatusCondition describes a single aspect of the phase or resource state
+type StatusCondition struct {
+	Type               string       `json:"type"`
+	Status             string       `json:"status"`
+	Reason             string       `json:"reason,omitempty"`
+	Message            string       `json:"message,omitempty"`
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ExecutorStatus describes the status reported by the phase executor
+type ExecutorStatus struct {
+	// State is the overall state of the phase
+	State              StatusState       `json:"state"`
+	Message            string            `json:"message,omitempty"`
+	LastTransitionTime *metav1.Time      `json:"lastTransitionTime,omitempty"`
+	Conditions         []StatusCondition `json:"conditions,omitempty"`
+	// Resources contains status of the resources managed by the executor
+	Resources []ResourceStatus `json:"resources,omitempty"`
+	// Details contains executor specific information
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// ResourceStatus object describes the status of a single resource managed by the phase
+type ResourceStatus struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Exists is true if the resource is found
+	Exists     bool              `json:"exists"`
+	State      StatusState       `json:"state"`
+	Drift      DriftStatus       `json:"drift,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Conditions []StatusCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PhaseStatus object describes the status of the phase
+type PhaseStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Executor is the kind of the phase executor
+	Executor       string `json:"executor,omitempty"`
+	ExecutorStatus `json:",inline"`
+}
+
+// DefaultPhaseStatus returns status object of the phase with unknown state
+func DefaultPhaseStatus(phase *Phase) *PhaseStatus {
+	status := &PhaseStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersion.String(),
+			Kind:       "PhaseStatus",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      phase.Name,
+			Namespace: phase.Namespace,
+		},
+		ExecutorStatus: ExecutorStatus{
+			State: StatusUnknown,
+		},
+	}
+	if phase.Config.ExecutorRef != nil {
+		status.Executor = phase.Config.ExecutorRef.Kind
+	}
+	return status
+}
@@ -25,7 +25,9 @@ import (
 type Phase struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Config            PhaseConfig `json:"config,omitempty"`
+	// Description is a human readable explanation of the purpose of the phase
+	Description string      `json:"description,omitempty"`
+	Config      PhaseConfig `json:"config,omitempty"`
 }
 
 // PhaseConfig represents configuration for a particular phase. It contains a reference to
@@ -0,0 +1,90 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PhaseRunResult describes outcome of the phase execution within a phase plan run
+type PhaseRunResult string
+
+const (
+	// PhaseRunPending means that phase execution hasn't been started yet
+	PhaseRunPending PhaseRunResult = "Pending"
+	// PhaseRunRunning means that phase is being executed or the run was interrupted
+	PhaseRunRunning PhaseRunResult = "Running"
+	// PhaseRunSucceeded means that phase has been executed successfully
+	PhaseRunSucceeded PhaseRunResult = "Succeeded"
+	// PhaseRunFailed means that phase execution returned an error
+	PhaseRunFailed PhaseRunResult = "Failed"
+	// PhaseRunSkipped means that phase was skipped during the run
+	PhaseRunSkipped PhaseRunResult = "Skipped"
+)
+
+// +kubebuilder:object:root=true
+
+// PhasePlanRun object stores execution state of the phase plan run
+type PhasePlanRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// PlanHash is a sha256 sum of the phase plan definition used for the run
+	PlanHash  string       `json:"planHash,omitempty"`
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	EndTime   *metav1.Time `json:"endTime,omitempty"`
+	Phases    []PhaseRun   `json:"phases,omitempty"`
+	// OnFailure contains execution state of the phases executed after the plan failure
+	OnFailure []PhaseRun `json:"onFailure,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PhaseRun object stores execution state of a single phase within a phase plan run
+type PhaseRun struct {
+	metav1.TypeMeta `json:",inline"`
+	Name            string         `json:"name"`
+	Namespace       string         `json:"namespace,omitempty"`
+	Result          PhaseRunResult `json:"result"`
+	StartTime       *metav1.Time   `json:"startTime,omitempty"`
+	EndTime         *metav1.Time   `json:"endTime,omitempty"`
+	Error           string         `json:"error,omitempty"`
+	// Attempts is the number of phase executions made during the run
+	Attempts int `json:"attempts,omitempty"`
+	// BundleHash is a sha256 sum of the phase document bundle rendered right before execution
+	BundleHash string `json:"bundleHash,omitempty"`
+}
+
+// DefaultPhasePlanRun returns phase plan run object with all plan phases pending
+func DefaultPhasePlanRun(plan *PhasePlan) *PhasePlanRun {
+	run := &PhasePlanRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersion.String(),
+			Kind:       "PhasePlanRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plan.Name,
+			Namespace: plan.Namespace,
+		},
+		Phases: make([]PhaseRun, len(plan.Phases)),
+	}
+	for i, step := range plan.Phases {
+		run.Phases[i] = PhaseRun{
+			Name:      step.Name,
+			Namespace: step.Namespace,
+			Result:    PhaseRunPending,
+		}
+	}
+	return run
+}
@@ -27,10 +27,51 @@ type PhasePlan struct {
 	Description       string           `json:"description,omitempty"`
 	Phases            []PhaseStep      `json:"phases,omitempty"`
 	ValidationCfg     ValidationConfig `json:"validation"`
+	// OnFailure is a list of phase names executed one by one if any phase of the plan fails
+	OnFailure []string `json:"onFailure,omitempty"`
 }
 
 // PhaseStep represents phase (or step) within a phase plan
 type PhaseStep struct {
 	Name      string `json:"name,omitempty"`
 	Namespace string `json:"namespace,omitempty"`
+	// DependsOn is a list of phase names within the plan which must succeed before this phase is executed.
+	// If none of the plan phases defines dependencies, phases are executed sequentially in the listed order
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Retry defines how many times the phase is executed before it is considered failed
+	Retry *PhaseRetry `json:"retry,omitempty"`
+	// Timeout overrides timeout given in the run options for this phase, e.g. 30m
+	Timeout string `json:"timeout,omitempty"`
+	// ContinueOnError allows the plan to proceed if the phase fails
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	// When defines condition which must be met for the phase to be executed, otherwise the phase is skipped
+	When *PhaseCondition `json:"when,omitempty"`
+	// Pre is a list of phase names executed one by one before the phase
+	Pre []string `json:"pre,omitempty"`
+	// Post is a list of phase names executed one by one after the phase succeeds
+	Post []string `json:"post,omitempty"`
+}
+
+// PhaseRetry defines retry policy of the phase within a phase plan
+type PhaseRetry struct {
+	// Attempts is the maximum number of phase executions including the first one
+	Attempts int `json:"attempts,omitempty"`
+	// Backoff is a delay before the second attempt, e.g. 30s. The delay is doubled after every failed attempt
+	Backoff string `json:"backoff,omitempty"`
+}
+
+// PhaseCondition defines condition of the phase execution, the condition is met when all of
+// the specified checks pass
+type PhaseCondition struct {
+	// Cluster is a name of the cluster which must be defined in the site cluster map
+	Cluster string `json:"cluster,omitempty"`
+	// EnvVar is an environment variable which must be set
+	EnvVar *EnvVarCondition `json:"envVar,omitempty"`
+}
+
+// EnvVarCondition defines environment variable check of the phase condition
+type EnvVarCondition struct {
+	Name string `json:"name"`
+	// Value of the environment variable, if it's empty the variable must be set to any non-empty value
+	Value string `json:"value,omitempty"`
 }
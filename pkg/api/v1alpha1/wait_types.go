@@ -0,0 +1,69 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// Wait executor waits for kubernetes resources of the phase cluster to reach the desired state
+type Wait struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WaitSpec `json:"spec"`
+}
+
+// WaitSpec defines resources to wait for and how long to wait
+type WaitSpec struct {
+	// Resources to wait for, the phase is completed when all selected resources reach the desired state
+	Resources []WaitResource `json:"resources"`
+	// Timeout in seconds
+	Timeout int `json:"timeout,omitempty"`
+	// PollInterval in seconds
+	PollInterval int `json:"pollInterval,omitempty"`
+}
+
+// WaitResource selects kubernetes resources by apiVersion and kind along with name or label selector.
+// At least one resource must match the selector, and every matched resource must be current according to
+// kstatus and must match all the conditions
+type WaitResource struct {
+	metav1.TypeMeta `json:",inline"`
+	Namespace       string `json:"namespace,omitempty"`
+	// Name selects a single resource, resources are selected by label selector if the name is empty
+	Name          string `json:"name,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Conditions are checked once the resource is current according to kstatus
+	Conditions []WaitCondition `json:"conditions,omitempty"`
+}
+
+// WaitCondition is a jsonpath which indicates what state of the resource to wait for
+type WaitCondition struct {
+	JSONPath string `json:"jsonPath"`
+	// Value is desired state to wait for, if no value specified - just existence of provided jsonPath will be checked
+	Value string `json:"value,omitempty"`
+}
+
+// DefaultWait can be used to safely unmarshal Wait object without nil pointers
+func DefaultWait() *Wait {
+	return &Wait{
+		Spec: WaitSpec{
+			Timeout:      3600,
+			PollInterval: 10,
+		},
+	}
+}
@@ -169,6 +169,57 @@ func (in *ApplyWaitOptions) DeepCopy() *ApplyWaitOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BIOSConfiguration) DeepCopyInto(out *BIOSConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOSConfiguration.
+func (in *BIOSConfiguration) DeepCopy() *BIOSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BIOSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BIOSConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BIOSConfigurationSpec) DeepCopyInto(out *BIOSConfigurationSpec) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootOrder != nil {
+		in, out := &in.BootOrder, &out.BootOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOSConfigurationSpec.
+func (in *BIOSConfigurationSpec) DeepCopy() *BIOSConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BIOSConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaremetalHostSelector) DeepCopyInto(out *BaremetalHostSelector) {
 	*out = *in
@@ -189,7 +240,7 @@ func (in *BaremetalManager) DeepCopyInto(out *BaremetalManager) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalManager.
@@ -214,7 +265,7 @@ func (in *BaremetalManager) DeepCopyObject() runtime.Object {
 func (in *BaremetalManagerSpec) DeepCopyInto(out *BaremetalManagerSpec) {
 	*out = *in
 	out.HostSelector = in.HostSelector
-	out.OperationOptions = in.OperationOptions
+	in.OperationOptions.DeepCopyInto(&out.OperationOptions)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalManagerSpec.
@@ -231,6 +282,8 @@ func (in *BaremetalManagerSpec) DeepCopy() *BaremetalManagerSpec {
 func (in *BaremetalOperationOptions) DeepCopyInto(out *BaremetalOperationOptions) {
 	*out = *in
 	out.RemoteDirect = in.RemoteDirect
+	in.ConfigureBIOS.DeepCopyInto(&out.ConfigureBIOS)
+	in.FirmwareUpdate.DeepCopyInto(&out.FirmwareUpdate)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalOperationOptions.
@@ -462,6 +515,21 @@ func (in *Clusterctl) DeepCopyInto(out *Clusterctl) {
 		*out = new(MoveOptions)
 		**out = **in
 	}
+	if in.DeleteOptions != nil {
+		in, out := &in.DeleteOptions, &out.DeleteOptions
+		*out = new(DeleteOptions)
+		**out = **in
+	}
+	if in.DescribeOptions != nil {
+		in, out := &in.DescribeOptions, &out.DescribeOptions
+		*out = new(DescribeOptions)
+		**out = **in
+	}
+	if in.ConfigClusterOptions != nil {
+		in, out := &in.ConfigClusterOptions, &out.ConfigClusterOptions
+		*out = new(ConfigClusterOptions)
+		**out = **in
+	}
 	if in.AdditionalComponentVariables != nil {
 		in, out := &in.AdditionalComponentVariables, &out.AdditionalComponentVariables
 		*out = make(map[string]string, len(*in))
@@ -544,6 +612,71 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigClusterOptions) DeepCopyInto(out *ConfigClusterOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigClusterOptions.
+func (in *ConfigClusterOptions) DeepCopy() *ConfigClusterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigClusterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigureBIOSOptions) DeepCopyInto(out *ConfigureBIOSOptions) {
+	*out = *in
+	if in.ConfigRef != nil {
+		in, out := &in.ConfigRef, &out.ConfigRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigureBIOSOptions.
+func (in *ConfigureBIOSOptions) DeepCopy() *ConfigureBIOSOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigureBIOSOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeleteOptions) DeepCopyInto(out *DeleteOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteOptions.
+func (in *DeleteOptions) DeepCopy() *DeleteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DescribeOptions) DeepCopyInto(out *DescribeOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DescribeOptions.
+func (in *DescribeOptions) DeepCopy() *DescribeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DescribeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndPointSpec) DeepCopyInto(out *EndPointSpec) {
 	*out = *in
@@ -559,6 +692,21 @@ func (in *EndPointSpec) DeepCopy() *EndPointSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarCondition) DeepCopyInto(out *EnvVarCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarCondition.
+func (in *EnvVarCondition) DeepCopy() *EnvVarCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralCluster) DeepCopyInto(out *EphemeralCluster) {
 	*out = *in
@@ -574,6 +722,102 @@ func (in *EphemeralCluster) DeepCopy() *EphemeralCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecPlugin) DeepCopyInto(out *ExecPlugin) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecPlugin.
+func (in *ExecPlugin) DeepCopy() *ExecPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExecPlugin) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecPluginSpec) DeepCopyInto(out *ExecPluginSpec) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecPluginSpec.
+func (in *ExecPluginSpec) DeepCopy() *ExecPluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecPluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutorStatus) DeepCopyInto(out *ExecutorStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]StatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutorStatus.
+func (in *ExecutorStatus) DeepCopy() *ExecutorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileProperties) DeepCopyInto(out *FileProperties) {
 	*out = *in
@@ -621,30 +865,234 @@ func (in FileSpec) DeepCopy() FileSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GenericContainer) DeepCopyInto(out *GenericContainer) {
+func (in *FirmwareConfiguration) DeepCopyInto(out *FirmwareConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareConfiguration.
+func (in *FirmwareConfiguration) DeepCopy() *FirmwareConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FirmwareConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareConfigurationSpec) DeepCopyInto(out *FirmwareConfigurationSpec) {
+	*out = *in
+	if in.Firmware != nil {
+		in, out := &in.Firmware, &out.Firmware
+		*out = make([]FirmwareTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareConfigurationSpec.
+func (in *FirmwareConfigurationSpec) DeepCopy() *FirmwareConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareTarget) DeepCopyInto(out *FirmwareTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareTarget.
+func (in *FirmwareTarget) DeepCopy() *FirmwareTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareUpdateOptions) DeepCopyInto(out *FirmwareUpdateOptions) {
+	*out = *in
+	if in.ConfigRef != nil {
+		in, out := &in.ConfigRef, &out.ConfigRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareUpdateOptions.
+func (in *FirmwareUpdateOptions) DeepCopy() *FirmwareUpdateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareUpdateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericContainer) DeepCopyInto(out *GenericContainer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ConfigRef != nil {
+		in, out := &in.ConfigRef, &out.ConfigRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericContainer.
+func (in *GenericContainer) DeepCopy() *GenericContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GenericContainer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericContainerSpec) DeepCopyInto(out *GenericContainerSpec) {
+	*out = *in
+	in.Airship.DeepCopyInto(&out.Airship)
+	out.KRM = in.KRM
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageMounts != nil {
+		in, out := &in.StorageMounts, &out.StorageMounts
+		*out = make([]StorageMount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericContainerSpec.
+func (in *GenericContainerSpec) DeepCopy() *GenericContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GetKubeconfigOptions) DeepCopyInto(out *GetKubeconfigOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GetKubeconfigOptions.
+func (in *GetKubeconfigOptions) DeepCopy() *GetKubeconfigOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GetKubeconfigOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Gvk) DeepCopyInto(out *Gvk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gvk.
+func (in *Gvk) DeepCopy() *Gvk {
+	if in == nil {
+		return nil
+	}
+	out := new(Gvk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmChartReference) DeepCopyInto(out *HelmChartReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmChartReference.
+func (in *HelmChartReference) DeepCopy() *HelmChartReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmChartReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmOptions) DeepCopyInto(out *HelmOptions) {
+	*out = *in
+	if in.CmdOptions != nil {
+		in, out := &in.CmdOptions, &out.CmdOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmOptions.
+func (in *HelmOptions) DeepCopy() *HelmOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmRelease) DeepCopyInto(out *HelmRelease) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	if in.ConfigRef != nil {
-		in, out := &in.ConfigRef, &out.ConfigRef
-		*out = new(v1.ObjectReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericContainer.
-func (in *GenericContainer) DeepCopy() *GenericContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmRelease.
+func (in *HelmRelease) DeepCopy() *HelmRelease {
 	if in == nil {
 		return nil
 	}
-	out := new(GenericContainer)
+	out := new(HelmRelease)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GenericContainer) DeepCopyObject() runtime.Object {
+func (in *HelmRelease) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -652,58 +1100,42 @@ func (in *GenericContainer) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GenericContainerSpec) DeepCopyInto(out *GenericContainerSpec) {
+func (in *HelmReleaseSpec) DeepCopyInto(out *HelmReleaseSpec) {
 	*out = *in
-	in.Airship.DeepCopyInto(&out.Airship)
-	out.KRM = in.KRM
-	if in.EnvVars != nil {
-		in, out := &in.EnvVars, &out.EnvVars
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.StorageMounts != nil {
-		in, out := &in.StorageMounts, &out.StorageMounts
-		*out = make([]StorageMount, len(*in))
+	out.Chart = in.Chart
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]HelmValuesReference, len(*in))
 		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericContainerSpec.
-func (in *GenericContainerSpec) DeepCopy() *GenericContainerSpec {
-	if in == nil {
-		return nil
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(GenericContainerSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GetKubeconfigOptions) DeepCopyInto(out *GetKubeconfigOptions) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GetKubeconfigOptions.
-func (in *GetKubeconfigOptions) DeepCopy() *GetKubeconfigOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmReleaseSpec.
+func (in *HelmReleaseSpec) DeepCopy() *HelmReleaseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GetKubeconfigOptions)
+	out := new(HelmReleaseSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Gvk) DeepCopyInto(out *Gvk) {
+func (in *HelmValuesReference) DeepCopyInto(out *HelmValuesReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gvk.
-func (in *Gvk) DeepCopy() *Gvk {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmValuesReference.
+func (in *HelmValuesReference) DeepCopy() *HelmValuesReference {
 	if in == nil {
 		return nil
 	}
-	out := new(Gvk)
+	out := new(HelmValuesReference)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1399,6 +1831,26 @@ func (in *Phase) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseCondition) DeepCopyInto(out *PhaseCondition) {
+	*out = *in
+	if in.EnvVar != nil {
+		in, out := &in.EnvVar, &out.EnvVar
+		*out = new(EnvVarCondition)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseCondition.
+func (in *PhaseCondition) DeepCopy() *PhaseCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhaseConfig) DeepCopyInto(out *PhaseConfig) {
 	*out = *in
@@ -1428,9 +1880,16 @@ func (in *PhasePlan) DeepCopyInto(out *PhasePlan) {
 	if in.Phases != nil {
 		in, out := &in.Phases, &out.Phases
 		*out = make([]PhaseStep, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.ValidationCfg.DeepCopyInto(&out.ValidationCfg)
+	if in.OnFailure != nil {
+		in, out := &in.OnFailure, &out.OnFailure
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhasePlan.
@@ -1451,6 +1910,100 @@ func (in *PhasePlan) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhasePlanRun) DeepCopyInto(out *PhasePlanRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]PhaseRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OnFailure != nil {
+		in, out := &in.OnFailure, &out.OnFailure
+		*out = make([]PhaseRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhasePlanRun.
+func (in *PhasePlanRun) DeepCopy() *PhasePlanRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PhasePlanRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PhasePlanRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseRetry) DeepCopyInto(out *PhaseRetry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseRetry.
+func (in *PhaseRetry) DeepCopy() *PhaseRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseRun) DeepCopyInto(out *PhaseRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseRun.
+func (in *PhaseRun) DeepCopy() *PhaseRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PhaseRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhaseSpec) DeepCopyInto(out *PhaseSpec) {
 	*out = *in
@@ -1466,9 +2019,60 @@ func (in *PhaseSpec) DeepCopy() *PhaseSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseStatus) DeepCopyInto(out *PhaseStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.ExecutorStatus.DeepCopyInto(&out.ExecutorStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseStatus.
+func (in *PhaseStatus) DeepCopy() *PhaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PhaseStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhaseStep) DeepCopyInto(out *PhaseStep) {
 	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(PhaseRetry)
+		**out = **in
+	}
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = new(PhaseCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseStep.
@@ -1681,6 +2285,28 @@ func (in *ResID) DeepCopy() *ResID {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceStatus) DeepCopyInto(out *ResourceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]StatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceStatus.
+func (in *ResourceStatus) DeepCopy() *ResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -1727,6 +2353,25 @@ func (in *Service) DeepCopy() *Service {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCondition) DeepCopyInto(out *StatusCondition) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCondition.
+func (in *StatusCondition) DeepCopy() *StatusCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageMount) DeepCopyInto(out *StorageMount) {
 	*out = *in
@@ -1984,3 +2629,87 @@ func (in *VersionsCatalogues) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Wait) DeepCopyInto(out *Wait) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Wait.
+func (in *Wait) DeepCopy() *Wait {
+	if in == nil {
+		return nil
+	}
+	out := new(Wait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Wait) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitCondition) DeepCopyInto(out *WaitCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitCondition.
+func (in *WaitCondition) DeepCopy() *WaitCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitResource) DeepCopyInto(out *WaitResource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]WaitCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitResource.
+func (in *WaitResource) DeepCopy() *WaitResource {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitSpec) DeepCopyInto(out *WaitSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]WaitResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitSpec.
+func (in *WaitSpec) DeepCopy() *WaitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,47 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle
+
+import (
+	"opendev.org/airship/airshipctl/pkg/config"
+)
+
+// Layout of the bundle tarball
+const (
+	// MetadataFile describes the exported manifest, it is the first entry of the tarball
+	MetadataFile = "metadata.yaml"
+	// ImagesFile lists container images required by the phases, one per line
+	ImagesFile = "images.txt"
+	// RepositoriesDir holds manifest repositories, each one in the directory named the same way
+	// 'airshipctl document pull' names it under the target path
+	RepositoriesDir = "repositories"
+	// PhasesDir holds documents rendered from document entry points of the phases, one file per phase
+	PhasesDir = "phases"
+	// ProvidersDir holds cluster API provider components of Clusterctl executors laid out the same way
+	// as clusterctl overrides directory
+	ProvidersDir = "providers"
+)
+
+// ImportDir is the directory under the target path where everything but manifest repositories is
+// put by the import
+const ImportDir = "airship-bundle"
+
+// Metadata describes the exported bundle
+type Metadata struct {
+	// ManifestName is the name of the manifest used by the exported context
+	ManifestName string `json:"manifestName"`
+	// Manifest is the exported manifest, repository credentials are not exported
+	Manifest *config.Manifest `json:"manifest"`
+}
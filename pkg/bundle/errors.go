@@ -0,0 +1,55 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle
+
+import (
+	"fmt"
+)
+
+// ErrBundlePathNotSpecified returned when path to the bundle tarball is empty
+type ErrBundlePathNotSpecified struct{}
+
+func (e ErrBundlePathNotSpecified) Error() string {
+	return "path to the bundle must be specified"
+}
+
+// ErrRepositoryDirUnknown returned when directory of the manifest repository can't be derived from its URL
+type ErrRepositoryDirUnknown struct {
+	Name string
+	URL  string
+}
+
+func (e ErrRepositoryDirUnknown) Error() string {
+	return fmt.Sprintf("could not get directory of the repository '%s' from URL '%s'", e.Name, e.URL)
+}
+
+// ErrInvalidBundleEntry returned when bundle tarball has an entry pointing outside of the import directory
+type ErrInvalidBundleEntry struct {
+	Name string
+}
+
+func (e ErrInvalidBundleEntry) Error() string {
+	return fmt.Sprintf("bundle entry '%s' points outside of the import directory", e.Name)
+}
+
+// ErrMissingBundleMetadata returned when bundle tarball doesn't start with the metadata file
+type ErrMissingBundleMetadata struct {
+	Path string
+}
+
+func (e ErrMissingBundleMetadata) Error() string {
+	return fmt.Sprintf("bundle '%s' doesn't start with %s, is it created by 'airshipctl bundle export'?",
+		e.Path, MetadataFile)
+}
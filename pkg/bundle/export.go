@@ -0,0 +1,344 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/document/images"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+const (
+	clusterctlKind = "Clusterctl"
+
+	bundleFilePerm = 0600
+	entryFilePerm  = 0644
+)
+
+// ExportFlags options for bundle export command
+type ExportFlags struct {
+	// Path to the tarball to create
+	Path string
+}
+
+// ExportCommand bundle export command
+type ExportCommand struct {
+	Options ExportFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE writes manifest repositories of the current context, documents rendered for every phase, cluster API
+// provider components and the list of container images required by the phases into the gzipped tarball
+func (c *ExportCommand) RunE() error {
+	if c.Options.Path == "" {
+		return ErrBundlePathNotSpecified{}
+	}
+
+	// encrypted documents are exported as is, they are decrypted when the phases are run
+	os.Setenv("TOLERATE_DECRYPTION_FAILURES", "true")
+
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	currentContext, err := cfg.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+
+	manifest, err := cfg.CurrentContextManifest()
+	if err != nil {
+		return err
+	}
+
+	helper, err := phase.NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(c.Options.Path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, bundleFilePerm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	e := &exporter{tw: tar.NewWriter(gz), helper: helper}
+	if err = e.export(currentContext.Manifest, manifest); err != nil {
+		return err
+	}
+	if err = e.tw.Close(); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.Writer, "Bundle of the manifest '%s' is written to %s\n",
+		currentContext.Manifest, c.Options.Path)
+	return err
+}
+
+// exporter writes bundle content to the tarball
+type exporter struct {
+	tw     *tar.Writer
+	helper ifc.Helper
+}
+
+func (e *exporter) export(name string, manifest *config.Manifest) error {
+	if err := e.writeMetadata(name, manifest); err != nil {
+		return err
+	}
+	if err := e.writeRepositories(manifest); err != nil {
+		return err
+	}
+
+	docs, err := e.writePhases()
+	if err != nil {
+		return err
+	}
+
+	refs, err := images.Collect(docs)
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	for _, ref := range refs {
+		fmt.Fprintln(buf, ref)
+	}
+	return e.writeFile(ImagesFile, buf.Bytes())
+}
+
+// writeMetadata writes the manifest without repository credentials
+func (e *exporter) writeMetadata(name string, manifest *config.Manifest) error {
+	exported := *manifest
+	exported.Repositories = make(map[string]*config.Repository, len(manifest.Repositories))
+	for repoName, repo := range manifest.Repositories {
+		exportedRepo := *repo
+		exportedRepo.Auth = nil
+		exported.Repositories[repoName] = &exportedRepo
+	}
+
+	data, err := yaml.Marshal(Metadata{ManifestName: name, Manifest: &exported})
+	if err != nil {
+		return err
+	}
+	return e.writeFile(MetadataFile, data)
+}
+
+// writeRepositories writes checked out manifest repositories along with their git metadata
+func (e *exporter) writeRepositories(manifest *config.Manifest) error {
+	names := make([]string, 0, len(manifest.Repositories))
+	for name := range manifest.Repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	exported := map[string]bool{}
+	for _, name := range names {
+		repo := manifest.Repositories[name]
+		dir := util.GitDirNameFromURL(repo.URL())
+		if dir == "" {
+			return ErrRepositoryDirUnknown{Name: name, URL: repo.URL()}
+		}
+		if exported[dir] {
+			continue
+		}
+		exported[dir] = true
+
+		src := filepath.Join(manifest.GetTargetPath(), dir)
+		log.Printf("Exporting repository %s from %s", name, src)
+		if err := e.writeDir(src, path.Join(RepositoriesDir, dir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePhases writes documents rendered from document entry points of the phases and provider components
+// of Clusterctl executors, documents inspected for container images are returned
+func (e *exporter) writePhases() ([]document.Document, error) {
+	phases, err := e.helper.ListPhases(ifc.ListPhaseOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	client := phase.NewClient(e.helper)
+	providers := map[string]bool{}
+	var docs []document.Document
+	for _, p := range phases {
+		if p.Config.ExecutorRef != nil {
+			var executorDoc document.Document
+			executorDoc, err = e.helper.ExecutorDoc(ifc.ID{Name: p.Name, Namespace: p.Namespace})
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, executorDoc)
+			if err = e.writeProviders(executorDoc, providers); err != nil {
+				return nil, err
+			}
+		}
+
+		if p.Config.DocumentEntryPoint == "" {
+			continue
+		}
+		log.Printf("Exporting documents of the phase %s", p.Name)
+		var rendered []document.Document
+		if rendered, err = e.writePhase(client, p.Name, p.Namespace); err != nil {
+			return nil, err
+		}
+		docs = append(docs, rendered...)
+	}
+	return docs, nil
+}
+
+func (e *exporter) writePhase(client ifc.Client, name, namespace string) ([]document.Document, error) {
+	p, err := client.PhaseByID(ifc.ID{Name: name, Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.DocumentRoot()
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := document.NewBundleByPath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err = bundle.Write(buf); err != nil {
+		return nil, err
+	}
+	if namespace != "" {
+		name = namespace + "-" + name
+	}
+	if err = e.writeFile(path.Join(PhasesDir, name+".yaml"), buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return bundle.GetAllDocuments()
+}
+
+// writeProviders writes provider components built by Clusterctl executor, components shared by several
+// executors are written once
+func (e *exporter) writeProviders(executorDoc document.Document, written map[string]bool) error {
+	if executorDoc.GetKind() != clusterctlKind {
+		return nil
+	}
+
+	components, err := executors.ClusterctlComponents(executorDoc, e.helper.TargetPath())
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(components))
+	for p := range components {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		name := path.Join(ProvidersDir, filepath.ToSlash(p))
+		if written[name] {
+			continue
+		}
+		written[name] = true
+		if err = e.writeFile(name, []byte(components[p])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes generated file to the tarball
+func (e *exporter) writeFile(name string, data []byte) error {
+	if err := e.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     entryFilePerm,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := e.tw.Write(data)
+	return err
+}
+
+// writeDir writes content of the src directory to the dst directory of the tarball
+func (e *exporter) writeDir(src, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(dst, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err = e.tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(filepath.Clean(p))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(e.tw, f)
+		return err
+	})
+}
@@ -0,0 +1,172 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/bundle"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+const (
+	testSiteURL    = "https://opendev.org/airship/site.git"
+	testFactoryErr = "test config error"
+)
+
+// testConfig returns config with the current context pointing to the site under testdata
+func testConfig() *config.Config {
+	cfg := testutil.DummyConfig()
+	manifest := cfg.Manifests["dummy_manifest"]
+	manifest.TargetPath = "testdata"
+	manifest.MetadataPath = "metadata.yaml"
+	manifest.PhaseRepositoryName = config.DefaultTestPhaseRepo
+	manifest.Repositories = map[string]*config.Repository{
+		config.DefaultTestPhaseRepo: {
+			URLString: testSiteURL,
+			Auth: &config.RepoAuth{
+				Type:         config.HTTPBasic,
+				Username:     "deployer",
+				HTTPPassword: "secret",
+			},
+		},
+	}
+	return cfg
+}
+
+// readBundle returns names of the tarball entries in order and content of the regular files
+func readBundle(t *testing.T, path string) ([]string, map[string]string) {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var names []string
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err == io.EOF {
+			return names, files
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+		if hdr.Typeflag == tar.TypeReg {
+			var data []byte
+			data, err = ioutil.ReadAll(tr)
+			require.NoError(t, err)
+			files[hdr.Name] = string(data)
+		}
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		factory     config.Factory
+		errContains string
+	}{
+		{
+			name:        "Error bundle path not specified",
+			factory:     func() (*config.Config, error) { return testConfig(), nil },
+			errContains: bundle.ErrBundlePathNotSpecified{}.Error(),
+		},
+		{
+			name: "Error config factory",
+			path: "bundle.tgz",
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name: "Error unknown repository directory",
+			path: "bundle.tgz",
+			factory: func() (*config.Config, error) {
+				cfg := testConfig()
+				cfg.Manifests["dummy_manifest"].Repositories["extra"] = &config.Repository{}
+				return cfg, nil
+			},
+			errContains: "could not get directory of the repository 'extra'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path != "" {
+				path = filepath.Join(t.TempDir(), path)
+			}
+			cmd := bundle.ExportCommand{
+				Options: bundle.ExportFlags{Path: path},
+				Factory: tt.factory,
+				Writer:  ioutil.Discard,
+			}
+			err := cmd.RunE()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.tgz")
+	buf := &bytes.Buffer{}
+	cmd := bundle.ExportCommand{
+		Options: bundle.ExportFlags{Path: path},
+		Factory: func() (*config.Config, error) { return testConfig(), nil },
+		Writer:  buf,
+	}
+	require.NoError(t, cmd.RunE())
+	assert.Equal(t, fmt.Sprintf("Bundle of the manifest 'dummy_manifest' is written to %s\n", path), buf.String())
+
+	names, files := readBundle(t, path)
+	require.NotEmpty(t, names)
+	assert.Equal(t, bundle.MetadataFile, names[0])
+	assert.Contains(t, files[bundle.MetadataFile], "manifestName: dummy_manifest")
+	assert.Contains(t, files[bundle.MetadataFile], testSiteURL)
+	assert.NotContains(t, files[bundle.MetadataFile], "secret")
+
+	assert.Contains(t, names, "repositories/site/")
+	assert.Contains(t, files, "repositories/site/metadata.yaml")
+	assert.Contains(t, files, "repositories/site/phases/phases.yaml")
+
+	assert.Contains(t, files["phases/initinfra.yaml"], "name: ironic")
+	assert.NotContains(t, files, "phases/clusterctl-init.yaml")
+
+	assert.Contains(t, files, "providers/cluster-api/v0.3.3/metadata.yaml")
+	assert.Contains(t, files["providers/cluster-api/v0.3.3/core-components.yaml"], "name: capi-system")
+
+	assert.Equal(t, `gcr.io/k8s-staging-cluster-api/cluster-api-controller:v0.3.3
+quay.io/airshipit/ipa:latest
+quay.io/metal3-io/ironic:capm3-v0.4.0
+`, files[bundle.ImagesFile])
+}
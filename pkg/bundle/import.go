@@ -0,0 +1,244 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+const importDirPerm = 0750
+
+// ImportFlags options for bundle import command
+type ImportFlags struct {
+	// Path to the tarball created by bundle export
+	Path string
+	// TargetPath to lay out the bundle under, target path of the current context is used if empty
+	TargetPath string
+}
+
+// ImportCommand bundle import command
+type ImportCommand struct {
+	Options ImportFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE extracts manifest repositories of the bundle under the target path and the rest of the bundle
+// into the ImportDir of the target path. The exported manifest is added to the airship config with the
+// repositories pointing to the extracted directories and the current context is switched to it
+func (c *ImportCommand) RunE() error {
+	if c.Options.Path == "" {
+		return ErrBundlePathNotSpecified{}
+	}
+
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	currentContext, err := cfg.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+
+	targetPath := c.Options.TargetPath
+	if targetPath == "" {
+		if targetPath, err = cfg.CurrentContextTargetPath(); err != nil {
+			return err
+		}
+	}
+
+	meta, err := extract(c.Options.Path, targetPath)
+	if err != nil {
+		return err
+	}
+
+	manifest := meta.Manifest
+	manifest.TargetPath = targetPath
+	for _, repo := range manifest.Repositories {
+		// local directory is used as repository URL, so the directory name stays the same
+		repo.URLString = filepath.Join(targetPath, util.GitDirNameFromURL(repo.URL()))
+	}
+	cfg.Manifests[meta.ManifestName] = manifest
+	currentContext.Manifest = meta.ManifestName
+	if err = cfg.PersistConfig(true); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.Writer, "Bundle is imported into %s, context '%s' uses manifest '%s'\n",
+		targetPath, cfg.CurrentContext, meta.ManifestName)
+	return err
+}
+
+// extract lays out the bundle under the target path and returns its metadata
+func extract(bundlePath, targetPath string) (*Metadata, error) {
+	f, err := os.Open(filepath.Clean(bundlePath))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err == io.EOF || (err == nil && hdr.Name != MetadataFile) {
+		return nil, ErrMissingBundleMetadata{Path: bundlePath}
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(tr)
+	if err != nil {
+		return nil, err
+	}
+	meta := &Metadata{}
+	if err = yaml.Unmarshal(data, meta); err != nil {
+		return nil, err
+	}
+	if meta.Manifest == nil {
+		return nil, ErrMissingBundleMetadata{Path: bundlePath}
+	}
+
+	log.Printf("Importing bundle of the manifest %s into %s", meta.ManifestName, targetPath)
+	for {
+		if hdr, err = tr.Next(); err == io.EOF {
+			return meta, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var dst string
+		if dst, err = entryPath(hdr.Name, targetPath); err != nil {
+			return nil, err
+		}
+		if err = extractEntry(tr, hdr, dst, targetPath); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// entryPath returns the path the tarball entry is extracted to, manifest repositories are put right under
+// the target path the same way 'airshipctl document pull' does
+func entryPath(name, targetPath string) (string, error) {
+	dst := filepath.Join(targetPath, ImportDir, filepath.FromSlash(name))
+	if rel := strings.TrimPrefix(path.Clean(name), RepositoriesDir+"/"); rel != path.Clean(name) {
+		dst = filepath.Join(targetPath, filepath.FromSlash(rel))
+	}
+	if path.IsAbs(name) || !within(dst, targetPath) {
+		return "", ErrInvalidBundleEntry{Name: name}
+	}
+	return dst, nil
+}
+
+// within checks if the path is located in the root directory
+func within(p, root string) bool {
+	rel, err := filepath.Rel(root, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// throughSymlink checks if the parent directory of the path goes through a symbolic link extracted earlier,
+// such paths are rejected since the files would be written to the location the link is resolved to, which
+// may be outside of the root directory if the links are chained
+func throughSymlink(p, root string) (bool, error) {
+	rel, err := filepath.Rel(root, filepath.Dir(p))
+	if err != nil || rel == "." {
+		return false, err
+	}
+	dir := root
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		dir = filepath.Join(dir, name)
+		fi, err := os.Lstat(dir)
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// extractEntry writes directory, regular file or symbolic link pointing inside of the root directory,
+// other entries are skipped
+func extractEntry(tr *tar.Reader, hdr *tar.Header, dst, root string) error {
+	linked, err := throughSymlink(dst, root)
+	if err != nil {
+		return err
+	}
+	if linked {
+		return ErrInvalidBundleEntry{Name: hdr.Name}
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(dst, importDirPerm)
+	case tar.TypeSymlink:
+		if filepath.IsAbs(hdr.Linkname) || !within(filepath.Join(filepath.Dir(dst), hdr.Linkname), root) {
+			return ErrInvalidBundleEntry{Name: hdr.Name}
+		}
+		if err = os.MkdirAll(filepath.Dir(dst), importDirPerm); err != nil {
+			return err
+		}
+		if err = os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return os.Symlink(hdr.Linkname, dst)
+	case tar.TypeReg:
+		if err = os.MkdirAll(filepath.Dir(dst), importDirPerm); err != nil {
+			return err
+		}
+		// the file must not be written through the symbolic link extracted earlier at the same path
+		if fi, lstatErr := os.Lstat(dst); lstatErr == nil && fi.Mode()&os.ModeSymlink != 0 {
+			if err = os.Remove(dst); err != nil {
+				return err
+			}
+		}
+		f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err = io.CopyN(f, tr, hdr.Size); err != nil {
+			return err
+		}
+		return f.Close()
+	default:
+		log.Debugf("Skipping bundle entry %s of type %c", hdr.Name, hdr.Typeflag)
+		return nil
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package bundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/bundle"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+const testMetadata = `manifestName: site_manifest
+manifest:
+  targetPath: /tmp/site
+  repositories:
+    primary:
+      url: https://opendev.org/airship/site.git
+`
+
+// writeTarball writes gzipped tarball with the entries given as header and content pairs
+func writeTarball(t *testing.T, entries ...interface{}) string {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for i := 0; i < len(entries); i += 2 {
+		hdr := entries[i].(*tar.Header)
+		data := entries[i+1].(string)
+		hdr.Size = int64(len(data))
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	path := filepath.Join(t.TempDir(), "bundle.tgz")
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0600))
+	return path
+}
+
+func regularFile(name string) *tar.Header {
+	return &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644}
+}
+
+func TestImportCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        func(t *testing.T) string
+		factory     config.Factory
+		errContains string
+	}{
+		{
+			name:        "Error bundle path not specified",
+			path:        func(t *testing.T) string { return "" },
+			factory:     func() (*config.Config, error) { return testConfig(), nil },
+			errContains: bundle.ErrBundlePathNotSpecified{}.Error(),
+		},
+		{
+			name: "Error config factory",
+			path: func(t *testing.T) string { return "bundle.tgz" },
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name:        "Error bundle does not exist",
+			path:        func(t *testing.T) string { return filepath.Join(t.TempDir(), "bundle.tgz") },
+			factory:     func() (*config.Config, error) { return testConfig(), nil },
+			errContains: "no such file or directory",
+		},
+		{
+			name: "Error metadata is missing",
+			path: func(t *testing.T) string {
+				return writeTarball(t, regularFile(bundle.ImagesFile), "quay.io/airshipit/toolbox:latest\n")
+			},
+			factory:     func() (*config.Config, error) { return testConfig(), nil },
+			errContains: "doesn't start with metadata.yaml",
+		},
+		{
+			name: "Error entry points outside of the target path",
+			path: func(t *testing.T) string {
+				return writeTarball(t,
+					regularFile(bundle.MetadataFile), testMetadata,
+					regularFile("../../evil"), "evil")
+			},
+			factory:     func() (*config.Config, error) { return testConfig(), nil },
+			errContains: bundle.ErrInvalidBundleEntry{Name: "../../evil"}.Error(),
+		},
+		{
+			name: "Error symbolic link points outside of the target path",
+			path: func(t *testing.T) string {
+				return writeTarball(t,
+					regularFile(bundle.MetadataFile), testMetadata,
+					&tar.Header{Typeflag: tar.TypeSymlink, Name: "repositories/site/link", Linkname: "../../etc"}, "")
+			},
+			factory:     func() (*config.Config, error) { return testConfig(), nil },
+			errContains: bundle.ErrInvalidBundleEntry{Name: "repositories/site/link"}.Error(),
+		},
+		{
+			name: "Error entry is extracted through chained symbolic links",
+			path: func(t *testing.T) string {
+				return writeTarball(t,
+					regularFile(bundle.MetadataFile), testMetadata,
+					&tar.Header{Typeflag: tar.TypeSymlink, Name: "repositories/site/a", Linkname: "."}, "",
+					&tar.Header{Typeflag: tar.TypeSymlink, Name: "repositories/site/a/b", Linkname: "../.."}, "",
+					regularFile("repositories/site/a/b/evil"), "evil")
+			},
+			factory:     func() (*config.Config, error) { return testConfig(), nil },
+			errContains: bundle.ErrInvalidBundleEntry{Name: "repositories/site/a/b"}.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := bundle.ImportCommand{
+				Options: bundle.ImportFlags{
+					Path:       tt.path(t),
+					TargetPath: t.TempDir(),
+				},
+				Factory: tt.factory,
+				Writer:  ioutil.Discard,
+			}
+			err := cmd.RunE()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tgz")
+	export := bundle.ExportCommand{
+		Options: bundle.ExportFlags{Path: bundlePath},
+		Factory: func() (*config.Config, error) { return testConfig(), nil },
+		Writer:  ioutil.Discard,
+	}
+	require.NoError(t, export.RunE())
+
+	cfg, cleanup := testutil.InitConfig(t)
+	defer cleanup(t)
+	targetPath := t.TempDir()
+	buf := &bytes.Buffer{}
+	cmd := bundle.ImportCommand{
+		Options: bundle.ImportFlags{
+			Path:       bundlePath,
+			TargetPath: targetPath,
+		},
+		Factory: func() (*config.Config, error) { return cfg, nil },
+		Writer:  buf,
+	}
+	require.NoError(t, cmd.RunE())
+	assert.Equal(t, fmt.Sprintf("Bundle is imported into %s, context 'def_ephemeral' uses manifest 'dummy_manifest'\n",
+		targetPath), buf.String())
+
+	for _, p := range []string{
+		"site/metadata.yaml",
+		"site/phases/phases.yaml",
+		"site/functions/capi/v0.3.3/kustomization.yaml",
+		filepath.Join(bundle.ImportDir, bundle.ImagesFile),
+		filepath.Join(bundle.ImportDir, "phases/initinfra.yaml"),
+		filepath.Join(bundle.ImportDir, "providers/cluster-api/v0.3.3/core-components.yaml"),
+	} {
+		_, err := os.Stat(filepath.Join(targetPath, p))
+		assert.NoError(t, err, p)
+	}
+
+	manifest, err := cfg.CurrentContextManifest()
+	require.NoError(t, err)
+	assert.Equal(t, targetPath, manifest.TargetPath)
+	assert.Equal(t, "metadata.yaml", manifest.MetadataPath)
+	repo := manifest.Repositories[config.DefaultTestPhaseRepo]
+	require.NotNil(t, repo)
+	assert.Equal(t, filepath.Join(targetPath, "site"), repo.URL())
+	assert.Nil(t, repo.Auth)
+
+	configPath := cfg.LoadedConfigPath()
+	loaded, err := config.CreateFactory(&configPath)()
+	require.NoError(t, err)
+	assert.Equal(t, targetPath, loaded.Manifests["dummy_manifest"].TargetPath)
+}
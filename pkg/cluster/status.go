@@ -0,0 +1,281 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/util"
+	"opendev.org/airship/airshipctl/pkg/util/yaml"
+)
+
+const (
+	capiGroup = "cluster.x-k8s.io"
+	// operationalStatusOK is the BareMetalHost operational status of a healthy host
+	operationalStatusOK = "OK"
+)
+
+// componentKind defines a kind of cluster components and how their status is summarized
+type componentKind struct {
+	groupKind schema.GroupKind
+	summarize func(obj unstructured.Unstructured, cs *ComponentStatus)
+}
+
+// componentKinds are listed in the order they are reported, kinds missing from the cluster are skipped
+var componentKinds = []componentKind{
+	{groupKind: schema.GroupKind{Group: capiGroup, Kind: "Cluster"}, summarize: clusterSummary},
+	{
+		groupKind: schema.GroupKind{Group: "controlplane." + capiGroup, Kind: "KubeadmControlPlane"},
+		summarize: controlPlaneSummary,
+	},
+	{groupKind: schema.GroupKind{Group: capiGroup, Kind: "MachineDeployment"}, summarize: machineDeploymentSummary},
+	{groupKind: schema.GroupKind{Group: capiGroup, Kind: "Machine"}, summarize: machineSummary},
+	{groupKind: schema.GroupKind{Kind: "Node"}, summarize: nodeSummary},
+	{groupKind: schema.GroupKind{Group: "metal3.io", Kind: "BareMetalHost"}, summarize: bmhSummary},
+}
+
+// Status is the status of the clusters defined in the cluster map
+type Status struct {
+	Clusters []ClusterStatus `json:"clusters"`
+}
+
+// ClusterStatus is the status of the components deployed to a single cluster
+type ClusterStatus struct {
+	Name       string            `json:"name"`
+	Context    string            `json:"context"`
+	Error      string            `json:"error,omitempty"`
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+// ComponentStatus is the status of a single cluster component
+type ComponentStatus struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Ready     bool   `json:"ready"`
+	Phase     string `json:"phase,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// StatusCommand holds options for cluster status command
+type StatusCommand struct {
+	ClusterNames []string
+	Format       string
+	// ClientFunc is used to get clients of the clusters, if nil clients are created from the kubeconfig
+	ClientFunc utils.DynamicClientFunc
+}
+
+// RunE gets the kubeconfig of the site and prints the status of the components deployed to every cluster
+// of the cluster map, or only to the clusters specified in the ClusterNames
+func (cmd *StatusCommand) RunE(cfgFactory config.Factory, writer io.Writer) error {
+	if cmd.Format != phase.TableOutputFormat && cmd.Format != phase.YamlOutputFormat {
+		return phaseerrors.ErrInvalidFormat{RequestedFormat: cmd.Format}
+	}
+
+	cfg, err := cfgFactory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := phase.NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	cMap, err := helper.ClusterMap()
+	if err != nil {
+		return err
+	}
+
+	clusters := cmd.ClusterNames
+	siteWide := len(clusters) == 0
+	if siteWide {
+		clusters = cMap.AllClusters()
+		sort.Strings(clusters)
+	}
+
+	kubeconf := kubeconfig.NewBuilder().
+		WithBundle(helper.PhaseConfigBundle()).
+		WithClusterMap(cMap).
+		WithClusterNames(cmd.ClusterNames...).
+		WithTempRoot(helper.WorkDir()).
+		SiteWide(siteWide).
+		Build()
+
+	kubeconfigPath, cleanup, err := kubeconf.GetFile()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	status, err := cmd.status(cMap, clusters, kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Format == phase.YamlOutputFormat {
+		return yaml.WriteOut(writer, status)
+	}
+	return printStatusTable(writer, status)
+}
+
+func (cmd *StatusCommand) status(cMap clustermap.ClusterMap, clusters []string, kubeconfigPath string) (Status,
+	error) {
+	clientFunc := cmd.ClientFunc
+	if clientFunc == nil {
+		clientFunc = utils.DynamicClientFromKubeConfig
+	}
+
+	status := Status{Clusters: make([]ClusterStatus, 0, len(clusters))}
+	for _, clusterName := range clusters {
+		kctx, err := cMap.ClusterKubeconfigContext(clusterName)
+		if err != nil {
+			return Status{}, err
+		}
+
+		clusterStatus := ClusterStatus{Name: clusterName, Context: kctx}
+		// an unreachable cluster doesn't prevent the others from being reported
+		client, mapper, err := clientFunc(kubeconfigPath, kctx)
+		if err == nil {
+			clusterStatus.Components, err = components(client, mapper)
+		}
+		if err != nil {
+			clusterStatus.Error = err.Error()
+		}
+		status.Clusters = append(status.Clusters, clusterStatus)
+	}
+	return status, nil
+}
+
+func components(client dynamic.Interface, mapper meta.RESTMapper) ([]ComponentStatus, error) {
+	result := []ComponentStatus{}
+	for _, ck := range componentKinds {
+		objs, err := utils.ListObjects(client, mapper, ck.groupKind, "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			cs := ComponentStatus{
+				Kind:      obj.GetKind(),
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+			}
+			ck.summarize(obj, &cs)
+			result = append(result, cs)
+		}
+	}
+	return result, nil
+}
+
+func clusterSummary(obj unstructured.Unstructured, cs *ComponentStatus) {
+	cs.Ready, cs.Message = readyCondition(obj)
+	cs.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+}
+
+func controlPlaneSummary(obj unstructured.Unstructured, cs *ComponentStatus) {
+	cs.Ready, cs.Message = readyCondition(obj)
+	if cs.Ready {
+		cs.Message = replicasMessage(obj)
+	}
+}
+
+func machineDeploymentSummary(obj unstructured.Unstructured, cs *ComponentStatus) {
+	replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	cs.Ready = ready >= replicas
+	cs.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	cs.Message = replicasMessage(obj)
+}
+
+func machineSummary(obj unstructured.Unstructured, cs *ComponentStatus) {
+	cs.Ready, cs.Message = readyCondition(obj)
+	cs.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	if node, _, _ := unstructured.NestedString(obj.Object, "status", "nodeRef", "name"); node != "" && cs.Ready {
+		cs.Message = fmt.Sprintf("Node %s", node)
+	}
+}
+
+func nodeSummary(obj unstructured.Unstructured, cs *ComponentStatus) {
+	cs.Ready, cs.Message = readyCondition(obj)
+	if cs.Ready {
+		cs.Message, _, _ = unstructured.NestedString(obj.Object, "status", "nodeInfo", "kubeletVersion")
+	}
+}
+
+func bmhSummary(obj unstructured.Unstructured, cs *ComponentStatus) {
+	opStatus, _, _ := unstructured.NestedString(obj.Object, "status", "operationalStatus")
+	cs.Ready = opStatus == operationalStatusOK
+	cs.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "provisioning", "state")
+	cs.Message, _, _ = unstructured.NestedString(obj.Object, "status", "errorMessage")
+	if cs.Message == "" && opStatus != "" {
+		cs.Message = fmt.Sprintf("Operational status %s", opStatus)
+	}
+}
+
+// readyCondition returns the state and the message of the Ready condition of the object
+func readyCondition(obj unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return condition["status"] == string(metav1.ConditionTrue), message
+	}
+	return false, ""
+}
+
+func replicasMessage(obj unstructured.Unstructured) string {
+	replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	return fmt.Sprintf("%d of %d replicas are ready", ready, replicas)
+}
+
+func printStatusTable(w io.Writer, status Status) error {
+	tw := util.GetNewTabWriter(w)
+	if _, err := fmt.Fprintln(tw, "CLUSTER\tKIND\tNAMESPACE\tNAME\tREADY\tPHASE\tMESSAGE"); err != nil {
+		return err
+	}
+	for _, cluster := range status.Clusters {
+		if cluster.Error != "" {
+			if _, err := fmt.Fprintf(tw, "%s\t\t\t\t%t\t\t%s\n", cluster.Name, false, cluster.Error); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, cs := range cluster.Components {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\t%s\n", cluster.Name, cs.Kind, cs.Namespace,
+				cs.Name, cs.Ready, cs.Phase, cs.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,324 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/cluster"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+)
+
+const (
+	testCluster = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Cluster
+metadata:
+  name: target-cluster
+  namespace: target-infra
+status:
+  phase: Provisioned
+  conditions:
+  - type: Ready
+    status: "True"
+`
+	testControlPlane = `apiVersion: controlplane.cluster.x-k8s.io/v1alpha3
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-controlplane
+  namespace: target-infra
+spec:
+  replicas: 1
+status:
+  readyReplicas: 1
+  conditions:
+  - type: Ready
+    status: "True"
+`
+	testMachineDeployment = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: MachineDeployment
+metadata:
+  name: worker-1
+  namespace: target-infra
+spec:
+  replicas: 2
+status:
+  phase: ScalingUp
+  readyReplicas: 1
+`
+	testMachine = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Machine
+metadata:
+  name: cluster-controlplane-5tx8d
+  namespace: target-infra
+status:
+  phase: Running
+  nodeRef:
+    name: node01
+  conditions:
+  - type: Ready
+    status: "True"
+`
+	testNode = `apiVersion: v1
+kind: Node
+metadata:
+  name: node01
+status:
+  nodeInfo:
+    kubeletVersion: v1.21.2
+  conditions:
+  - type: Ready
+    status: "True"
+`
+	testProvisionedHost = `apiVersion: metal3.io/v1alpha1
+kind: BareMetalHost
+metadata:
+  name: node01
+  namespace: target-infra
+status:
+  operationalStatus: OK
+  provisioning:
+    state: provisioned
+`
+	testFailedHost = `apiVersion: metal3.io/v1alpha1
+kind: BareMetalHost
+metadata:
+  name: node03
+  namespace: target-infra
+status:
+  operationalStatus: error
+  errorMessage: Failed to inspect hardware
+  provisioning:
+    state: inspecting
+`
+)
+
+func testConfig(t *testing.T) config.Factory {
+	t.Helper()
+	confString := `apiVersion: airshipit.org/v1alpha1
+contexts:
+  dummy_cluster:
+    manifest: dummy_manifest
+currentContext: dummy_cluster
+kind: Config
+manifests:
+  dummy_manifest:
+    phaseRepositoryName: primary
+    targetPath: testdata
+    metadataPath: metadata.yaml
+    repositories:
+      primary:
+        url: "empty/filename/"`
+
+	conf := config.NewConfig()
+	require.NoError(t, yaml.Unmarshal([]byte(confString), conf))
+	return func() (*config.Config, error) { return conf, nil }
+}
+
+// testClientFunc returns fake dynamic client with objects of the kubeconfig context,
+// error is returned for the contexts without objects
+func testClientFunc(t *testing.T, objsByContext map[string][]string) utils.DynamicClientFunc {
+	gvrs := map[schema.GroupVersionResource]string{
+		{Group: "cluster.x-k8s.io", Version: "v1alpha3", Resource: "clusters"}:           "ClusterList",
+		{Group: "cluster.x-k8s.io", Version: "v1alpha3", Resource: "machinedeployments"}: "MachineDeploymentList",
+		{Group: "cluster.x-k8s.io", Version: "v1alpha3", Resource: "machines"}:           "MachineList",
+		{Group: "controlplane.cluster.x-k8s.io", Version: "v1alpha3",
+			Resource: "kubeadmcontrolplanes"}: "KubeadmControlPlaneList",
+		{Group: "metal3.io", Version: "v1alpha1", Resource: "baremetalhosts"}: "BareMetalHostList",
+		{Version: "v1", Resource: "nodes"}:                                    "NodeList",
+	}
+	gvs := []schema.GroupVersion{}
+	for gvr := range gvrs {
+		gvs = append(gvs, gvr.GroupVersion())
+	}
+	mapper := meta.NewDefaultRESTMapper(gvs)
+	for gvr, listKind := range gvrs {
+		scope := meta.RESTScopeNamespace
+		if gvr.Resource == "nodes" {
+			scope = meta.RESTScopeRoot
+		}
+		mapper.Add(gvr.GroupVersion().WithKind(listKind[:len(listKind)-len("List")]), scope)
+	}
+
+	return func(_, kctx string) (dynamic.Interface, meta.RESTMapper, error) {
+		objs, ok := objsByContext[kctx]
+		if !ok {
+			return nil, nil, fmt.Errorf("unable to connect to '%s'", kctx)
+		}
+		scheme := runtime.NewScheme()
+		for gvr, listKind := range gvrs {
+			scheme.AddKnownTypeWithName(gvr.GroupVersion().WithKind(listKind), &unstructured.UnstructuredList{})
+		}
+		runtimeObjs := []runtime.Object{}
+		for _, obj := range objs {
+			jsonObj, err := yaml.YAMLToJSON([]byte(obj))
+			require.NoError(t, err)
+			u := &unstructured.Unstructured{}
+			require.NoError(t, u.UnmarshalJSON(jsonObj))
+			runtimeObjs = append(runtimeObjs, u)
+		}
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, gvrs, runtimeObjs...), mapper, nil
+	}
+}
+
+func TestStatusCommand(t *testing.T) {
+	clientFunc := testClientFunc(t, map[string][]string{
+		"target-cluster": {
+			testFailedHost,
+			testProvisionedHost,
+			testNode,
+			testMachine,
+			testMachineDeployment,
+			testControlPlane,
+			testCluster,
+		},
+	})
+
+	tests := []struct {
+		name           string
+		clusterNames   []string
+		format         string
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:   "success site table",
+			format: "table",
+			expectedOutput: "CLUSTER             KIND                  NAMESPACE      NAME                         " +
+				"READY   PHASE         MESSAGE\n" +
+				"ephemeral-cluster                                                                     " +
+				"false                 unable to connect to 'ephemeral-cluster'\n" +
+				"target-cluster      Cluster               target-infra   target-cluster               " +
+				"true    Provisioned   \n" +
+				"target-cluster      KubeadmControlPlane   target-infra   cluster-controlplane         " +
+				"true                  1 of 1 replicas are ready\n" +
+				"target-cluster      MachineDeployment     target-infra   worker-1                     " +
+				"false   ScalingUp     1 of 2 replicas are ready\n" +
+				"target-cluster      Machine               target-infra   cluster-controlplane-5tx8d   " +
+				"true    Running       Node node01\n" +
+				"target-cluster      Node                                 node01                       " +
+				"true                  v1.21.2\n" +
+				"target-cluster      BareMetalHost         target-infra   node01                       " +
+				"true    provisioned   Operational status OK\n" +
+				"target-cluster      BareMetalHost         target-infra   node03                       " +
+				"false   inspecting    Failed to inspect hardware\n",
+		},
+		{
+			name:         "success cluster yaml",
+			clusterNames: []string{"target-cluster"},
+			format:       "yaml",
+			expectedOutput: `---
+clusters:
+- components:
+  - kind: Cluster
+    name: target-cluster
+    namespace: target-infra
+    phase: Provisioned
+    ready: true
+  - kind: KubeadmControlPlane
+    message: 1 of 1 replicas are ready
+    name: cluster-controlplane
+    namespace: target-infra
+    ready: true
+  - kind: MachineDeployment
+    message: 1 of 2 replicas are ready
+    name: worker-1
+    namespace: target-infra
+    phase: ScalingUp
+    ready: false
+  - kind: Machine
+    message: Node node01
+    name: cluster-controlplane-5tx8d
+    namespace: target-infra
+    phase: Running
+    ready: true
+  - kind: Node
+    message: v1.21.2
+    name: node01
+    ready: true
+  - kind: BareMetalHost
+    message: Operational status OK
+    name: node01
+    namespace: target-infra
+    phase: provisioned
+    ready: true
+  - kind: BareMetalHost
+    message: Failed to inspect hardware
+    name: node03
+    namespace: target-infra
+    phase: inspecting
+    ready: false
+  context: target-cluster
+  name: target-cluster
+...
+`,
+		},
+		{
+			name:         "success unreachable cluster yaml",
+			clusterNames: []string{"ephemeral-cluster"},
+			format:       "yaml",
+			expectedOutput: `---
+clusters:
+- context: ephemeral-cluster
+  error: unable to connect to 'ephemeral-cluster'
+  name: ephemeral-cluster
+...
+`,
+		},
+		{
+			name:         "error cluster is not in the map",
+			clusterNames: []string{"unknown-cluster"},
+			format:       "table",
+			expectedErr:  "cluster 'unknown-cluster' is not defined in cluster map",
+		},
+		{
+			name:        "error invalid output format",
+			format:      "json",
+			expectedErr: "invalid output format specified json. Allowed values are table|yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cluster.StatusCommand{
+				ClusterNames: tt.clusterNames,
+				Format:       tt.format,
+				ClientFunc:   clientFunc,
+			}
+			buf := &bytes.Buffer{}
+			err := cmd.RunE(testConfig(t), buf)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, buf.String())
+		})
+	}
+}
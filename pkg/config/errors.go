@@ -18,8 +18,12 @@ import (
 	"fmt"
 	"strings"
 
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	redfishauto "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/auto"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	redfishhpe "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	redfishsupermicro "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
 )
 
 // ErrIncompatibleAuthOptions is returned when incompatible
@@ -236,8 +240,15 @@ type ErrUnknownManagementType struct {
 }
 
 func (e ErrUnknownManagementType) Error() string {
+	knownTypes := []string{
+		redfish.ClientType,
+		redfishdell.ClientType,
+		redfishhpe.ClientType,
+		redfishsupermicro.ClientType,
+		redfishauto.ClientType,
+	}
 	return fmt.Sprintf("Unknown management type '%s'. Known types include '%s' and '%s'.", e.Type,
-		redfish.ClientType, redfishdell.ClientType)
+		strings.Join(knownTypes, "', '"), ipmi.ClientType)
 }
 
 // ErrMissingManifestName is returned when manifest name is empty
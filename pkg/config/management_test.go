@@ -21,7 +21,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
+	redfishauto "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/auto"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	redfishhpe "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	redfishsupermicro "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
 )
 
 func TestNewManagementConfiguration(t *testing.T) {
@@ -61,6 +65,28 @@ func TestValidateRedfishDell(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateRedfishVendors(t *testing.T) {
+	for _, managementType := range []string{
+		redfishhpe.ClientType,
+		redfishsupermicro.ClientType,
+		redfishauto.ClientType,
+	} {
+		cfg := config.NewManagementConfiguration()
+		cfg.Type = managementType
+
+		err := cfg.Validate()
+		assert.NoError(t, err, managementType)
+	}
+}
+
+func TestValidateIPMI(t *testing.T) {
+	cfg := config.NewManagementConfiguration()
+	cfg.Type = ipmi.ClientType
+
+	err := cfg.Validate()
+	assert.NoError(t, err)
+}
+
 func TestValidateInvalidManagementType(t *testing.T) {
 	cfg := config.NewManagementConfiguration()
 	cfg.Type = "invalid"
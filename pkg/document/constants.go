@@ -66,6 +66,15 @@ const (
 	ApplierContainerKind = "GenericContainer"
 	// ApplierContainerName defines Name for applier container
 	ApplierContainerName = "applier"
+
+	// HelmContainerGroup defines Group for helm container
+	HelmContainerGroup = "airshipit.org"
+	// HelmContainerVersion defines Version for helm container
+	HelmContainerVersion = "v1alpha1"
+	// HelmContainerKind defines Kind for helm container
+	HelmContainerKind = "GenericContainer"
+	// HelmContainerName defines Name for helm container
+	HelmContainerName = "helm"
 )
 
 // KustomizationFile is used for kustomization file
@@ -14,6 +14,8 @@
 
 package document
 
+import "strings"
+
 // GetBMHNetworkData retrieves the associated network data string
 // for the bmh document supplied from the bundle supplied
 func GetBMHNetworkData(bmh Document, bundle Bundle) (string, error) {
@@ -44,6 +46,16 @@ func GetBMHBMCAddress(bmh Document) (string, error) {
 	return bmcAddress, nil
 }
 
+// GetBMHBootMACAddress returns the MAC address of the NIC the host boots from for the bmh document supplied,
+// the address is optional so an empty string is returned if the document doesn't specify it
+func GetBMHBootMACAddress(bmh Document) (string, error) {
+	bootMACAddress, err := bmh.GetString("spec.bootMACAddress")
+	if err != nil && strings.Contains(err.Error(), "no field named") {
+		return "", nil
+	}
+	return bootMACAddress, err
+}
+
 // GetBMHBMCCredentials returns the BMC credentials for the bmh document supplied from
 // the supplied bundle
 func GetBMHBMCCredentials(bmh Document, bundle Bundle) (username string, password string, err error) {
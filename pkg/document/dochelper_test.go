@@ -42,6 +42,16 @@ spec:
   networkData:
     name: master-0-networkdata
     namespace: metal3
+`
+	bmhConfigNoBootMAC = `apiVersion: metal3.io/v1alpha1
+kind: BareMetalHost
+metadata:
+  name: master-1
+spec:
+  online: true
+  bmc:
+    address: redfish+https://192.168.111.1/v1/Redfish/Foo/Baz
+    credentialsName: master-1-bmc
 `
 	networkConfig = `apiVersion: v1
 kind: Secret
@@ -175,6 +185,24 @@ func TestDocHelpers(t *testing.T) {
 		assert.Equal(bmcAddress, "redfish+https://192.168.111.1/v1/Redfish/Foo/Bar")
 	})
 
+	t.Run("GetBMHBootMACAddress", func(t *testing.T) {
+		// retrieve our single bmh in the dataset
+		selector := document.NewSelector().ByKind("BareMetalHost")
+		doc, err := bundle.SelectOne(selector)
+		require.NoError(err)
+
+		bootMACAddress, err := document.GetBMHBootMACAddress(doc)
+		require.NoError(err, "Unexpected error trying to GetBMHBootMACAddress")
+		assert.Equal("00:3b:8b:0c:ec:8b", bootMACAddress)
+
+		// boot MAC address is optional
+		doc, err = document.NewDocumentFromBytes([]byte(bmhConfigNoBootMAC))
+		require.NoError(err)
+		bootMACAddress, err = document.GetBMHBootMACAddress(doc)
+		require.NoError(err)
+		assert.Empty(bootMACAddress)
+	})
+
 	t.Run("GetBMHBMCCredentials", func(t *testing.T) {
 		// retrieve our single bmh in the dataset
 		selector := document.NewSelector().ByKind("BareMetalHost")
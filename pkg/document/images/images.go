@@ -0,0 +1,293 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package images
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+)
+
+const (
+	genericContainerKind  = "GenericContainer"
+	clusterctlKind        = "Clusterctl"
+	versionsCatalogueKind = "VersionsCatalogue"
+
+	// dockerHubNamespace is the namespace of the official images which are referenced by a bare name
+	dockerHubNamespace = "library"
+	// localHost is the registry host of the images built locally, e.g. KRM functions, they aren't pulled
+	// from any registry
+	localHost = "localhost"
+)
+
+// containerFields are the keys of the pod spec which hold lists of containers
+var containerFields = []string{"containers", "initContainers", "ephemeralContainers"}
+
+// visitor is called for every image reference found in the documents, full references are passed to
+// image, while repository receives prefixes the image names are appended to. Both return the value
+// to store in the document
+type visitor struct {
+	image      func(string) string
+	repository func(string) string
+}
+
+// Collect returns sorted list of unique container image references used by the documents. References are
+// taken from the containers of workloads, GenericContainer specs, Clusterctl image overrides and
+// VersionsCatalogues
+func Collect(docs []document.Document) ([]string, error) {
+	seen := map[string]bool{}
+	v := visitor{
+		image: func(ref string) string {
+			if ref != "" {
+				seen[ref] = true
+			}
+			return ref
+		},
+		repository: func(repo string) string { return repo },
+	}
+	for _, doc := range docs {
+		if _, err := walkDocument(doc, v); err != nil {
+			return nil, err
+		}
+	}
+
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
+
+// Mirror returns a copy of the bundle with every image reference and image repository pointing to
+// the registry, see MirrorReference for details
+func Mirror(bundle document.Bundle, registry string) (document.Bundle, error) {
+	docs, err := bundle.GetAllDocuments()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	for _, doc := range docs {
+		var data []byte
+		if data, err = mirrorYAML(doc, registry); err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+	return document.NewBundleFromBytes(buf.Bytes())
+}
+
+// MirrorDocument returns a copy of the document with every image reference and image repository pointing
+// to the registry, see MirrorReference for details
+func MirrorDocument(doc document.Document, registry string) (document.Document, error) {
+	data, err := mirrorYAML(doc, registry)
+	if err != nil {
+		return nil, err
+	}
+	return document.NewDocumentFromBytes(data)
+}
+
+// mirrorYAML returns YAML of the document with the image references pointing to the registry
+func mirrorYAML(doc document.Document, registry string) ([]byte, error) {
+	obj, err := walkDocument(doc, visitor{
+		image:      func(ref string) string { return MirrorReference(ref, registry) },
+		repository: func(repo string) string { return mirrorRepository(repo, registry) },
+	})
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(obj)
+}
+
+// MirrorReference replaces registry host of the image reference with the registry keeping the rest of
+// the path, tag and digest, e.g. quay.io/metal3-io/ironic:v1 becomes registry/metal3-io/ironic:v1.
+// Images referenced without a registry host are Docker Hub images, official images among them get
+// the "library" namespace, e.g. nginx:1.19 becomes registry/library/nginx:1.19. Images built locally,
+// e.g. localhost/toolbox, are returned as is since they aren't pulled from a registry
+func MirrorReference(ref, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+	if ref == "" || registry == "" || strings.HasPrefix(ref, registry+"/") || isLocal(ref) {
+		return ref
+	}
+
+	path := ref
+	if i := strings.Index(ref, "/"); i < 0 {
+		path = dockerHubNamespace + "/" + ref
+	} else if isRegistryHost(ref[:i]) {
+		path = ref[i+1:]
+	}
+	return registry + "/" + path
+}
+
+// mirrorRepository replaces registry host of the repository prefix, e.g. quay.io/metal3-io becomes
+// registry/metal3-io and quay.io becomes registry. Repositories of the local images are returned as is
+func mirrorRepository(repo, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+	if repo == "" || registry == "" || repo == registry || strings.HasPrefix(repo, registry+"/") ||
+		isLocal(repo) {
+		return repo
+	}
+
+	path := repo
+	host := strings.SplitN(repo, "/", 2)
+	if isRegistryHost(host[0]) {
+		path = ""
+		if len(host) > 1 {
+			path = host[1]
+		}
+	}
+	if path == "" {
+		return registry
+	}
+	return registry + "/" + path
+}
+
+// isRegistryHost checks if the first component of the image path is a registry host the same way the
+// container runtimes do
+func isRegistryHost(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == localHost
+}
+
+// isLocal checks if the image reference or repository points to the images built locally, registries
+// listening on the local host, e.g. localhost:5000, serve pulled images and aren't local
+func isLocal(ref string) bool {
+	return ref == localHost || strings.HasPrefix(ref, localHost+"/")
+}
+
+// walkDocument calls the visitor for every image of the document and returns the document
+// as an object with the values returned by the visitor
+func walkDocument(doc document.Document, v visitor) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	if err := doc.ToObject(&obj); err != nil {
+		return nil, err
+	}
+
+	spec, _ := obj["spec"].(map[string]interface{})
+	if doc.GetGroup() == v1alpha1.GroupVersion.Group {
+		switch doc.GetKind() {
+		case genericContainerKind:
+			setString(spec, "image", v.image)
+		case clusterctlKind:
+			walkClusterctl(obj, v)
+		case versionsCatalogueKind:
+			walkVersionsCatalogue(spec, v)
+		}
+	}
+	walkContainers(obj, v)
+	return obj, nil
+}
+
+// walkContainers looks for the lists of containers at any level of the object, so that pods, pod
+// templates of the workloads and custom resources embedding them are handled the same way
+func walkContainers(obj interface{}, v visitor) {
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		for _, field := range containerFields {
+			containers, ok := typed[field].([]interface{})
+			if !ok {
+				continue
+			}
+			for _, container := range containers {
+				if c, ok := container.(map[string]interface{}); ok {
+					setString(c, "image", v.image)
+				}
+			}
+		}
+		for _, value := range typed {
+			walkContainers(value, v)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			walkContainers(value, v)
+		}
+	}
+}
+
+// walkClusterctl handles image overrides of the clusterctl config, overrides keyed by
+// <component>/<image name> with the tag define the complete image reference
+func walkClusterctl(obj map[string]interface{}, v visitor) {
+	metas, _ := obj["images"].(map[string]interface{})
+	for name, meta := range metas {
+		m, ok := meta.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		repo, _ := m["repository"].(string)
+		tag, _ := m["tag"].(string)
+		if i := strings.Index(name, "/"); i >= 0 && repo != "" && tag != "" {
+			v.image(repo + "/" + name[i+1:] + ":" + tag)
+		}
+		setString(m, "repository", v.repository)
+	}
+}
+
+// walkVersionsCatalogue handles images, image components, cluster API images and image repositories
+// of the catalogue
+func walkVersionsCatalogue(spec map[string]interface{}, v visitor) {
+	forEachLeaf(spec["images"], 3, func(m map[string]interface{}) {
+		setString(m, "image", v.image)
+	})
+	forEachLeaf(spec["image_components"], 2, func(m map[string]interface{}) {
+		repo, _ := m["repository"].(string)
+		if repo == "" {
+			return
+		}
+		ref := repo
+		if tag, _ := m["tag"].(string); tag != "" {
+			ref += ":" + tag
+		}
+		if digest, _ := m["digest"].(string); digest != "" {
+			ref += "@" + digest
+		}
+		// only the registry part of the reference is changed by the visitor, so the repository is the
+		// returned reference without the tag and digest
+		m["repository"] = strings.TrimSuffix(v.image(ref), ref[len(repo):])
+	})
+	forEachLeaf(spec["capi_images"], 2, func(m map[string]interface{}) {
+		setString(m, "repository", v.repository)
+	})
+	forEachLeaf(spec["image_repositories"], 1, func(m map[string]interface{}) {
+		setString(m, "repository", v.repository)
+	})
+}
+
+// forEachLeaf calls f for every map found at the given depth of the nested maps
+func forEachLeaf(obj interface{}, depth int, f func(map[string]interface{})) {
+	m, ok := obj.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if depth == 0 {
+		f(m)
+		return
+	}
+	for _, value := range m {
+		forEachLeaf(value, depth-1, f)
+	}
+}
+
+// setString replaces string value of the key with the one returned by f
+func setString(m map[string]interface{}, key string, f func(string) string) {
+	if value, ok := m[key].(string); ok && value != "" {
+		m[key] = f(value)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package images_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/document/images"
+)
+
+const (
+	testDocs = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ironic
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init-images
+        image: quay.io/airshipit/ipa-wallaby:latest
+      containers:
+      - name: ironic
+        image: quay.io/metal3-io/ironic:capm3-v0.5.0
+      - name: dnsmasq
+        image: quay.io/metal3-io/ironic:capm3-v0.5.0
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox
+---
+apiVersion: airshipit.org/v1alpha1
+kind: GenericContainer
+metadata:
+  name: toolbox
+spec:
+  image: localhost:5000/toolbox:latest
+---
+apiVersion: airshipit.org/v1alpha1
+kind: Clusterctl
+metadata:
+  name: clusterctl-v1
+images:
+  cluster-api/cluster-api-controller:
+    repository: gcr.io/k8s-staging-cluster-api
+    tag: v0.3.3
+  cert-manager:
+    repository: quay.io/jetstack
+---
+apiVersion: airshipit.org/v1alpha1
+kind: VersionsCatalogue
+metadata:
+  name: versions
+spec:
+  capi_images:
+    capm3:
+      manager:
+        repository: quay.io/metal3-io
+        tag: v0.5.0
+  images:
+    calico_v3:
+      kube_controllers:
+        calico_kube_controllers:
+          image: quay.io/calico/kube-controllers:v3.15.1
+  image_components:
+    flux:
+      helm_controller:
+        repository: fluxcd/helm-controller
+        tag: v0.11.1
+        digest: sha256:0123456789abcdef
+  image_repositories:
+    cni:
+      name: tigera-operator
+      repository: quay.io
+`
+
+	mirroredDocs = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ironic
+spec:
+  template:
+    spec:
+      containers:
+      - image: mirror.local/metal3-io/ironic:capm3-v0.5.0
+        name: ironic
+      - image: mirror.local/metal3-io/ironic:capm3-v0.5.0
+        name: dnsmasq
+      initContainers:
+      - image: mirror.local/airshipit/ipa-wallaby:latest
+        name: init-images
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - image: mirror.local/library/busybox
+    name: debug
+---
+apiVersion: airshipit.org/v1alpha1
+kind: GenericContainer
+metadata:
+  name: toolbox
+spec:
+  image: mirror.local/toolbox:latest
+---
+apiVersion: airshipit.org/v1alpha1
+images:
+  cert-manager:
+    repository: mirror.local/jetstack
+  cluster-api/cluster-api-controller:
+    repository: mirror.local/k8s-staging-cluster-api
+    tag: v0.3.3
+kind: Clusterctl
+metadata:
+  name: clusterctl-v1
+---
+apiVersion: airshipit.org/v1alpha1
+kind: VersionsCatalogue
+metadata:
+  name: versions
+spec:
+  capi_images:
+    capm3:
+      manager:
+        repository: mirror.local/metal3-io
+        tag: v0.5.0
+  image_components:
+    flux:
+      helm_controller:
+        digest: sha256:0123456789abcdef
+        repository: mirror.local/fluxcd/helm-controller
+        tag: v0.11.1
+  image_repositories:
+    cni:
+      name: tigera-operator
+      repository: mirror.local
+  images:
+    calico_v3:
+      kube_controllers:
+        calico_kube_controllers:
+          image: mirror.local/calico/kube-controllers:v3.15.1
+`
+)
+
+func TestCollect(t *testing.T) {
+	bundle, err := document.NewBundleFromBytes([]byte(testDocs))
+	require.NoError(t, err)
+	docs, err := bundle.GetAllDocuments()
+	require.NoError(t, err)
+
+	refs, err := images.Collect(docs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"busybox",
+		"fluxcd/helm-controller:v0.11.1@sha256:0123456789abcdef",
+		"gcr.io/k8s-staging-cluster-api/cluster-api-controller:v0.3.3",
+		"localhost:5000/toolbox:latest",
+		"quay.io/airshipit/ipa-wallaby:latest",
+		"quay.io/calico/kube-controllers:v3.15.1",
+		"quay.io/metal3-io/ironic:capm3-v0.5.0",
+	}, refs)
+}
+
+func TestMirror(t *testing.T) {
+	bundle, err := document.NewBundleFromBytes([]byte(testDocs))
+	require.NoError(t, err)
+
+	mirrored, err := images.Mirror(bundle, "mirror.local/")
+	require.NoError(t, err)
+
+	expected, err := document.NewBundleFromBytes([]byte(mirroredDocs))
+	require.NoError(t, err)
+	expectedDocs, err := expected.GetAllDocuments()
+	require.NoError(t, err)
+	actualDocs, err := mirrored.GetAllDocuments()
+	require.NoError(t, err)
+	require.Len(t, actualDocs, len(expectedDocs))
+	for i := range expectedDocs {
+		expectedYAML, err := expectedDocs[i].AsYAML()
+		require.NoError(t, err)
+		actualYAML, err := actualDocs[i].AsYAML()
+		require.NoError(t, err)
+		assert.YAMLEq(t, string(expectedYAML), string(actualYAML))
+	}
+}
+
+func TestMirrorDocument(t *testing.T) {
+	bundle, err := document.NewBundleFromBytes([]byte(`apiVersion: airshipit.org/v1alpha1
+kind: GenericContainer
+metadata:
+  name: toolbox
+spec:
+  type: krm
+  image: quay.io/airshipit/toolbox:latest
+`))
+	require.NoError(t, err)
+	docs, err := bundle.GetAllDocuments()
+	require.NoError(t, err)
+
+	mirrored, err := images.MirrorDocument(docs[0], "mirror.local")
+	require.NoError(t, err)
+	refs, err := images.Collect([]document.Document{mirrored})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mirror.local/airshipit/toolbox:latest"}, refs)
+}
+
+func TestMirrorReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		registry string
+		expected string
+	}{
+		{
+			ref:      "quay.io/metal3-io/ironic:capm3-v0.5.0",
+			registry: "mirror.local:5000",
+			expected: "mirror.local:5000/metal3-io/ironic:capm3-v0.5.0",
+		},
+		{
+			ref:      "nginx:1.19",
+			registry: "mirror.local:5000",
+			expected: "mirror.local:5000/library/nginx:1.19",
+		},
+		{
+			ref:      "fluxcd/helm-operator@sha256:0123456789abcdef",
+			registry: "mirror.local:5000/airship",
+			expected: "mirror.local:5000/airship/fluxcd/helm-operator@sha256:0123456789abcdef",
+		},
+		{
+			ref:      "localhost/toolbox",
+			registry: "mirror.local:5000",
+			expected: "localhost/toolbox",
+		},
+		{
+			ref:      "localhost:5000/toolbox",
+			registry: "mirror.local:5000",
+			expected: "mirror.local:5000/toolbox",
+		},
+		{
+			ref:      "mirror.local:5000/metal3-io/ironic",
+			registry: "mirror.local:5000",
+			expected: "mirror.local:5000/metal3-io/ironic",
+		},
+		{
+			ref:      "quay.io/metal3-io/ironic",
+			expected: "quay.io/metal3-io/ironic",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.expected, images.MirrorReference(tt.ref, tt.registry))
+		})
+	}
+}
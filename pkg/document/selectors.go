@@ -246,3 +246,11 @@ func NewApplierContainerExecutorSelector() Selector {
 		ApplierContainerKind).
 		ByName(ApplierContainerName)
 }
+
+// NewHelmContainerExecutorSelector returns selector to get executor documents for helm container
+func NewHelmContainerExecutorSelector() Selector {
+	return NewSelector().ByGvk(HelmContainerGroup,
+		HelmContainerVersion,
+		HelmContainerKind).
+		ByName(HelmContainerName)
+}
@@ -16,14 +16,22 @@ package baremetal
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
 	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	redfishauto "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/auto"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	redfishhpe "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	redfishsupermicro "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
 )
 
 // Inventory implements baremetal invenotry interface
@@ -82,12 +90,14 @@ func (i Inventory) SelectOne(selector ifc.BaremetalHostSelector) (remoteifc.Clie
 }
 
 // RunOperation runs specified operation against the hosts that would be filtered by selector.
-// Options are ignored for now, when we implement concurency, they will be used.
+// Up to opts.Concurrency hosts are processed at the same time, results of the operation are stored in
+// opts.Results if it is set. Error of the host is returned if the operation fails against one host,
+// ErrBatchOperationFailed is returned if it fails against several hosts.
 func (i Inventory) RunOperation(
 	ctx context.Context,
 	op ifc.BaremetalOperation,
 	selector ifc.BaremetalHostSelector,
-	_ ifc.BaremetalBatchRunOptions) error {
+	opts ifc.BaremetalBatchRunOptions) error {
 	log.Debugf("Running operation '%s' against hosts selected by selector '%v'", op, selector)
 
 	hostAction, err := action(ctx, op)
@@ -105,24 +115,137 @@ func (i Inventory) RunOperation(
 		return ErrNoBaremetalHostsFound{Selector: selector}
 	}
 
-	// TODO add concurent action execution
-	// TODO consider adding FailFast flag to BaremetalBatchRunOptions that would allow
-	// not fail on first error, but accumulate errors and return them at the end.
-	for _, host := range hosts {
-		if hostErr := hostAction(host); hostErr != nil {
-			return hostErr
+	results := ifc.BaremetalBatchRunResults{
+		Operation: op,
+		Hosts: RunBatch(hosts, func(_ int, host remoteifc.Client) error {
+			return hostAction(host)
+		}, opts),
+	}
+	if opts.Results != nil {
+		*opts.Results = results
+	}
+	return BatchError(results)
+}
+
+// RunBatch performs the action against the hosts keeping up to opts.Concurrency actions running
+// at the same time, the action receives index of the host among the hosts. The action isn't started
+// against the remaining hosts once it fails in fail fast mode. Results are returned in the order of
+// the hosts, opts.Results is ignored.
+func RunBatch(
+	hosts []remoteifc.Client,
+	hostAction func(idx int, host remoteifc.Client) error,
+	opts ifc.BaremetalBatchRunOptions) []ifc.BaremetalHostResult {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]ifc.BaremetalHostResult, len(hosts))
+	slots := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	var failed int32
+	for idx, host := range hosts {
+		results[idx] = ifc.BaremetalHostResult{NodeName: host.NodeName(), NodeID: host.NodeID()}
+
+		slots <- struct{}{}
+		if opts.FailFast && atomic.LoadInt32(&failed) > 0 {
+			<-slots
+			log.Debugf("Skipping host '%s' since the operation has already failed", host.NodeName())
+			results[idx].Skipped = true
+			continue
 		}
+
+		wg.Add(1)
+		go func(idx int, host remoteifc.Client, result *ifc.BaremetalHostResult) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			start := time.Now()
+			result.Error = hostAction(idx, host)
+			result.Duration = time.Since(start)
+			if result.Error != nil {
+				log.Debugf("Operation against host '%s' failed: %v", host.NodeName(), result.Error)
+				atomic.StoreInt32(&failed, 1)
+			}
+		}(idx, host, &results[idx])
 	}
+	wg.Wait()
+	return results
+}
 
-	return nil
+// BatchError returns error of the host if the operation has failed against one host and
+// ErrBatchOperationFailed if it has failed against several hosts
+func BatchError(results ifc.BaremetalBatchRunResults) error {
+	failed := results.Failed()
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return failed[0].Error
+	default:
+		return ErrBatchOperationFailed{Operation: results.Operation, Total: len(results.Hosts), Failed: failed}
+	}
 }
 
 // Host implements baremetal host interface
 type Host struct {
 	remoteifc.Client
+
+	bootMACAddress string
+}
+
+var _ ifc.BaremetalHost = Host{}
+
+// BootMACAddress returns MAC address of the NIC the host boots from as specified by its BareMetalHost document
+func (h Host) BootMACAddress() string {
+	return h.bootMACAddress
 }
 
-var _ remoteifc.Client = Host{}
+// HardwareInventory retrieves hardware inventory of the host if its management type supports it
+func (h Host) HardwareInventory(ctx context.Context) (hardware.Inventory, error) {
+	client, ok := h.Client.(remoteifc.HardwareInventoryClient)
+	if !ok {
+		return hardware.Inventory{}, hardware.ErrInventoryNotSupported{NodeName: h.NodeName()}
+	}
+	return client.HardwareInventory(ctx)
+}
+
+// BIOSSettings retrieves BIOS settings of the host if its management type supports it
+func (h Host) BIOSSettings(ctx context.Context) (hardware.BIOSSettings, error) {
+	client, ok := h.Client.(remoteifc.BIOSClient)
+	if !ok {
+		return hardware.BIOSSettings{}, hardware.ErrBIOSNotSupported{NodeName: h.NodeName()}
+	}
+	return client.BIOSSettings(ctx)
+}
+
+// SetBIOSSettings changes BIOS settings of the host if its management type supports it
+func (h Host) SetBIOSSettings(ctx context.Context, settings hardware.BIOSSettings) error {
+	client, ok := h.Client.(remoteifc.BIOSClient)
+	if !ok {
+		return hardware.ErrBIOSNotSupported{NodeName: h.NodeName()}
+	}
+	return client.SetBIOSSettings(ctx, settings)
+}
+
+// FirmwareInventory retrieves firmware versions of the host if its management type supports it
+func (h Host) FirmwareInventory(ctx context.Context) ([]hardware.Firmware, error) {
+	client, ok := h.Client.(remoteifc.FirmwareClient)
+	if !ok {
+		return nil, hardware.ErrFirmwareUpdateNotSupported{NodeName: h.NodeName()}
+	}
+	return client.FirmwareInventory(ctx)
+}
+
+// UpdateFirmware updates firmware of the host from the image if its management type supports it
+func (h Host) UpdateFirmware(ctx context.Context, imageURL string) error {
+	client, ok := h.Client.(remoteifc.FirmwareClient)
+	if !ok {
+		return hardware.ErrFirmwareUpdateNotSupported{NodeName: h.NodeName()}
+	}
+	return client.UpdateFirmware(ctx, imageURL)
+}
 
 func (i Inventory) newHost(doc document.Document) (Host, error) {
 	address, err := document.GetBMHBMCAddress(doc)
@@ -135,12 +258,25 @@ func (i Inventory) newHost(doc document.Document) (Host, error) {
 		return Host{}, err
 	}
 
+	bootMACAddress, err := document.GetBMHBootMACAddress(doc)
+	if err != nil {
+		return Host{}, err
+	}
+
 	var clientFactory remoteifc.ClientFactory
 	switch i.mgmtCfg.Type {
 	case redfish.ClientType:
 		clientFactory = redfish.ClientFactory
 	case redfishdell.ClientType:
 		clientFactory = redfishdell.ClientFactory
+	case redfishhpe.ClientType:
+		clientFactory = redfishhpe.ClientFactory
+	case redfishsupermicro.ClientType:
+		clientFactory = redfishsupermicro.ClientFactory
+	case redfishauto.ClientType:
+		clientFactory = redfishauto.ClientFactory
+	case ipmi.ClientType:
+		clientFactory = ipmi.ClientFactory
 	default:
 		return Host{}, ErrRemoteDriverNotSupported{
 			BMHName:      doc.GetName(),
@@ -162,7 +298,7 @@ func (i Inventory) newHost(doc document.Document) (Host, error) {
 	if err != nil {
 		return Host{}, err
 	}
-	return Host{Client: client}, nil
+	return Host{Client: client, bootMACAddress: bootMACAddress}, nil
 }
 
 func action(ctx context.Context, op ifc.BaremetalOperation) (func(remoteifc.Client) error, error) {
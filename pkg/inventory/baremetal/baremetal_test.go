@@ -17,7 +17,10 @@ package baremetal
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -26,7 +29,10 @@ import (
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
 	testdoc "opendev.org/airship/airshipctl/testutil/document"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
 )
 
 const (
@@ -155,6 +161,12 @@ func TestSelect(t *testing.T) {
 			expectedErr:  "not supported",
 			selector:     (ifc.BaremetalHostSelector{}).ByLabel("host-group=control-plane"),
 		},
+		{
+			name:         "error redfish address with ipmi driver",
+			remoteDriver: "ipmi",
+			expectedErr:  "invalid IPMI BMC address",
+			selector:     (ifc.BaremetalHostSelector{}).ByName("master-0"),
+		},
 		{
 			name:         "error no credentials",
 			remoteDriver: "redfish",
@@ -224,6 +236,31 @@ func TestSelectOne(t *testing.T) {
 	}
 }
 
+func TestHost(t *testing.T) {
+	mgmCfg := &config.ManagementConfiguration{Type: "redfish"}
+	inventory := NewInventory(mgmCfg, testSelectBundle(t))
+	hosts, err := inventory.Select((ifc.BaremetalHostSelector{}).ByName("master-0"))
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+
+	host, ok := hosts[0].(ifc.BaremetalHost)
+	require.True(t, ok)
+	assert.Equal(t, "00:3b:8b:0c:ec:8b", host.BootMACAddress())
+
+	mockClient := &redfishutils.MockClient{}
+	mockClient.On("NodeName").Return("master-0")
+	_, err = Host{Client: mockClient}.HardwareInventory(context.Background())
+	assert.Equal(t, hardware.ErrInventoryNotSupported{NodeName: "master-0"}, err)
+	_, err = Host{Client: mockClient}.BIOSSettings(context.Background())
+	assert.Equal(t, hardware.ErrBIOSNotSupported{NodeName: "master-0"}, err)
+	err = Host{Client: mockClient}.SetBIOSSettings(context.Background(), hardware.BIOSSettings{})
+	assert.Equal(t, hardware.ErrBIOSNotSupported{NodeName: "master-0"}, err)
+	_, err = Host{Client: mockClient}.FirmwareInventory(context.Background())
+	assert.Equal(t, hardware.ErrFirmwareUpdateNotSupported{NodeName: "master-0"}, err)
+	err = Host{Client: mockClient}.UpdateFirmware(context.Background(), "http://localhost:8099/bios.exe")
+	assert.Equal(t, hardware.ErrFirmwareUpdateNotSupported{NodeName: "master-0"}, err)
+}
+
 func TestRunAction(t *testing.T) {
 	tests := []struct {
 		name, remoteDriver, expectedErr string
@@ -252,6 +289,13 @@ func TestRunAction(t *testing.T) {
 			selector:     (ifc.BaremetalHostSelector{}).ByName("master-0"),
 			expectedErr:  "HTTP request failed",
 		},
+		{
+			name:         "error against several hosts",
+			remoteDriver: "redfish",
+			operation:    ifc.BaremetalOperationPowerOn,
+			selector:     (ifc.BaremetalHostSelector{}).ByLabel("host-group=control-plane"),
+			expectedErr:  "Baremetal operation 'power-on' failed against 2 of 2 hosts",
+		},
 	}
 
 	bundle := testSelectBundle(t)
@@ -275,6 +319,164 @@ func TestRunAction(t *testing.T) {
 	}
 }
 
+func TestRunActionResults(t *testing.T) {
+	mgmCfg := config.ManagementConfiguration{Type: "redfish"}
+	inventory := NewInventory(&mgmCfg, testSelectBundle(t))
+	results := &ifc.BaremetalBatchRunResults{}
+	err := inventory.RunOperation(
+		context.Background(),
+		ifc.BaremetalOperationPowerOn,
+		(ifc.BaremetalHostSelector{}).ByName("master-0"),
+		ifc.BaremetalBatchRunOptions{Results: results})
+	require.Error(t, err)
+	assert.Equal(t, ifc.BaremetalOperationPowerOn, results.Operation)
+	require.Len(t, results.Hosts, 1)
+	assert.Equal(t, "master-0", results.Hosts[0].NodeName)
+	assert.Equal(t, err, results.Hosts[0].Error)
+}
+
+// fakeHost records the number of actions running against the hosts at the same time
+type fakeHost struct {
+	remoteifc.Client
+	name string
+	err  error
+
+	mu      *sync.Mutex
+	running *int
+	maxRun  *int
+}
+
+func (h fakeHost) NodeName() string { return h.name }
+func (h fakeHost) NodeID() string   { return h.name + "-id" }
+
+func (h fakeHost) SystemPowerOn(context.Context) error {
+	h.mu.Lock()
+	*h.running++
+	if *h.running > *h.maxRun {
+		*h.maxRun = *h.running
+	}
+	h.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	h.mu.Lock()
+	*h.running--
+	h.mu.Unlock()
+	return h.err
+}
+
+func TestRunBatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           ifc.BaremetalBatchRunOptions
+		failed         []int
+		expectedMaxRun int
+		expectedErrors []int
+		expectedSkips  []int
+	}{
+		{
+			name:           "sequential by default",
+			expectedMaxRun: 1,
+		},
+		{
+			name:           "concurrency limit",
+			opts:           ifc.BaremetalBatchRunOptions{Concurrency: 3},
+			expectedMaxRun: 3,
+		},
+		{
+			name:           "collect all errors",
+			opts:           ifc.BaremetalBatchRunOptions{Concurrency: 2},
+			failed:         []int{1, 4},
+			expectedMaxRun: 2,
+			expectedErrors: []int{1, 4},
+		},
+		{
+			name:           "fail fast",
+			opts:           ifc.BaremetalBatchRunOptions{FailFast: true},
+			failed:         []int{1, 4},
+			expectedMaxRun: 1,
+			expectedErrors: []int{1},
+			expectedSkips:  []int{2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var running, maxRun int
+			mu := &sync.Mutex{}
+			hosts := make([]remoteifc.Client, 6)
+			for i := range hosts {
+				hosts[i] = fakeHost{name: fmt.Sprintf("node-%d", i), mu: mu, running: &running, maxRun: &maxRun}
+			}
+			for _, i := range tt.failed {
+				host := hosts[i].(fakeHost)
+				host.err = fmt.Errorf("power on failed")
+				hosts[i] = host
+			}
+
+			hostAction, err := action(context.Background(), ifc.BaremetalOperationPowerOn)
+			require.NoError(t, err)
+			results := RunBatch(hosts, func(idx int, host remoteifc.Client) error {
+				assert.Equal(t, hosts[idx].NodeName(), host.NodeName())
+				return hostAction(host)
+			}, tt.opts)
+			require.Len(t, results, len(hosts))
+			assert.Equal(t, tt.expectedMaxRun, maxRun)
+
+			var errs, skips []int
+			for i, result := range results {
+				assert.Equal(t, hosts[i].NodeName(), result.NodeName)
+				assert.Equal(t, hosts[i].NodeID(), result.NodeID)
+				if result.Error != nil {
+					errs = append(errs, i)
+				}
+				if result.Skipped {
+					skips = append(skips, i)
+					assert.Zero(t, result.Duration)
+				}
+			}
+			assert.Equal(t, tt.expectedErrors, errs)
+			assert.Equal(t, tt.expectedSkips, skips)
+		})
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	hostErr := fmt.Errorf("power on failed")
+	results := ifc.BaremetalBatchRunResults{
+		Operation: ifc.BaremetalOperationPowerOn,
+		Hosts: []ifc.BaremetalHostResult{
+			{NodeName: "node-0"},
+			{NodeName: "node-1", Error: hostErr},
+			{NodeName: "node-2", Skipped: true},
+		},
+	}
+	assert.Equal(t, hostErr, BatchError(results))
+
+	results.Hosts[0].Error = hostErr
+	assert.Equal(t, ErrBatchOperationFailed{
+		Operation: ifc.BaremetalOperationPowerOn,
+		Total:     3,
+		Failed:    results.Hosts[:2],
+	}, BatchError(results))
+
+	assert.NoError(t, BatchError(ifc.BaremetalBatchRunResults{Hosts: results.Hosts[2:]}))
+}
+
+func TestErrBatchOperationFailed(t *testing.T) {
+	err := ErrBatchOperationFailed{
+		Operation: ifc.BaremetalOperationReboot,
+		Total:     3,
+		Failed: []ifc.BaremetalHostResult{
+			{NodeName: "node-0", Error: fmt.Errorf("timeout")},
+			{NodeName: "node-2", Error: fmt.Errorf("unauthorized")},
+		},
+	}
+	assert.Equal(t, "Baremetal operation 'reboot' failed against 2 of 3 hosts: 'node-0': timeout; "+
+		"'node-2': unauthorized", err.Error())
+}
+
 func TestAction(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -16,6 +16,7 @@ package baremetal
 
 import (
 	"fmt"
+	"strings"
 
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
 )
@@ -49,3 +50,19 @@ type ErrBaremetalOperationNotSupported struct {
 func (e ErrBaremetalOperationNotSupported) Error() string {
 	return fmt.Sprintf("Baremetal operation not supported: '%s'", e.Operation)
 }
+
+// ErrBatchOperationFailed is returned when baremetal operation fails against several hosts
+type ErrBatchOperationFailed struct {
+	Operation ifc.BaremetalOperation
+	Total     int
+	Failed    []ifc.BaremetalHostResult
+}
+
+func (e ErrBatchOperationFailed) Error() string {
+	errs := make([]string, 0, len(e.Failed))
+	for _, host := range e.Failed {
+		errs = append(errs, fmt.Sprintf("'%s': %v", host.NodeName, host.Error))
+	}
+	return fmt.Sprintf("Baremetal operation '%s' failed against %d of %d hosts: %s",
+		e.Operation, len(e.Failed), e.Total, strings.Join(errs, "; "))
+}
@@ -0,0 +1,216 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+// ConfigureBIOSCommand is used to store common variables from cmd flags for configure-bios command
+type ConfigureBIOSCommand struct {
+	Writer  io.Writer
+	Options *CommandOptions
+	// ConfigPath is either a YAML file or a kustomization directory with BIOSConfiguration document
+	ConfigPath string
+	// ConfigName selects BIOSConfiguration document by name if the path contains several of them
+	ConfigName string
+	DryRun     bool
+}
+
+// NewConfigureBIOSCommand ConfigureBIOSCommand constructor
+func NewConfigureBIOSCommand(options *CommandOptions) *ConfigureBIOSCommand {
+	return &ConfigureBIOSCommand{Options: options}
+}
+
+// RunE reads the desired BIOS settings from BIOSConfiguration document and applies them to the selected hosts
+func (c *ConfigureBIOSCommand) RunE() error {
+	if c.ConfigPath == "" {
+		return ErrInvalidOptions{Message: "path to BIOSConfiguration document must be specified"}
+	}
+
+	doc, err := configDocument(c.ConfigPath, c.ConfigName, v1alpha1.DefaultBIOSConfiguration())
+	if err != nil {
+		return err
+	}
+
+	settings, err := BIOSSettingsFromDocument(doc)
+	if err != nil {
+		return err
+	}
+	return c.Options.ConfigureBIOS(settings, c.DryRun, c.Writer)
+}
+
+// configDocument selects the document of the same kind as obj from the YAML file or the kustomization directory,
+// the name is required only if there are several such documents
+func configDocument(path, name string, obj runtime.Object) (document.Document, error) {
+	bundle, err := bundleByPath(path)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := document.NewSelector().ByObject(obj, v1alpha1.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return bundle.SelectOne(selector.ByName(name))
+}
+
+// bundleByPath builds bundle from a kustomization directory or from a YAML file
+func bundleByPath(path string) (document.Bundle, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return document.NewBundleByPath(path)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return document.NewBundleFromBytes(data)
+}
+
+// BIOSSettingsFromDocument returns the desired BIOS settings described by BIOSConfiguration document
+func BIOSSettingsFromDocument(doc document.Document) (hardware.BIOSSettings, error) {
+	cfg := v1alpha1.DefaultBIOSConfiguration()
+	if err := doc.ToAPIObject(cfg, v1alpha1.Scheme); err != nil {
+		return hardware.BIOSSettings{}, err
+	}
+
+	settings := hardware.BIOSSettings{BootOrder: cfg.Spec.BootOrder}
+	if cfg.Spec.Attributes != nil && len(cfg.Spec.Attributes.Raw) != 0 {
+		if err := json.Unmarshal(cfg.Spec.Attributes.Raw, &settings.Attributes); err != nil {
+			return hardware.BIOSSettings{}, ErrInvalidBIOSConfiguration{Name: doc.GetName(), Err: err}
+		}
+	}
+	return settings, nil
+}
+
+// HostBIOSSettingsDiff compares BIOS settings of the host with the desired ones
+func HostBIOSSettingsDiff(
+	ctx context.Context,
+	client remoteifc.Client,
+	desired hardware.BIOSSettings) (hardware.BIOSSettingsDiff, error) {
+	host, ok := client.(ifc.BaremetalHost)
+	if !ok {
+		return hardware.BIOSSettingsDiff{}, hardware.ErrBIOSNotSupported{NodeName: client.NodeName()}
+	}
+	return biosSettingsDiff(ctx, host, desired)
+}
+
+func biosSettingsDiff(
+	ctx context.Context,
+	host ifc.BaremetalHost,
+	desired hardware.BIOSSettings) (hardware.BIOSSettingsDiff, error) {
+	current, err := host.BIOSSettings(ctx)
+	if err != nil {
+		return hardware.BIOSSettingsDiff{}, err
+	}
+	return hardware.DiffBIOSSettings(current, desired)
+}
+
+// ConfigureBIOS brings BIOS settings of the selected hosts to the desired ones. Only the settings that differ
+// are changed, the hosts are rebooted afterwards for BIOS to apply them. The differences and the results of the
+// operation are written to w for every host, the settings aren't changed in dry run mode.
+func (o *CommandOptions) ConfigureBIOS(desired hardware.BIOSSettings, dryRun bool, w io.Writer) error {
+	if err := o.validateBMHAction(); err != nil {
+		return err
+	}
+
+	hosts, err := o.getAllHost()
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return baremetal.ErrNoBaremetalHostsFound{Selector: o.selector()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+
+	diffs := make([]hardware.BIOSSettingsDiff, len(hosts))
+	results := o.runHostBatch(ifc.BaremetalOperationConfigureBIOS, hosts,
+		func(idx int, host remoteifc.Client) (err error) {
+			diffs[idx], err = configureHostBIOS(ctx, host, desired, dryRun)
+			return err
+		})
+
+	writeBIOSSettingsDiffs(w, results.Hosts, diffs, dryRun)
+	if writeErr := WriteBatchResults(w, results); writeErr != nil {
+		log.Printf("Failed to write results of the operation '%s': %v", results.Operation, writeErr)
+	}
+	return baremetal.BatchError(results)
+}
+
+// configureHostBIOS applies the desired BIOS settings that differ from the current ones and reboots the host
+func configureHostBIOS(
+	ctx context.Context,
+	client remoteifc.Client,
+	desired hardware.BIOSSettings,
+	dryRun bool) (hardware.BIOSSettingsDiff, error) {
+	host, ok := client.(ifc.BaremetalHost)
+	if !ok {
+		return hardware.BIOSSettingsDiff{}, hardware.ErrBIOSNotSupported{NodeName: client.NodeName()}
+	}
+
+	diff, err := biosSettingsDiff(ctx, host, desired)
+	if err != nil || dryRun || diff.Empty() {
+		return diff, err
+	}
+
+	if err = host.SetBIOSSettings(ctx, diff.Changes()); err != nil {
+		return diff, err
+	}
+	log.Printf("BIOS settings of host '%s' are changed, rebooting the host to apply them", host.NodeName())
+	return diff, host.RebootSystem(ctx)
+}
+
+// writeBIOSSettingsDiffs writes the BIOS settings to change for every host the diff is known for
+func writeBIOSSettingsDiffs(w io.Writer, hosts []ifc.BaremetalHostResult, diffs []hardware.BIOSSettingsDiff,
+	dryRun bool) {
+	for idx, host := range hosts {
+		if host.Skipped || host.Error != nil && diffs[idx].Empty() {
+			continue
+		}
+		if diffs[idx].Empty() {
+			fmt.Fprintf(w, "BIOS settings of host '%s' are up to date\n", host.NodeName)
+			continue
+		}
+		fmt.Fprintf(w, "BIOS settings of host '%s' to change:\n", host.NodeName)
+		for _, line := range strings.Split(strings.TrimSuffix(diffs[idx].String(), "\n"), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+	if dryRun {
+		fmt.Fprintln(w, "Dry run, BIOS settings of the hosts are not changed")
+	}
+}
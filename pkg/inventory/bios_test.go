@@ -0,0 +1,223 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	mockinventory "opendev.org/airship/airshipctl/testutil/inventory"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+const biosConfiguration = `apiVersion: airshipit.org/v1alpha1
+kind: BIOSConfiguration
+metadata:
+  name: bios
+spec:
+  attributes:
+    ProcVirtualization: Enabled
+    SriovGlobalEnable: true
+    NumLock: "On"
+  bootOrder:
+  - Boot0002
+  - Boot0001
+`
+
+var currentBIOSSettings = hardware.BIOSSettings{
+	Attributes: map[string]interface{}{
+		"ProcVirtualization": "Disabled",
+		"SriovGlobalEnable":  true,
+		"NumLock":            "On",
+	},
+	BootOrder: []string{"Boot0001", "Boot0002"},
+}
+
+var desiredBIOSSettings = hardware.BIOSSettings{
+	Attributes: map[string]interface{}{
+		"ProcVirtualization": "Enabled",
+		"SriovGlobalEnable":  true,
+		"NumLock":            "On",
+	},
+	BootOrder: []string{"Boot0002", "Boot0001"},
+}
+
+var biosChanges = hardware.BIOSSettings{
+	Attributes: map[string]interface{}{"ProcVirtualization": "Enabled"},
+	BootOrder:  []string{"Boot0002", "Boot0001"},
+}
+
+func mockBIOSHost(name string, current hardware.BIOSSettings, err error) *mockinventory.MockBaremetalHost {
+	host := &mockinventory.MockBaremetalHost{}
+	host.On("NodeName").Return(name)
+	host.On("NodeID").Return(name + "-id")
+	host.On("BIOSSettings").Return(current, err)
+	return host
+}
+
+func batchOptions(hosts ...remoteifc.Client) *inventory.CommandOptions {
+	bmhInv := &mockinventory.MockBMHInventory{}
+	bmhInv.On("Select").Return(hosts, nil)
+
+	inv := &mockinventory.MockInventory{}
+	inv.On("BaremetalInventory").Return(bmhInv, nil)
+
+	co := inventory.NewOptions(inv)
+	co.All = true
+	co.Concurrency = 2
+	co.Timeout = time.Minute
+	return co
+}
+
+func TestBIOSSettingsFromDocument(t *testing.T) {
+	doc, err := document.NewDocumentFromBytes([]byte(biosConfiguration))
+	require.NoError(t, err)
+
+	settings, err := inventory.BIOSSettingsFromDocument(doc)
+	require.NoError(t, err)
+	assert.Equal(t, desiredBIOSSettings, settings)
+
+	doc, err = document.NewDocumentFromBytes([]byte(`apiVersion: airshipit.org/v1alpha1
+kind: BIOSConfiguration
+metadata:
+  name: bios
+spec:
+  attributes:
+  - ProcVirtualization
+`))
+	require.NoError(t, err)
+	_, err = inventory.BIOSSettingsFromDocument(doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "attributes of BIOSConfiguration 'bios' must be a map")
+}
+
+func TestConfigureBIOS(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		changed := mockBIOSHost("node-0", currentBIOSSettings, nil)
+		changed.On("SetBIOSSettings", biosChanges).Return(nil)
+		changed.On("RebootSystem").Return(nil)
+		upToDate := mockBIOSHost("node-1", desiredBIOSSettings, nil)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, batchOptions(changed, upToDate).ConfigureBIOS(desiredBIOSSettings, false, buf))
+		changed.AssertExpectations(t)
+		upToDate.AssertNotCalled(t, "SetBIOSSettings", biosChanges)
+		upToDate.AssertNotCalled(t, "RebootSystem")
+
+		assert.Contains(t, buf.String(), "BIOS settings of host 'node-0' to change:\n"+
+			"  ProcVirtualization: Disabled -> Enabled\n"+
+			"  BootOrder: [Boot0001 Boot0002] -> [Boot0002 Boot0001]\n"+
+			"BIOS settings of host 'node-1' are up to date\n")
+		assert.Regexp(t, `node-0\s+succeeded`, buf.String())
+		assert.Contains(t, buf.String(), "Operation 'configure-bios' succeeded against 2 of 2 hosts")
+		assert.NotContains(t, buf.String(), "Dry run")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		host := mockBIOSHost("node-0", currentBIOSSettings, nil)
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, batchOptions(host).ConfigureBIOS(desiredBIOSSettings, true, buf))
+		host.AssertNotCalled(t, "SetBIOSSettings", biosChanges)
+		host.AssertNotCalled(t, "RebootSystem")
+		assert.Contains(t, buf.String(), "  ProcVirtualization: Disabled -> Enabled\n")
+		assert.Contains(t, buf.String(), "Dry run, BIOS settings of the hosts are not changed\n")
+	})
+
+	t.Run("error single host", func(t *testing.T) {
+		host := mockBIOSHost("node-0", currentBIOSSettings, nil)
+		host.On("SetBIOSSettings", biosChanges).Return(fmt.Errorf("attribute is read only"))
+		buf := bytes.NewBuffer([]byte{})
+		err := batchOptions(host).ConfigureBIOS(desiredBIOSSettings, false, buf)
+		assert.Equal(t, fmt.Errorf("attribute is read only"), err)
+		host.AssertNotCalled(t, "RebootSystem")
+		assert.Regexp(t, `node-0\s+failed\s+\S+\s+attribute is read only`, buf.String())
+	})
+
+	t.Run("error several hosts", func(t *testing.T) {
+		unreachable := mockBIOSHost("node-0", hardware.BIOSSettings{}, fmt.Errorf("BMC is unreachable"))
+		unknown := mockBIOSHost("node-1", hardware.BIOSSettings{}, nil)
+		unsupported := &redfishutils.MockClient{}
+		unsupported.On("NodeName").Return("node-2")
+		unsupported.On("NodeID").Return("node-2-id")
+
+		buf := bytes.NewBuffer([]byte{})
+		err := batchOptions(unreachable, unknown, unsupported).ConfigureBIOS(desiredBIOSSettings, false, buf)
+		batchErr, ok := err.(baremetal.ErrBatchOperationFailed)
+		require.True(t, ok)
+		assert.Equal(t, 3, batchErr.Total)
+		require.Len(t, batchErr.Failed, 3)
+		assert.Equal(t, hardware.ErrUnknownBIOSAttributes{
+			Attributes: []string{"NumLock", "ProcVirtualization", "SriovGlobalEnable"},
+		}, batchErr.Failed[1].Error)
+		assert.Equal(t, hardware.ErrBIOSNotSupported{NodeName: "node-2"}, batchErr.Failed[2].Error)
+		assert.NotContains(t, buf.String(), "to change")
+	})
+
+	t.Run("error no hosts", func(t *testing.T) {
+		err := batchOptions().ConfigureBIOS(desiredBIOSSettings, false, bytes.NewBuffer([]byte{}))
+		assert.IsType(t, baremetal.ErrNoBaremetalHostsFound{}, err)
+	})
+
+	t.Run("error invalid options", func(t *testing.T) {
+		co := batchOptions()
+		co.All = false
+		err := co.ConfigureBIOS(desiredBIOSSettings, false, bytes.NewBuffer([]byte{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
+	})
+}
+
+func TestConfigureBIOSCommand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "bios.yaml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(biosConfiguration), 0600))
+
+	t.Run("success", func(t *testing.T) {
+		host := mockBIOSHost("node-0", currentBIOSSettings, nil)
+		c := inventory.NewConfigureBIOSCommand(batchOptions(host))
+		c.ConfigPath = configPath
+		c.DryRun = true
+		buf := bytes.NewBuffer([]byte{})
+		c.Writer = buf
+		require.NoError(t, c.RunE())
+		assert.Contains(t, buf.String(), "BIOS settings of host 'node-0' to change:\n")
+	})
+
+	t.Run("error document not found", func(t *testing.T) {
+		c := inventory.NewConfigureBIOSCommand(batchOptions())
+		c.ConfigPath = configPath
+		c.ConfigName = "other"
+		c.Writer = bytes.NewBuffer([]byte{})
+		require.Error(t, c.RunE())
+	})
+
+	t.Run("error no path", func(t *testing.T) {
+		c := inventory.NewConfigureBIOSCommand(batchOptions())
+		err := c.RunE()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "path to BIOSConfiguration document must be specified")
+	})
+}
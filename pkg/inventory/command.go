@@ -21,7 +21,9 @@ import (
 	"time"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
 	"opendev.org/airship/airshipctl/pkg/util"
 	"opendev.org/airship/airshipctl/pkg/util/yaml"
@@ -44,6 +46,11 @@ type CommandOptions struct {
 	IsoURL    string
 	Timeout   time.Duration
 
+	// Concurrency is the maximum number of hosts a batch action is performed against at the same time
+	Concurrency int
+	// FailFast stops a batch action once it fails against any host
+	FailFast bool
+
 	Inventory ifc.Inventory
 }
 
@@ -99,8 +106,8 @@ func (l *ListHostsCommand) RunE() error {
 	return l.Write(hostClients)
 }
 
-// BMHAction performs an action against BaremetalHost objects
-func (o *CommandOptions) BMHAction(op ifc.BaremetalOperation) error {
+// BMHAction performs an action against BaremetalHost objects and writes results for every host to w
+func (o *CommandOptions) BMHAction(op ifc.BaremetalOperation, w io.Writer) error {
 	if err := o.validateBMHAction(); err != nil {
 		return err
 	}
@@ -112,11 +119,23 @@ func (o *CommandOptions) BMHAction(op ifc.BaremetalOperation) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
 	defer cancel()
-	return bmhInventory.RunOperation(
+	results := &ifc.BaremetalBatchRunResults{}
+	err = bmhInventory.RunOperation(
 		ctx,
 		op,
 		o.selector(),
-		ifc.BaremetalBatchRunOptions{})
+		ifc.BaremetalBatchRunOptions{
+			Concurrency: o.Concurrency,
+			FailFast:    o.FailFast,
+			Results:     results,
+		})
+	if len(results.Hosts) == 0 {
+		return err
+	}
+	if writeErr := WriteBatchResults(w, *results); writeErr != nil {
+		log.Printf("Failed to write results of the operation '%s': %v", op, writeErr)
+	}
+	return err
 }
 
 // RemoteDirect perform RemoteDirect operation against single host
@@ -191,3 +210,44 @@ func (l *ListHostsCommand) Write(clients []remoteifc.Client) error {
 	}
 	return util.PrintObjects(hostList, util.HostListFormat, l.Writer, false)
 }
+
+// WriteBatchResults writes table with the result of the batch operation for every host followed by the summary
+func WriteBatchResults(w io.Writer, results ifc.BaremetalBatchRunResults) error {
+	var succeeded, failed, skipped int
+	tw := util.GetNewTabWriter(w)
+	fmt.Fprintln(tw, "NODE NAME\tRESULT\tDURATION\tERROR")
+	for _, host := range results.Hosts {
+		result, duration, hostErr := "succeeded", host.Duration.Round(time.Millisecond).String(), ""
+		switch {
+		case host.Skipped:
+			skipped++
+			result, duration = "skipped", ""
+		case host.Error != nil:
+			failed++
+			result, hostErr = "failed", host.Error.Error()
+		default:
+			succeeded++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", host.NodeName, result, duration, hostErr)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Operation '%s' succeeded against %d of %d hosts, failed against %d, skipped %d\n",
+		results.Operation, succeeded, len(results.Hosts), failed, skipped)
+	return err
+}
+
+// runHostBatch performs the action against the hosts with concurrency and fail fast mode of the command options
+func (o *CommandOptions) runHostBatch(
+	op ifc.BaremetalOperation,
+	hosts []remoteifc.Client,
+	hostAction func(idx int, host remoteifc.Client) error) ifc.BaremetalBatchRunResults {
+	return ifc.BaremetalBatchRunResults{
+		Operation: op,
+		Hosts: baremetal.RunBatch(hosts, hostAction, ifc.BaremetalBatchRunOptions{
+			Concurrency: o.Concurrency,
+			FailFast:    o.FailFast,
+		}),
+	}
+}
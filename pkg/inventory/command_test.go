@@ -17,7 +17,10 @@ package inventory_test
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -94,7 +97,7 @@ func TestCommandOptions(t *testing.T) {
 
 		co := inventory.NewOptions(inv)
 		co.All = true
-		actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn, ioutil.Discard)
 		assert.Equal(t, expectedErr, actualErr)
 	})
 
@@ -102,7 +105,7 @@ func TestCommandOptions(t *testing.T) {
 		inv := &mockinventory.MockInventory{}
 
 		co := inventory.NewOptions(inv)
-		err := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		err := co.BMHAction(ifc.BaremetalOperationPowerOn, ioutil.Discard)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
 	})
@@ -113,7 +116,7 @@ func TestCommandOptions(t *testing.T) {
 		co := inventory.NewOptions(inv)
 		co.All = true
 		co.Labels = "foo=bar"
-		err := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		err := co.BMHAction(ifc.BaremetalOperationPowerOn, ioutil.Discard)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
 	})
@@ -127,10 +130,33 @@ func TestCommandOptions(t *testing.T) {
 
 		co := inventory.NewOptions(inv)
 		co.All = true
-		actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn, ioutil.Discard)
 		assert.Equal(t, nil, actualErr)
 	})
 
+	t.Run("error BMHAction with results", func(t *testing.T) {
+		expectedErr := fmt.Errorf("power on failed")
+		bmhInv := &mockinventory.MockBMHInventory{}
+		bmhInv.On("RunOperation").Once().Return(expectedErr, ifc.BaremetalBatchRunResults{
+			Operation: ifc.BaremetalOperationPowerOn,
+			Hosts: []ifc.BaremetalHostResult{
+				{NodeName: "node-0", Duration: 1500 * time.Millisecond},
+				{NodeName: "node-1", Error: expectedErr},
+			},
+		})
+
+		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+
+		co := inventory.NewOptions(inv)
+		co.All = true
+		buf := bytes.NewBuffer([]byte{})
+		actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn, buf)
+		assert.Equal(t, expectedErr, actualErr)
+		assert.Contains(t, buf.String(), "node-1")
+		assert.Contains(t, buf.String(), "Operation 'power-on' succeeded against 1 of 2 hosts, failed against 1, skipped 0")
+	})
+
 	t.Run("error PowerStatus SelectOne", func(t *testing.T) {
 		expectedErr := fmt.Errorf("SelectOne inventory error")
 		bmhInv := &mockinventory.MockBMHInventory{}
@@ -268,3 +294,23 @@ func TestCommandOptions(t *testing.T) {
 		assert.Len(t, buf.Bytes(), 0)
 	})
 }
+
+func TestWriteBatchResults(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	err := inventory.WriteBatchResults(buf, ifc.BaremetalBatchRunResults{
+		Operation: ifc.BaremetalOperationReboot,
+		Hosts: []ifc.BaremetalHostResult{
+			{NodeName: "node-0", Duration: 1500 * time.Millisecond},
+			{NodeName: "node-1", Error: fmt.Errorf("connection refused"), Duration: 2 * time.Second},
+			{NodeName: "node-2", Skipped: true},
+		},
+	})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, []string{"NODE", "NAME", "RESULT", "DURATION", "ERROR"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"node-0", "succeeded", "1.5s"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"node-1", "failed", "2s", "connection", "refused"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"node-2", "skipped"}, strings.Fields(lines[3]))
+	assert.Equal(t, "Operation 'reboot' succeeded against 1 of 3 hosts, failed against 1, skipped 1", lines[4])
+}
@@ -14,7 +14,10 @@
 
 package inventory
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrInvalidOptions is returned when incompatible flags are
 type ErrInvalidOptions struct {
@@ -24,3 +27,58 @@ type ErrInvalidOptions struct {
 func (e ErrInvalidOptions) Error() string {
 	return fmt.Sprintf("invalid options are supplied: %s", e.Message)
 }
+
+// ErrHardwareInventoryFailed is returned when hardware inventory of some hosts can't be retrieved or their
+// boot MAC addresses don't belong to any of their NICs
+type ErrHardwareInventoryFailed struct {
+	Failed          []string
+	BootMACMismatch []string
+}
+
+func (e ErrHardwareInventoryFailed) Error() string {
+	var problems []string
+	if len(e.Failed) != 0 {
+		problems = append(problems, fmt.Sprintf("failed to retrieve hardware inventory of hosts '%s'",
+			strings.Join(e.Failed, "', '")))
+	}
+	if len(e.BootMACMismatch) != 0 {
+		problems = append(problems, fmt.Sprintf("boot MAC address doesn't match any NIC of hosts '%s'",
+			strings.Join(e.BootMACMismatch, "', '")))
+	}
+	return strings.Join(problems, "; ")
+}
+
+// ErrInvalidBIOSConfiguration is returned when BIOS attributes of BIOSConfiguration document aren't a map
+type ErrInvalidBIOSConfiguration struct {
+	Name string
+	Err  error
+}
+
+func (e ErrInvalidBIOSConfiguration) Error() string {
+	return fmt.Sprintf("attributes of BIOSConfiguration '%s' must be a map of attribute names to values: %v",
+		e.Name, e.Err)
+}
+
+// ErrInvalidFirmwareConfiguration is returned when a firmware component of FirmwareConfiguration document misses
+// a required field
+type ErrInvalidFirmwareConfiguration struct {
+	Name  string
+	Index int
+	Field string
+}
+
+func (e ErrInvalidFirmwareConfiguration) Error() string {
+	return fmt.Sprintf("firmware[%d] of FirmwareConfiguration '%s' must have %s", e.Index, e.Name, e.Field)
+}
+
+// ErrFirmwareUpdateFailed is returned when a firmware component of a host can't be updated
+type ErrFirmwareUpdateFailed struct {
+	Component string
+	Version   string
+	Err       error
+}
+
+func (e ErrFirmwareUpdateFailed) Error() string {
+	return fmt.Sprintf("failed to update firmware component '%s' to version '%s': %v", e.Component, e.Version,
+		e.Err)
+}
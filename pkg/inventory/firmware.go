@@ -0,0 +1,199 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+// FirmwareUpdateCommand is used to store common variables from cmd flags for firmware command
+type FirmwareUpdateCommand struct {
+	Writer  io.Writer
+	Options *CommandOptions
+	// ConfigPath is either a YAML file or a kustomization directory with FirmwareConfiguration document
+	ConfigPath string
+	// ConfigName selects FirmwareConfiguration document by name if the path contains several of them
+	ConfigName string
+	DryRun     bool
+}
+
+// NewFirmwareUpdateCommand FirmwareUpdateCommand constructor
+func NewFirmwareUpdateCommand(options *CommandOptions) *FirmwareUpdateCommand {
+	return &FirmwareUpdateCommand{Options: options}
+}
+
+// RunE reads the desired firmware versions from FirmwareConfiguration document and updates firmware of the
+// selected hosts to them
+func (c *FirmwareUpdateCommand) RunE() error {
+	if c.ConfigPath == "" {
+		return ErrInvalidOptions{Message: "path to FirmwareConfiguration document must be specified"}
+	}
+
+	doc, err := configDocument(c.ConfigPath, c.ConfigName, v1alpha1.DefaultFirmwareConfiguration())
+	if err != nil {
+		return err
+	}
+
+	targets, err := FirmwareTargetsFromDocument(doc)
+	if err != nil {
+		return err
+	}
+	return c.Options.UpdateFirmware(targets, c.DryRun, c.Writer)
+}
+
+// FirmwareTargetsFromDocument returns the desired firmware versions described by FirmwareConfiguration document
+func FirmwareTargetsFromDocument(doc document.Document) ([]hardware.FirmwareTarget, error) {
+	cfg := v1alpha1.DefaultFirmwareConfiguration()
+	if err := doc.ToAPIObject(cfg, v1alpha1.Scheme); err != nil {
+		return nil, err
+	}
+
+	targets := make([]hardware.FirmwareTarget, 0, len(cfg.Spec.Firmware))
+	for idx, fw := range cfg.Spec.Firmware {
+		var missing string
+		switch {
+		case fw.Component == "":
+			missing = "component"
+		case fw.Version == "":
+			missing = "version"
+		case fw.ImageURL == "":
+			missing = "imageURL"
+		}
+		if missing != "" {
+			return nil, ErrInvalidFirmwareConfiguration{Name: doc.GetName(), Index: idx, Field: missing}
+		}
+		targets = append(targets, hardware.FirmwareTarget{
+			Component: fw.Component,
+			Version:   fw.Version,
+			ImageURL:  fw.ImageURL,
+		})
+	}
+	return targets, nil
+}
+
+// HostFirmwareUpdates returns the firmware updates required to bring firmware of the host to the desired versions
+func HostFirmwareUpdates(
+	ctx context.Context,
+	client remoteifc.Client,
+	targets []hardware.FirmwareTarget) ([]hardware.FirmwareUpdate, error) {
+	host, ok := client.(ifc.BaremetalHost)
+	if !ok {
+		return nil, hardware.ErrFirmwareUpdateNotSupported{NodeName: client.NodeName()}
+	}
+	return firmwareUpdates(ctx, host, targets)
+}
+
+func firmwareUpdates(
+	ctx context.Context,
+	host ifc.BaremetalHost,
+	targets []hardware.FirmwareTarget) ([]hardware.FirmwareUpdate, error) {
+	components, err := host.FirmwareInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return hardware.PlanFirmwareUpdates(components, targets)
+}
+
+// UpdateFirmware brings firmware of the selected hosts to the desired versions. Only the components which versions
+// differ are updated one by one, every update is awaited until the BMC completes it. Some components, e.g. BIOS,
+// apply the new firmware on the next reboot of the host only. The updates and the results of the operation are
+// written to w for every host, the firmware isn't updated in dry run mode.
+func (o *CommandOptions) UpdateFirmware(targets []hardware.FirmwareTarget, dryRun bool, w io.Writer) error {
+	if err := o.validateBMHAction(); err != nil {
+		return err
+	}
+
+	hosts, err := o.getAllHost()
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return baremetal.ErrNoBaremetalHostsFound{Selector: o.selector()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+
+	updates := make([][]hardware.FirmwareUpdate, len(hosts))
+	results := o.runHostBatch(ifc.BaremetalOperationFirmwareUpdate, hosts,
+		func(idx int, host remoteifc.Client) (err error) {
+			updates[idx], err = updateHostFirmware(ctx, host, targets, dryRun)
+			return err
+		})
+
+	writeFirmwareUpdates(w, results.Hosts, updates, dryRun)
+	if writeErr := WriteBatchResults(w, results); writeErr != nil {
+		log.Printf("Failed to write results of the operation '%s': %v", results.Operation, writeErr)
+	}
+	return baremetal.BatchError(results)
+}
+
+// updateHostFirmware updates the firmware components of the host which versions differ from the desired ones in
+// the order of the targets, it stops at the first failed update
+func updateHostFirmware(
+	ctx context.Context,
+	client remoteifc.Client,
+	targets []hardware.FirmwareTarget,
+	dryRun bool) ([]hardware.FirmwareUpdate, error) {
+	host, ok := client.(ifc.BaremetalHost)
+	if !ok {
+		return nil, hardware.ErrFirmwareUpdateNotSupported{NodeName: client.NodeName()}
+	}
+
+	updates, err := firmwareUpdates(ctx, host, targets)
+	if err != nil || dryRun {
+		return updates, err
+	}
+
+	for _, update := range updates {
+		log.Printf("Updating firmware component '%s' of host '%s' to version '%s'", update.Component,
+			host.NodeName(), update.Version)
+		if err = host.UpdateFirmware(ctx, update.ImageURL); err != nil {
+			return updates, ErrFirmwareUpdateFailed{Component: update.Component, Version: update.Version, Err: err}
+		}
+	}
+	return updates, nil
+}
+
+// writeFirmwareUpdates writes the firmware updates for every host they are known for
+func writeFirmwareUpdates(w io.Writer, hosts []ifc.BaremetalHostResult, updates [][]hardware.FirmwareUpdate,
+	dryRun bool) {
+	for idx, host := range hosts {
+		if host.Skipped || host.Error != nil && len(updates[idx]) == 0 {
+			continue
+		}
+		if len(updates[idx]) == 0 {
+			fmt.Fprintf(w, "Firmware of host '%s' is up to date\n", host.NodeName)
+			continue
+		}
+		fmt.Fprintf(w, "Firmware of host '%s' to update:\n", host.NodeName)
+		for _, update := range updates[idx] {
+			fmt.Fprintf(w, "  %s\n", update)
+		}
+	}
+	if dryRun {
+		fmt.Fprintln(w, "Dry run, firmware of the hosts is not updated")
+	}
+}
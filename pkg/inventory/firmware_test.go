@@ -0,0 +1,205 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	mockinventory "opendev.org/airship/airshipctl/testutil/inventory"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+const firmwareConfiguration = `apiVersion: airshipit.org/v1alpha1
+kind: FirmwareConfiguration
+metadata:
+  name: firmware
+spec:
+  firmware:
+  - component: BIOS
+    version: 2.12.2
+    imageURL: http://localhost:8099/bios-2.12.2.exe
+  - component: BMC
+    version: 5.00.00.00
+    imageURL: http://localhost:8099/bmc-5.00.00.00.exe
+`
+
+var (
+	biosTarget = hardware.FirmwareTarget{
+		Component: "BIOS",
+		Version:   "2.12.2",
+		ImageURL:  "http://localhost:8099/bios-2.12.2.exe",
+	}
+	bmcTarget = hardware.FirmwareTarget{
+		Component: "BMC",
+		Version:   "5.00.00.00",
+		ImageURL:  "http://localhost:8099/bmc-5.00.00.00.exe",
+	}
+	firmwareTargets = []hardware.FirmwareTarget{biosTarget, bmcTarget}
+
+	outdatedFirmware = []hardware.Firmware{
+		{ID: "BIOS", Version: "2.10.2"},
+		{ID: "BMC", Version: "5.00.00.00"},
+	}
+	upToDateFirmware = []hardware.Firmware{
+		{ID: "BIOS", Version: "2.12.2"},
+		{ID: "BMC", Version: "5.00.00.00"},
+	}
+)
+
+func mockFirmwareHost(name string, components []hardware.Firmware, err error) *mockinventory.MockBaremetalHost {
+	host := &mockinventory.MockBaremetalHost{}
+	host.On("NodeName").Return(name)
+	host.On("NodeID").Return(name + "-id")
+	host.On("FirmwareInventory").Return(components, err)
+	return host
+}
+
+func TestFirmwareTargetsFromDocument(t *testing.T) {
+	doc, err := document.NewDocumentFromBytes([]byte(firmwareConfiguration))
+	require.NoError(t, err)
+
+	targets, err := inventory.FirmwareTargetsFromDocument(doc)
+	require.NoError(t, err)
+	assert.Equal(t, firmwareTargets, targets)
+
+	doc, err = document.NewDocumentFromBytes([]byte(`apiVersion: airshipit.org/v1alpha1
+kind: FirmwareConfiguration
+metadata:
+  name: firmware
+spec:
+  firmware:
+  - component: BIOS
+    version: 2.12.2
+    imageURL: http://localhost:8099/bios-2.12.2.exe
+  - component: BMC
+`))
+	require.NoError(t, err)
+	_, err = inventory.FirmwareTargetsFromDocument(doc)
+	assert.Equal(t, inventory.ErrInvalidFirmwareConfiguration{Name: "firmware", Index: 1, Field: "version"}, err)
+}
+
+func TestUpdateFirmware(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		outdated := mockFirmwareHost("node-0", outdatedFirmware, nil)
+		outdated.On("UpdateFirmware", biosTarget.ImageURL).Return(nil)
+		upToDate := mockFirmwareHost("node-1", upToDateFirmware, nil)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, batchOptions(outdated, upToDate).UpdateFirmware(firmwareTargets, false, buf))
+		outdated.AssertExpectations(t)
+		outdated.AssertNotCalled(t, "UpdateFirmware", bmcTarget.ImageURL)
+		upToDate.AssertNotCalled(t, "UpdateFirmware", biosTarget.ImageURL)
+
+		assert.Contains(t, buf.String(), "Firmware of host 'node-0' to update:\n"+
+			"  BIOS: 2.10.2 -> 2.12.2\n"+
+			"Firmware of host 'node-1' is up to date\n")
+		assert.Regexp(t, `node-0\s+succeeded`, buf.String())
+		assert.Contains(t, buf.String(), "Operation 'firmware-update' succeeded against 2 of 2 hosts")
+		assert.NotContains(t, buf.String(), "Dry run")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		host := mockFirmwareHost("node-0", outdatedFirmware, nil)
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, batchOptions(host).UpdateFirmware(firmwareTargets, true, buf))
+		host.AssertNotCalled(t, "UpdateFirmware", biosTarget.ImageURL)
+		assert.Contains(t, buf.String(), "  BIOS: 2.10.2 -> 2.12.2\n")
+		assert.Contains(t, buf.String(), "Dry run, firmware of the hosts is not updated\n")
+	})
+
+	t.Run("error single host", func(t *testing.T) {
+		host := mockFirmwareHost("node-0", []hardware.Firmware{
+			{ID: "BIOS", Version: "2.10.2"},
+			{ID: "BMC", Version: "4.40.00.00"},
+		}, nil)
+		host.On("UpdateFirmware", biosTarget.ImageURL).Return(fmt.Errorf("image is corrupted"))
+		buf := bytes.NewBuffer([]byte{})
+		err := batchOptions(host).UpdateFirmware(firmwareTargets, false, buf)
+		assert.Equal(t, inventory.ErrFirmwareUpdateFailed{
+			Component: "BIOS",
+			Version:   "2.12.2",
+			Err:       fmt.Errorf("image is corrupted"),
+		}, err)
+		host.AssertNotCalled(t, "UpdateFirmware", bmcTarget.ImageURL)
+		assert.Contains(t, buf.String(), "  BMC: 4.40.00.00 -> 5.00.00.00\n")
+		assert.Regexp(t, `node-0\s+failed\s+\S+\s+failed to update firmware component 'BIOS' to version '2.12.2': `+
+			`image is corrupted`, buf.String())
+	})
+
+	t.Run("error several hosts", func(t *testing.T) {
+		unreachable := mockFirmwareHost("node-0", nil, fmt.Errorf("BMC is unreachable"))
+		unknown := mockFirmwareHost("node-1", []hardware.Firmware{{ID: "BIOS", Version: "2.12.2"}}, nil)
+		unsupported := &redfishutils.MockClient{}
+		unsupported.On("NodeName").Return("node-2")
+		unsupported.On("NodeID").Return("node-2-id")
+
+		buf := bytes.NewBuffer([]byte{})
+		err := batchOptions(unreachable, unknown, unsupported).UpdateFirmware(firmwareTargets, false, buf)
+		batchErr, ok := err.(baremetal.ErrBatchOperationFailed)
+		require.True(t, ok)
+		assert.Equal(t, 3, batchErr.Total)
+		require.Len(t, batchErr.Failed, 3)
+		assert.Equal(t, hardware.ErrUnknownFirmwareComponents{Components: []string{"BMC"}}, batchErr.Failed[1].Error)
+		assert.Equal(t, hardware.ErrFirmwareUpdateNotSupported{NodeName: "node-2"}, batchErr.Failed[2].Error)
+		assert.NotContains(t, buf.String(), "to update")
+	})
+
+	t.Run("error no hosts", func(t *testing.T) {
+		err := batchOptions().UpdateFirmware(firmwareTargets, false, bytes.NewBuffer([]byte{}))
+		assert.IsType(t, baremetal.ErrNoBaremetalHostsFound{}, err)
+	})
+}
+
+func TestFirmwareUpdateCommand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "firmware.yaml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(firmwareConfiguration), 0600))
+
+	t.Run("success", func(t *testing.T) {
+		host := mockFirmwareHost("node-0", outdatedFirmware, nil)
+		c := inventory.NewFirmwareUpdateCommand(batchOptions(host))
+		c.ConfigPath = configPath
+		c.DryRun = true
+		buf := bytes.NewBuffer([]byte{})
+		c.Writer = buf
+		require.NoError(t, c.RunE())
+		assert.Contains(t, buf.String(), "Firmware of host 'node-0' to update:\n")
+	})
+
+	t.Run("error document not found", func(t *testing.T) {
+		c := inventory.NewFirmwareUpdateCommand(batchOptions())
+		c.ConfigPath = configPath
+		c.ConfigName = "other"
+		c.Writer = bytes.NewBuffer([]byte{})
+		require.Error(t, c.RunE())
+	})
+
+	t.Run("error no path", func(t *testing.T) {
+		c := inventory.NewFirmwareUpdateCommand(batchOptions())
+		err := c.RunE()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "path to FirmwareConfiguration document must be specified")
+	})
+}
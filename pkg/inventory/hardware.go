@@ -0,0 +1,215 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/util"
+	"opendev.org/airship/airshipctl/pkg/util/yaml"
+)
+
+const (
+	// JSONOutputFormat json
+	JSONOutputFormat = "json"
+
+	// BootMACMatch means that the boot MAC address of the host belongs to one of its NICs
+	BootMACMatch = "match"
+	// BootMACMismatch means that none of the host NICs has the boot MAC address, the BareMetalHost document
+	// most likely describes another host or the host is cabled wrong
+	BootMACMismatch = "mismatch"
+	// BootMACUnknown means that the boot MAC address isn't specified or the NICs of the host aren't known
+	BootMACUnknown = "unknown"
+)
+
+// hardwareOutputFormats lists formats supported by the hardware inventory command
+var hardwareOutputFormats = []string{TableOutputFormat, YamlOutputFormat, JSONOutputFormat}
+
+// HardwareInventoryCommand is used to store common variables from cmd flags for inventory command
+type HardwareInventoryCommand struct {
+	Writer       io.Writer
+	Options      *CommandOptions
+	OutputFormat string
+}
+
+// NewHardwareInventoryCommand HardwareInventoryCommand constructor
+func NewHardwareInventoryCommand(options *CommandOptions) *HardwareInventoryCommand {
+	return &HardwareInventoryCommand{Options: options, OutputFormat: TableOutputFormat}
+}
+
+// HostHardwareInventory is the hardware inventory of a single baremetal host
+type HostHardwareInventory struct {
+	NodeName       string `json:"nodeName"`
+	NodeID         string `json:"nodeID"`
+	BootMACAddress string `json:"bootMACAddress,omitempty"`
+	// BootMACCheck is the result of looking up the boot MAC address among the MAC addresses of the host NICs
+	BootMACCheck string              `json:"bootMACCheck"`
+	Hardware     *hardware.Inventory `json:"hardware,omitempty"`
+	Error        string              `json:"error,omitempty"`
+}
+
+// RunE retrieves hardware inventory of the selected hosts and writes it in the requested format. An error is
+// returned if the inventory of any host can't be retrieved or its boot MAC address doesn't match its NICs.
+func (c *HardwareInventoryCommand) RunE() error {
+	if err := checkHardwareOutputFormat(c.OutputFormat); err != nil {
+		return err
+	}
+	if err := c.Options.validateBMHAction(); err != nil {
+		return err
+	}
+
+	hosts, err := c.Options.getAllHost()
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("no hosts present in the hostInventory")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Options.Timeout)
+	defer cancel()
+	inventories := hardwareInventories(ctx, hosts, ifc.BaremetalBatchRunOptions{
+		Concurrency: c.Options.Concurrency,
+		FailFast:    c.Options.FailFast,
+	})
+	if err = c.Write(inventories); err != nil {
+		return err
+	}
+
+	var failed, mismatched []string
+	for _, inventory := range inventories {
+		switch {
+		case inventory.Error != "":
+			failed = append(failed, inventory.NodeName)
+		case inventory.BootMACCheck == BootMACMismatch:
+			mismatched = append(mismatched, inventory.NodeName)
+		}
+	}
+	if len(failed) != 0 || len(mismatched) != 0 {
+		return ErrHardwareInventoryFailed{Failed: failed, BootMACMismatch: mismatched}
+	}
+	return nil
+}
+
+// Write writes hardware inventory of the hosts in the output format of the command
+func (c *HardwareInventoryCommand) Write(inventories []HostHardwareInventory) error {
+	switch c.OutputFormat {
+	case YamlOutputFormat:
+		return yaml.WriteOut(c.Writer, inventories)
+	case JSONOutputFormat:
+		enc := json.NewEncoder(c.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(inventories)
+	default:
+		return writeHardwareTable(c.Writer, inventories)
+	}
+}
+
+// checkHardwareOutputFormat makes sure that output format is supported by the hardware inventory command
+func checkHardwareOutputFormat(format string) error {
+	for _, f := range hardwareOutputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return ErrInvalidOptions{Message: fmt.Sprintf("output format '%s'. Supported options are %v",
+		format, hardwareOutputFormats)}
+}
+
+// hardwareInventories retrieves hardware inventory of up to opts.Concurrency hosts at the same time, the
+// inventories are returned in the order of the hosts. Inventories of the hosts skipped in fail fast mode
+// report that they are skipped.
+func hardwareInventories(
+	ctx context.Context,
+	hosts []remoteifc.Client,
+	opts ifc.BaremetalBatchRunOptions) []HostHardwareInventory {
+	inventories := make([]HostHardwareInventory, len(hosts))
+	results := baremetal.RunBatch(hosts, func(idx int, host remoteifc.Client) error {
+		inventories[idx] = hostHardwareInventory(ctx, host)
+		if inventories[idx].Error != "" {
+			return errors.New(inventories[idx].Error)
+		}
+		return nil
+	}, opts)
+	for idx, result := range results {
+		if result.Skipped {
+			inventories[idx] = HostHardwareInventory{
+				NodeName:     result.NodeName,
+				NodeID:       result.NodeID,
+				BootMACCheck: BootMACUnknown,
+				Error:        "skipped since hardware inventory of another host can't be retrieved",
+			}
+		}
+	}
+	return inventories
+}
+
+func hostHardwareInventory(ctx context.Context, client remoteifc.Client) HostHardwareInventory {
+	inventory := HostHardwareInventory{
+		NodeName:     client.NodeName(),
+		NodeID:       client.NodeID(),
+		BootMACCheck: BootMACUnknown,
+	}
+
+	host, ok := client.(ifc.BaremetalHost)
+	if !ok {
+		inventory.Error = hardware.ErrInventoryNotSupported{NodeName: inventory.NodeName}.Error()
+		return inventory
+	}
+	inventory.BootMACAddress = host.BootMACAddress()
+
+	hw, err := host.HardwareInventory(ctx)
+	if err != nil {
+		log.Debugf("Failed to retrieve hardware inventory of host '%s': %v", inventory.NodeName, err)
+		inventory.Error = err.Error()
+		return inventory
+	}
+	inventory.Hardware = &hw
+
+	if inventory.BootMACAddress != "" && len(hw.NICs) != 0 {
+		inventory.BootMACCheck = BootMACMismatch
+		if hw.HasMACAddress(inventory.BootMACAddress) {
+			inventory.BootMACCheck = BootMACMatch
+		}
+	}
+	return inventory
+}
+
+// writeHardwareTable writes summary of the hardware inventory of every host, NICs and disks are counted, their
+// details are available in yaml and json formats
+func writeHardwareTable(w io.Writer, inventories []HostHardwareInventory) error {
+	tw := util.GetNewTabWriter(w)
+	fmt.Fprintln(tw, "NODE NAME\tMODEL\tSERIAL\tBIOS\tBMC\tCPUS\tMEMORY\tNICS\tDISKS\tHEALTH\tBOOT MAC\tERROR")
+	for _, inventory := range inventories {
+		hw := inventory.Hardware
+		if hw == nil {
+			hw = &hardware.Inventory{}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%g GiB\t%d\t%d\t%s\t%s\t%s\n",
+			inventory.NodeName, hw.Model, hw.SerialNumber, hw.BIOSVersion, hw.BMCFirmwareVersion,
+			hw.Processors.Count, hw.MemoryGiB, len(hw.NICs), len(hw.Disks), hw.Health,
+			inventory.BootMACCheck, inventory.Error)
+	}
+	return tw.Flush()
+}
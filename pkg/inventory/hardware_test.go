@@ -0,0 +1,170 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	mockinventory "opendev.org/airship/airshipctl/testutil/inventory"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+func mockHost(name, bootMAC string, hw hardware.Inventory, err error) *mockinventory.MockBaremetalHost {
+	host := &mockinventory.MockBaremetalHost{}
+	host.On("NodeName").Return(name)
+	host.On("NodeID").Return(name + "-id")
+	host.On("BootMACAddress").Return(bootMAC)
+	host.On("HardwareInventory").Return(hw, err)
+	return host
+}
+
+func hardwareCommand(format string, hosts ...remoteifc.Client) (*inventory.HardwareInventoryCommand, *bytes.Buffer) {
+	bmhInv := &mockinventory.MockBMHInventory{}
+	bmhInv.On("Select").Return(hosts, nil)
+
+	inv := &mockinventory.MockInventory{}
+	inv.On("BaremetalInventory").Return(bmhInv, nil)
+
+	co := inventory.NewOptions(inv)
+	co.All = true
+	co.Concurrency = 2
+	co.Timeout = time.Minute
+	c := inventory.NewHardwareInventoryCommand(co)
+	c.OutputFormat = format
+	buf := bytes.NewBuffer([]byte{})
+	c.Writer = buf
+	return c, buf
+}
+
+var testHardware = hardware.Inventory{
+	Model:              "PowerEdge R640",
+	SerialNumber:       "CN7475",
+	BIOSVersion:        "2.10.2",
+	BMCFirmwareVersion: "4.40.00.00",
+	Processors:         hardware.Processors{Count: 2},
+	MemoryGiB:          384,
+	NICs: []hardware.NIC{
+		{Name: "NIC.1", MACAddress: "00:3B:8B:0C:EC:8B"},
+		{Name: "NIC.2", MACAddress: "00:3B:8B:0C:EC:8C"},
+	},
+	Disks:  []hardware.Disk{{Name: "Disk.0"}},
+	Health: hardware.HealthOK,
+}
+
+func TestHardwareInventoryCommand(t *testing.T) {
+	t.Run("success table", func(t *testing.T) {
+		c, buf := hardwareCommand(inventory.TableOutputFormat,
+			mockHost("node-0", "00:3b:8b:0c:ec:8b", testHardware, nil),
+			mockHost("node-1", "", testHardware, nil))
+		require.NoError(t, c.RunE())
+		assert.Contains(t, buf.String(), "NODE NAME")
+		assert.Regexp(t, `node-0\s+PowerEdge R640\s+CN7475\s+2.10.2\s+4.40.00.00\s+2\s+384 GiB\s+2\s+1\s+OK\s+match`,
+			buf.String())
+		assert.Regexp(t, `node-1\s+.*\s+OK\s+unknown`, buf.String())
+	})
+
+	t.Run("success json", func(t *testing.T) {
+		c, buf := hardwareCommand(inventory.JSONOutputFormat,
+			mockHost("node-0", "00:3b:8b:0c:ec:8c", testHardware, nil))
+		require.NoError(t, c.RunE())
+
+		var inventories []inventory.HostHardwareInventory
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &inventories))
+		hw := testHardware
+		assert.Equal(t, []inventory.HostHardwareInventory{{
+			NodeName:       "node-0",
+			NodeID:         "node-0-id",
+			BootMACAddress: "00:3b:8b:0c:ec:8c",
+			BootMACCheck:   inventory.BootMACMatch,
+			Hardware:       &hw,
+		}}, inventories)
+	})
+
+	t.Run("error mismatch and failure yaml", func(t *testing.T) {
+		c, buf := hardwareCommand(inventory.YamlOutputFormat,
+			mockHost("node-0", "00:3b:8b:0c:ec:8d", testHardware, nil),
+			mockHost("node-1", "00:3b:8b:0c:ec:8b", hardware.Inventory{}, fmt.Errorf("BMC is unreachable")),
+			mockHost("node-2", "00:3b:8b:0c:ec:8b", testHardware, nil))
+		err := c.RunE()
+		assert.Equal(t, inventory.ErrHardwareInventoryFailed{
+			Failed:          []string{"node-1"},
+			BootMACMismatch: []string{"node-0"},
+		}, err)
+		assert.Contains(t, buf.String(), "bootMACCheck: mismatch")
+		assert.Contains(t, buf.String(), "error: BMC is unreachable")
+		assert.Contains(t, buf.String(), "nodeName: node-2")
+	})
+
+	t.Run("error fail fast", func(t *testing.T) {
+		c, buf := hardwareCommand(inventory.YamlOutputFormat,
+			mockHost("node-0", "", hardware.Inventory{}, fmt.Errorf("BMC is unreachable")),
+			mockHost("node-1", "", testHardware, nil))
+		c.Options.Concurrency = 1
+		c.Options.FailFast = true
+		err := c.RunE()
+		assert.Equal(t, inventory.ErrHardwareInventoryFailed{Failed: []string{"node-0", "node-1"}}, err)
+		assert.Contains(t, buf.String(), "error: BMC is unreachable")
+		assert.Contains(t, buf.String(), "error: skipped since hardware inventory of another host can't be retrieved")
+	})
+
+	t.Run("error inventory not supported", func(t *testing.T) {
+		host := &redfishutils.MockClient{}
+		host.On("NodeName").Return("node-0")
+		host.On("NodeID").Return("node-0-id")
+		c, buf := hardwareCommand(inventory.TableOutputFormat, host)
+		err := c.RunE()
+		assert.Equal(t, inventory.ErrHardwareInventoryFailed{Failed: []string{"node-0"}}, err)
+		assert.Contains(t, buf.String(), hardware.ErrInventoryNotSupported{NodeName: "node-0"}.Error())
+	})
+
+	t.Run("error no hosts", func(t *testing.T) {
+		c, _ := hardwareCommand(inventory.TableOutputFormat)
+		assert.Equal(t, fmt.Errorf("no hosts present in the hostInventory"), c.RunE())
+	})
+
+	t.Run("error invalid output format", func(t *testing.T) {
+		c, _ := hardwareCommand("xml")
+		err := c.RunE()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "output format 'xml'")
+	})
+
+	t.Run("error invalid options", func(t *testing.T) {
+		c, _ := hardwareCommand(inventory.TableOutputFormat)
+		c.Options.All = false
+		err := c.RunE()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
+	})
+}
+
+func TestErrHardwareInventoryFailed(t *testing.T) {
+	err := inventory.ErrHardwareInventoryFailed{
+		Failed:          []string{"node-0", "node-1"},
+		BootMACMismatch: []string{"node-2"},
+	}
+	assert.Equal(t, "failed to retrieve hardware inventory of hosts 'node-0', 'node-1'; "+
+		"boot MAC address doesn't match any NIC of hosts 'node-2'", err.Error())
+}
@@ -16,6 +16,7 @@ package ifc
 
 import (
 	"context"
+	"time"
 
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
 )
@@ -32,6 +33,17 @@ type BaremetalInventory interface {
 	RunOperation(context.Context, BaremetalOperation, BaremetalHostSelector, BaremetalBatchRunOptions) error
 }
 
+// BaremetalHost is a host selected from the inventory
+type BaremetalHost interface {
+	remoteifc.Client
+	remoteifc.HardwareInventoryClient
+	remoteifc.BIOSClient
+	remoteifc.FirmwareClient
+
+	// BootMACAddress returns MAC address of the NIC the host boots from, it's empty if it isn't known
+	BootMACAddress() string
+}
+
 // BaremetalOperation baremetal operation
 type BaremetalOperation string
 
@@ -46,9 +58,48 @@ const (
 	BaremetalOperationEjectVirtualMedia BaremetalOperation = "eject-virtual-media"
 	// BaremetalOperationListHosts list hosts
 	BaremetalOperationListHosts BaremetalOperation = "list-hosts"
+	// BaremetalOperationConfigureBIOS configure BIOS
+	BaremetalOperationConfigureBIOS BaremetalOperation = "configure-bios"
+	// BaremetalOperationFirmwareUpdate firmware update
+	BaremetalOperationFirmwareUpdate BaremetalOperation = "firmware-update"
 )
 
-// BaremetalBatchRunOptions are options to be passed to RunOperation, this is to be
-// extended in the future, to support such things as concurency
+// BaremetalBatchRunOptions are options to be passed to RunOperation
 type BaremetalBatchRunOptions struct {
+	// Concurrency is the maximum number of hosts the operation is performed against at the same time,
+	// hosts are processed one by one if it is less than 1
+	Concurrency int
+	// FailFast stops starting the operation against the remaining hosts once it fails against any
+	// host, otherwise the operation is performed against every host and all the failures are reported
+	FailFast bool
+	// Results if set receives results of the operation for every selected host
+	Results *BaremetalBatchRunResults
+}
+
+// BaremetalBatchRunResults holds results of the operation performed against the hosts
+type BaremetalBatchRunResults struct {
+	Operation BaremetalOperation
+	Hosts     []BaremetalHostResult
+}
+
+// BaremetalHostResult is the result of the operation performed against single host
+type BaremetalHostResult struct {
+	NodeName string
+	NodeID   string
+	// Skipped is true if the operation wasn't started against the host because of failure against
+	// another host in fail fast mode
+	Skipped  bool
+	Error    error
+	Duration time.Duration
+}
+
+// Failed returns results of the hosts the operation has failed against
+func (r BaremetalBatchRunResults) Failed() []BaremetalHostResult {
+	var failed []BaremetalHostResult
+	for _, host := range r.Hosts {
+		if host.Error != nil {
+			failed = append(failed, host)
+		}
+	}
+	return failed
 }
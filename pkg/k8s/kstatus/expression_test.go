@@ -0,0 +1,105 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package kstatus_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/k8s/kstatus"
+)
+
+func TestExpressionMatch(t *testing.T) {
+	obj := map[string]interface{}{
+		"status": map[string]interface{}{
+			"controlPlaneReady": true,
+			"phase":             "Provisioned",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Paused", "status": "False"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		expression  kstatus.Expression
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name:       "value matches",
+			expression: kstatus.Expression{Condition: "{.status.phase}", Value: "Provisioned"},
+			expected:   true,
+		},
+		{
+			name:       "boolean value matches",
+			expression: kstatus.Expression{Condition: "{.status.controlPlaneReady}", Value: "true"},
+			expected:   true,
+		},
+		{
+			name:       "value doesn't match",
+			expression: kstatus.Expression{Condition: "{.status.phase}", Value: "Provisioning"},
+		},
+		{
+			name:       "filter matches",
+			expression: kstatus.Expression{Condition: `{.status.conditions[?(@.type=="Ready")].status}`, Value: "True"},
+			expected:   true,
+		},
+		{
+			name:       "path exists",
+			expression: kstatus.Expression{Condition: "{.status.phase}"},
+			expected:   true,
+		},
+		{
+			name:        "path doesn't exist",
+			expression:  kstatus.Expression{Condition: "{.status.ready}"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid jsonpath",
+			expression:  kstatus.Expression{Condition: "{.status["},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := tt.expression.Match(obj)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
+func TestExpressionInSyncWithApplier(t *testing.T) {
+	code := func(path string) string {
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		i := strings.Index(string(data), "type Expression struct")
+		require.NotEqual(t, -1, i)
+		return string(data[i:])
+	}
+	assert.Equal(t, code("../../../krm-functions/applier/image/poller/expression.go"), code("expression.go"))
+}
@@ -0,0 +1,321 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package kstatus computes status of kubernetes resources following the kstatus conventions
+// used by the cli-utils applier, so airshipctl can report the same statuses without running
+// the applier container
+package kstatus
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Status is a computed status of the kubernetes resource
+type Status string
+
+// Possible resource statuses
+const (
+	InProgressStatus  Status = "InProgress"
+	FailedStatus      Status = "Failed"
+	CurrentStatus     Status = "Current"
+	TerminatingStatus Status = "Terminating"
+	NotFoundStatus    Status = "NotFound"
+	UnknownStatus     Status = "Unknown"
+)
+
+// Result contains computed status of the resource along with human readable message
+type Result struct {
+	Status  Status
+	Message string
+}
+
+// statusFunc computes status of the specific resource kind
+type statusFunc func(u *unstructured.Unstructured) Result
+
+var kindStatusFuncs = map[string]statusFunc{
+	"apps/Deployment":        deploymentStatus,
+	"apps/StatefulSet":       statefulSetStatus,
+	"apps/DaemonSet":         daemonSetStatus,
+	"apps/ReplicaSet":        replicaSetStatus,
+	"/Pod":                   podStatus,
+	"/PersistentVolumeClaim": pvcStatus,
+	"/Service":               serviceStatus,
+	"batch/Job":              jobStatus,
+	"apiextensions.k8s.io/CustomResourceDefinition": crdStatus,
+}
+
+// Compute returns status of the resource, known kinds are checked according to their specifics,
+// generic Reconciling and Stalled conditions are checked for all other kinds
+func Compute(u *unstructured.Unstructured) Result {
+	if u.GetDeletionTimestamp() != nil {
+		return Result{Status: TerminatingStatus, Message: "Resource scheduled for deletion"}
+	}
+
+	if res, inProgress := checkGeneration(u); inProgress {
+		return res
+	}
+
+	gvk := u.GroupVersionKind()
+	if fn, found := kindStatusFuncs[gvk.Group+"/"+gvk.Kind]; found {
+		return fn(u)
+	}
+	return genericStatus(u)
+}
+
+func checkGeneration(u *unstructured.Unstructured) (Result, bool) {
+	observed, found := getInt(u.Object, "status", "observedGeneration")
+	if !found {
+		return Result{}, false
+	}
+	if generation := getIntDefault(u.Object, 0, "metadata", "generation"); observed < generation {
+		return Result{
+			Status: InProgressStatus,
+			Message: fmt.Sprintf("%s generation is %d, but latest observed generation is %d",
+				u.GetKind(), generation, observed),
+		}, true
+	}
+	return Result{}, false
+}
+
+func genericStatus(u *unstructured.Unstructured) Result {
+	if c, found := getCondition(u, "Stalled"); found && c.status == "True" {
+		return Result{Status: FailedStatus, Message: c.message}
+	}
+	if c, found := getCondition(u, "Reconciling"); found && c.status == "True" {
+		return Result{Status: InProgressStatus, Message: c.message}
+	}
+	return Result{Status: CurrentStatus, Message: "Resource is current"}
+}
+
+func deploymentStatus(u *unstructured.Unstructured) Result {
+	if c, found := getCondition(u, "Progressing"); found && c.reason == "ProgressDeadlineExceeded" {
+		return Result{Status: FailedStatus, Message: "Progress deadline exceeded"}
+	}
+
+	specReplicas := getIntDefault(u.Object, 1, "spec", "replicas")
+	statusReplicas := getIntDefault(u.Object, 0, "status", "replicas")
+	updated := getIntDefault(u.Object, 0, "status", "updatedReplicas")
+	ready := getIntDefault(u.Object, 0, "status", "readyReplicas")
+	available := getIntDefault(u.Object, 0, "status", "availableReplicas")
+
+	switch {
+	case specReplicas > statusReplicas:
+		return replicasInProgress("replicas", specReplicas, statusReplicas)
+	case specReplicas > updated:
+		return replicasInProgress("updated replicas", specReplicas, updated)
+	case statusReplicas > specReplicas:
+		return Result{Status: InProgressStatus,
+			Message: fmt.Sprintf("Pending termination: %d", statusReplicas-specReplicas)}
+	case updated > available:
+		return replicasInProgress("available replicas", updated, available)
+	case specReplicas > ready:
+		return replicasInProgress("ready replicas", specReplicas, ready)
+	}
+
+	if c, found := getCondition(u, "Available"); found && c.status != "True" {
+		return Result{Status: InProgressStatus, Message: "Deployment not Available"}
+	}
+	return Result{Status: CurrentStatus, Message: fmt.Sprintf("Deployment is available. Replicas: %d", statusReplicas)}
+}
+
+func statefulSetStatus(u *unstructured.Unstructured) Result {
+	specReplicas := getIntDefault(u.Object, 1, "spec", "replicas")
+	ready := getIntDefault(u.Object, 0, "status", "readyReplicas")
+	current := getIntDefault(u.Object, 0, "status", "currentReplicas")
+
+	if specReplicas > ready {
+		return replicasInProgress("ready replicas", specReplicas, ready)
+	}
+	strategy, _, _ := unstructured.NestedString(u.Object, "spec", "updateStrategy", "type")
+	if strategy != "OnDelete" {
+		currentRevision, _, _ := unstructured.NestedString(u.Object, "status", "currentRevision")
+		updateRevision, _, _ := unstructured.NestedString(u.Object, "status", "updateRevision")
+		if currentRevision != updateRevision {
+			return Result{Status: InProgressStatus,
+				Message: fmt.Sprintf("Waiting for update of revision %s", updateRevision)}
+		}
+		if specReplicas > current {
+			return replicasInProgress("current replicas", specReplicas, current)
+		}
+	}
+	return Result{Status: CurrentStatus, Message: fmt.Sprintf("All replicas scheduled as expected. Replicas: %d", ready)}
+}
+
+func daemonSetStatus(u *unstructured.Unstructured) Result {
+	desired := getIntDefault(u.Object, 0, "status", "desiredNumberScheduled")
+	scheduled := getIntDefault(u.Object, 0, "status", "currentNumberScheduled")
+	updated := getIntDefault(u.Object, 0, "status", "updatedNumberScheduled")
+	available := getIntDefault(u.Object, 0, "status", "numberAvailable")
+	ready := getIntDefault(u.Object, 0, "status", "numberReady")
+
+	switch {
+	case desired > scheduled:
+		return replicasInProgress("scheduled pods", desired, scheduled)
+	case desired > updated:
+		return replicasInProgress("updated pods", desired, updated)
+	case desired > available:
+		return replicasInProgress("available pods", desired, available)
+	case desired > ready:
+		return replicasInProgress("ready pods", desired, ready)
+	}
+	return Result{Status: CurrentStatus, Message: fmt.Sprintf("All replicas scheduled as expected. Replicas: %d", desired)}
+}
+
+func replicaSetStatus(u *unstructured.Unstructured) Result {
+	if c, found := getCondition(u, "ReplicaFailure"); found && c.status == "True" {
+		return Result{Status: FailedStatus, Message: c.message}
+	}
+
+	specReplicas := getIntDefault(u.Object, 1, "spec", "replicas")
+	labeled := getIntDefault(u.Object, 0, "status", "fullyLabeledReplicas")
+	available := getIntDefault(u.Object, 0, "status", "availableReplicas")
+	ready := getIntDefault(u.Object, 0, "status", "readyReplicas")
+
+	switch {
+	case specReplicas > labeled:
+		return replicasInProgress("labeled replicas", specReplicas, labeled)
+	case specReplicas > available:
+		return replicasInProgress("available replicas", specReplicas, available)
+	case specReplicas > ready:
+		return replicasInProgress("ready replicas", specReplicas, ready)
+	}
+	return Result{Status: CurrentStatus, Message: fmt.Sprintf("ReplicaSet is available. Replicas: %d", specReplicas)}
+}
+
+func podStatus(u *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return Result{Status: CurrentStatus, Message: "Pod has completed successfully"}
+	case "Failed":
+		return Result{Status: FailedStatus, Message: "Pod has completed, but not successfully"}
+	case "Running":
+		if c, found := getCondition(u, "Ready"); found && c.status == "True" {
+			return Result{Status: CurrentStatus, Message: "Pod is Ready"}
+		}
+	}
+
+	statuses, _, _ := unstructured.NestedSlice(u.Object, "status", "containerStatuses")
+	for _, s := range statuses {
+		reason, _, _ := unstructured.NestedString(toMap(s), "state", "waiting", "reason")
+		if reason == "CrashLoopBackOff" {
+			return Result{Status: FailedStatus, Message: "Pod has a container in CrashLoopBackOff"}
+		}
+	}
+	return Result{Status: InProgressStatus, Message: fmt.Sprintf("Pod phase is '%s'", phase)}
+}
+
+func pvcStatus(u *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	if phase != "Bound" {
+		return Result{Status: InProgressStatus, Message: "PVC is not Bound"}
+	}
+	return Result{Status: CurrentStatus, Message: "PVC is Bound"}
+}
+
+func serviceStatus(u *unstructured.Unstructured) Result {
+	specType, _, _ := unstructured.NestedString(u.Object, "spec", "type")
+	if specType == "LoadBalancer" {
+		ingress, _, _ := unstructured.NestedSlice(u.Object, "status", "loadBalancer", "ingress")
+		if len(ingress) == 0 {
+			return Result{Status: InProgressStatus, Message: "LoadBalancer ingress is not assigned"}
+		}
+	}
+	return Result{Status: CurrentStatus, Message: "Service is ready"}
+}
+
+func jobStatus(u *unstructured.Unstructured) Result {
+	if c, found := getCondition(u, "Failed"); found && c.status == "True" {
+		return Result{Status: FailedStatus, Message: fmt.Sprintf("Job failed: %s", c.message)}
+	}
+	if c, found := getCondition(u, "Complete"); found && c.status == "True" {
+		return Result{Status: CurrentStatus, Message: "Job completed"}
+	}
+	return Result{Status: InProgressStatus, Message: "Job in progress"}
+}
+
+func crdStatus(u *unstructured.Unstructured) Result {
+	if c, found := getCondition(u, "NamesAccepted"); found && c.status == "False" {
+		return Result{Status: FailedStatus, Message: c.message}
+	}
+	if c, found := getCondition(u, "Established"); found && c.status == "True" {
+		return Result{Status: CurrentStatus, Message: "CRD is established"}
+	}
+	return Result{Status: InProgressStatus, Message: "CRD is not established"}
+}
+
+func replicasInProgress(what string, expected, actual int64) Result {
+	return Result{
+		Status:  InProgressStatus,
+		Message: fmt.Sprintf("%s: %d/%d", what, actual, expected),
+	}
+}
+
+type condition struct {
+	status  string
+	reason  string
+	message string
+}
+
+func getCondition(u *unstructured.Unstructured, conditionType string) (condition, bool) {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		m := toMap(c)
+		if t, _, _ := unstructured.NestedString(m, "type"); t != conditionType {
+			continue
+		}
+		res := condition{}
+		res.status, _, _ = unstructured.NestedString(m, "status")
+		res.reason, _, _ = unstructured.NestedString(m, "reason")
+		res.message, _, _ = unstructured.NestedString(m, "message")
+		return res, true
+	}
+	return condition{}, false
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return m
+}
+
+// getInt returns integer field of the object, numbers decoded from JSON are float64 so they
+// are converted as well
+func getInt(obj map[string]interface{}, fields ...string) (int64, bool) {
+	v, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func getIntDefault(obj map[string]interface{}, def int64, fields ...string) int64 {
+	if v, found := getInt(obj, fields...); found {
+		return v
+	}
+	return def
+}
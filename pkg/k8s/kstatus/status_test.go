@@ -0,0 +1,197 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package kstatus_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/k8s/kstatus"
+)
+
+func TestCompute(t *testing.T) {
+	testCases := []struct {
+		name           string
+		obj            string
+		expectedStatus kstatus.Status
+	}{
+		{
+			name: "deployment is current",
+			obj: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  readyReplicas: 2
+  availableReplicas: 2
+  conditions:
+  - type: Available
+    status: "True"
+`,
+			expectedStatus: kstatus.CurrentStatus,
+		},
+		{
+			name: "deployment generation is not observed",
+			obj: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+  generation: 3
+spec:
+  replicas: 1
+status:
+  observedGeneration: 2
+  replicas: 1
+  updatedReplicas: 1
+  readyReplicas: 1
+  availableReplicas: 1
+`,
+			expectedStatus: kstatus.InProgressStatus,
+		},
+		{
+			name: "deployment is not ready",
+			obj: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+spec:
+  replicas: 3
+status:
+  replicas: 3
+  updatedReplicas: 3
+  readyReplicas: 1
+  availableReplicas: 3
+`,
+			expectedStatus: kstatus.InProgressStatus,
+		},
+		{
+			name: "deployment progress deadline exceeded",
+			obj: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+status:
+  conditions:
+  - type: Progressing
+    status: "False"
+    reason: ProgressDeadlineExceeded
+`,
+			expectedStatus: kstatus.FailedStatus,
+		},
+		{
+			name: "resource is terminating",
+			obj: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  deletionTimestamp: "2021-01-01T00:00:00Z"
+`,
+			expectedStatus: kstatus.TerminatingStatus,
+		},
+		{
+			name: "pod in crash loop",
+			obj: `apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+status:
+  phase: Running
+  containerStatuses:
+  - name: test
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+`,
+			expectedStatus: kstatus.FailedStatus,
+		},
+		{
+			name: "job completed",
+			obj: `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: test
+status:
+  conditions:
+  - type: Complete
+    status: "True"
+`,
+			expectedStatus: kstatus.CurrentStatus,
+		},
+		{
+			name: "crd is not established",
+			obj: `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: tests.airshipit.org
+`,
+			expectedStatus: kstatus.InProgressStatus,
+		},
+		{
+			name: "custom resource is stalled",
+			obj: `apiVersion: airshipit.org/v1alpha1
+kind: Test
+metadata:
+  name: test
+status:
+  conditions:
+  - type: Stalled
+    status: "True"
+    message: failed to reconcile
+`,
+			expectedStatus: kstatus.FailedStatus,
+		},
+		{
+			name: "custom resource is reconciling",
+			obj: `apiVersion: airshipit.org/v1alpha1
+kind: Test
+metadata:
+  name: test
+status:
+  conditions:
+  - type: Reconciling
+    status: "True"
+`,
+			expectedStatus: kstatus.InProgressStatus,
+		},
+		{
+			name: "custom resource without conditions",
+			obj: `apiVersion: airshipit.org/v1alpha1
+kind: Test
+metadata:
+  name: test
+`,
+			expectedStatus: kstatus.CurrentStatus,
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			obj := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.obj), &obj))
+			res := kstatus.Compute(&unstructured.Unstructured{Object: obj})
+			assert.Equal(t, tt.expectedStatus, res.Status, res.Message)
+		})
+	}
+}
@@ -15,7 +15,15 @@
 package utils
 
 import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
 
@@ -41,3 +49,52 @@ func FactoryFromKubeConfig(path, context string, opts ...ClientOption) cmdutil.F
 
 	return cmdutil.NewFactory(cmdutil.NewMatchVersionFlags(kf))
 }
+
+// DynamicClientFunc returns dynamic kubernetes client and REST mapper for the given kube config path and context
+type DynamicClientFunc func(path, context string) (dynamic.Interface, meta.RESTMapper, error)
+
+// DynamicClientFromKubeConfig returns dynamic kubernetes client and REST mapper
+// for the given kube config path and context
+func DynamicClientFromKubeConfig(path, context string) (dynamic.Interface, meta.RESTMapper, error) {
+	f := FactoryFromKubeConfig(path, context)
+	client, err := f.DynamicClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	mapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, mapper, nil
+}
+
+// ListObjects returns objects of the given kind sorted by namespace and name, objects are listed in all
+// namespaces if namespace is empty, nothing is returned if the kind isn't served by the cluster
+func ListObjects(client dynamic.Interface, mapper meta.RESTMapper, gk schema.GroupKind,
+	namespace, labelSelector string) ([]unstructured.Unstructured, error) {
+	mapping, err := mapper.RESTMapping(gk)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ri dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if namespace != "" && mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ri = client.Resource(mapping.Resource).Namespace(namespace)
+	}
+	list, err := ri.List(context.Background(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+	return items, nil
+}
@@ -0,0 +1,85 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package log
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// outputMu serializes writes of all prefixed writers, so lines produced concurrently never interleave
+var outputMu sync.Mutex
+
+// PrefixedWriter is a line buffered writer which adds prefix to every line written to the underlying writer
+type PrefixedWriter struct {
+	out    io.Writer
+	prefix []byte
+	buf    []byte
+	mu     sync.Mutex
+}
+
+// NewPrefixedWriter returns writer which prepends every line with prefix, it is safe to use multiple
+// prefixed writers with the same underlying writer from different go routines
+func NewPrefixedWriter(out io.Writer, prefix string) *PrefixedWriter {
+	return &PrefixedWriter{out: out, prefix: []byte(prefix)}
+}
+
+// Write buffers p and writes all complete lines to the underlying writer
+func (w *PrefixedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	lines := w.buf[:i+1]
+	if err := w.writeLines(lines); err != nil {
+		return 0, err
+	}
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+	return len(p), nil
+}
+
+// Flush writes the incomplete line remaining in the buffer if any
+func (w *PrefixedWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLines(append(w.buf, '\n'))
+	w.buf = w.buf[:0]
+	return err
+}
+
+func (w *PrefixedWriter) writeLines(lines []byte) error {
+	out := &bytes.Buffer{}
+	for _, line := range bytes.SplitAfter(lines, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		out.Write(w.prefix)
+		out.Write(line)
+	}
+
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	_, err := w.out.Write(out.Bytes())
+	return err
+}
@@ -0,0 +1,74 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package log_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+func TestPrefixedWriter(t *testing.T) {
+	t.Run("Lines are prefixed", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		w := log.NewPrefixedWriter(output, "[phase] ")
+
+		_, err := w.Write([]byte("first line\nsecond "))
+		require.NoError(t, err)
+		assert.Equal(t, "[phase] first line\n", output.String())
+
+		_, err = w.Write([]byte("line\nincomplete"))
+		require.NoError(t, err)
+		assert.Equal(t, "[phase] first line\n[phase] second line\n", output.String())
+
+		require.NoError(t, w.Flush())
+		assert.Equal(t, "[phase] first line\n[phase] second line\n[phase] incomplete\n", output.String())
+		require.NoError(t, w.Flush())
+		assert.Equal(t, "[phase] first line\n[phase] second line\n[phase] incomplete\n", output.String())
+	})
+
+	t.Run("Concurrent writes do not interleave", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		wg := sync.WaitGroup{}
+		for i := 0; i < 5; i++ {
+			w := log.NewPrefixedWriter(output, fmt.Sprintf("[phase%d] ", i))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					// write line in several chunks
+					_, err := w.Write([]byte("some "))
+					assert.NoError(t, err)
+					_, err = w.Write([]byte("output\n"))
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+		assert.Len(t, lines, 500)
+		for _, line := range lines {
+			assert.Regexp(t, `^\[phase\d\] some output$`, line)
+		}
+	})
+}
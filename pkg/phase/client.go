@@ -16,9 +16,11 @@ package phase
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
@@ -26,6 +28,7 @@ import (
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/document/images"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
 	"opendev.org/airship/airshipctl/pkg/log"
 	"opendev.org/airship/airshipctl/pkg/phase/errors"
@@ -38,17 +41,10 @@ import (
 // ExecutorRegistry returns map with executor factories
 type ExecutorRegistry func() map[schema.GroupVersionKind]ifc.ExecutorFactory
 
-// DefaultExecutorRegistry returns map with executor factories
+// DefaultExecutorRegistry returns map with factories of the built-in executors and the executors
+// registered by executors.Register
 func DefaultExecutorRegistry() map[schema.GroupVersionKind]ifc.ExecutorFactory {
-	execMap := make(map[schema.GroupVersionKind]ifc.ExecutorFactory)
-
-	for _, execName := range []string{executors.Clusterctl, executors.KubernetesApply,
-		executors.GenericContainer, executors.Ephemeral, executors.BMHManager} {
-		if err := executors.RegisterExecutor(execName, execMap); err != nil {
-			log.Fatal(executorerrors.ErrExecutorRegistration{ExecutorName: execName, Err: err})
-		}
-	}
-	return execMap
+	return executors.Registry()
 }
 
 var _ ifc.Phase = &phase{}
@@ -62,7 +58,24 @@ type phase struct {
 
 // Executor returns executor interface associated with the phase
 func (p *phase) Executor() (ifc.Executor, error) {
-	executorDoc, err := p.helper.PhaseConfigBundle().SelectOne(
+	return p.executor("")
+}
+
+// executor returns executor of the phase, if the image registry is set, container image references of the
+// executor document, of the phase config bundle and of the documents rendered from the phase entry point
+// point to this registry
+func (p *phase) executor(imageRegistry string) (ifc.Executor, error) {
+	phaseConfigBundle := p.helper.PhaseConfigBundle()
+	bundleFactory := document.BundleFactoryFromDocRoot(p.DocumentRoot)
+	if imageRegistry != "" {
+		var err error
+		if phaseConfigBundle, err = images.Mirror(phaseConfigBundle, imageRegistry); err != nil {
+			return nil, err
+		}
+		bundleFactory = mirrorBundleFactory(bundleFactory, imageRegistry)
+	}
+
+	executorDoc, err := phaseConfigBundle.SelectOne(
 		document.NewSelector().ByObjectReference(p.apiObj.Config.ExecutorRef))
 	if err != nil {
 		return nil, err
@@ -95,12 +108,12 @@ func (p *phase) Executor() (ifc.Executor, error) {
 	return executorFactory(
 		ifc.ExecutorConfig{
 			ClusterMap:        cMap,
-			BundleFactory:     document.BundleFactoryFromDocRoot(p.DocumentRoot),
+			BundleFactory:     bundleFactory,
 			PhaseName:         p.apiObj.Name,
 			KubeConfig:        kubeconf,
 			ExecutorDocument:  executorDoc,
 			ClusterName:       p.apiObj.ClusterName,
-			PhaseConfigBundle: p.helper.PhaseConfigBundle(),
+			PhaseConfigBundle: phaseConfigBundle,
 			SinkBasePath:      p.helper.PhaseEntryPointBasePath(),
 			TargetPath:        p.helper.TargetPath(),
 			Inventory:         p.helper.Inventory(),
@@ -109,7 +122,7 @@ func (p *phase) Executor() (ifc.Executor, error) {
 
 // Run runs the phase via executor
 func (p *phase) Run(ro ifc.RunOptions) error {
-	executor, err := p.Executor()
+	executor, err := p.executor(ro.ImageRegistry)
 	if err != nil {
 		return err
 	}
@@ -117,6 +130,18 @@ func (p *phase) Run(ro ifc.RunOptions) error {
 	return executor.Run(ro)
 }
 
+// mirrorBundleFactory returns bundle factory that points container image references of the bundles
+// built by the factory to the image registry
+func mirrorBundleFactory(factory document.BundleFactoryFunc, imageRegistry string) document.BundleFactoryFunc {
+	return func() (document.Bundle, error) {
+		bundle, err := factory()
+		if err != nil {
+			return nil, err
+		}
+		return images.Mirror(bundle, imageRegistry)
+	}
+}
+
 // Validate makes sure that phase is properly configured
 func (p *phase) Validate() error {
 	executor, err := p.Executor()
@@ -209,7 +234,12 @@ func (p *phase) Status() (ifc.PhaseStatus, error) {
 		return ifc.PhaseStatus{}, err
 	}
 
-	return ifc.PhaseStatus{ExecutorStatus: sts}, err
+	status := v1alpha1.DefaultPhaseStatus(p.apiObj)
+	if sts.State == "" {
+		sts.State = status.State
+	}
+	status.ExecutorStatus = sts
+	return *status, nil
 }
 
 // DocumentRoot root that holds all the documents associated with the phase
@@ -224,11 +254,40 @@ func (p *phase) DocumentRoot() (string, error) {
 	return filepath.Join(p.helper.PhaseEntryPointBasePath(), relativePath), nil
 }
 
-// Details returns description of the phase
-// TODO implement this: add details field to api.Phase and method to executor and combine them here
-// to give a clear understanding to user of what this phase is about
+// Details returns description of the phase combined with the description provided by its executor
 func (p *phase) Details() (string, error) {
-	return "", nil
+	executor, err := p.Executor()
+	if err != nil {
+		return "", err
+	}
+
+	executorDetails, err := executor.Describe()
+	if err != nil {
+		return "", err
+	}
+
+	sb := &strings.Builder{}
+	name := p.apiObj.Name
+	if p.apiObj.Namespace != "" {
+		name = p.apiObj.Namespace + "/" + name
+	}
+	fmt.Fprintf(sb, "Phase: %s\n", name)
+	if p.apiObj.ClusterName != "" {
+		fmt.Fprintf(sb, "Cluster: %s\n", p.apiObj.ClusterName)
+	}
+	if ref := p.apiObj.Config.ExecutorRef; ref != nil {
+		fmt.Fprintf(sb, "Executor: %s %s\n", ref.Kind, ref.Name)
+	}
+	if p.apiObj.Config.DocumentEntryPoint != "" {
+		fmt.Fprintf(sb, "Document entry point: %s\n", p.apiObj.Config.DocumentEntryPoint)
+	}
+	if p.apiObj.Description != "" {
+		fmt.Fprintf(sb, "Description: %s\n", p.apiObj.Description)
+	}
+	if executorDetails != "" {
+		fmt.Fprintf(sb, "\n%s", executorDetails)
+	}
+	return sb.String(), nil
 }
 
 var _ ifc.Plan = &plan{}
@@ -241,13 +300,26 @@ type plan struct {
 
 // Validate makes sure that phase plan is properly configured
 func (p *plan) Validate() error {
+	if _, err := newPlanGraph(p.apiObj); err != nil {
+		return err
+	}
+	if _, err := newStepPolicies(p.apiObj); err != nil {
+		return err
+	}
+	// hook phases are validated along with the plan phases
+	names := make([]string, 0, len(p.apiObj.Phases))
+	for _, step := range p.apiObj.Phases {
+		names = append(names, step.Name)
+	}
+	names = append(names, hookPhases(p.apiObj)...)
+
 	util.Setenv(util.EnvVar{Key: v1alpha1.ValidatorPreventCleanup})
-	for i, step := range p.apiObj.Phases {
-		log.Printf("validating phase: %s\n", step.Name)
-		if i == len(p.apiObj.Phases)-1 {
+	for i, name := range names {
+		log.Printf("validating phase: %s\n", name)
+		if i == len(names)-1 {
 			util.Unsetenv(util.EnvVar{Key: v1alpha1.ValidatorPreventCleanup})
 		}
-		phaseRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: step.Name})
+		phaseRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: name})
 		if err != nil {
 			return err
 		}
@@ -262,43 +334,182 @@ func (p *plan) Validate() error {
 	return nil
 }
 
-// Run function executes Run method for each phase
+// Run function executes Run method for each phase, phases which don't depend on each other are
+// executed concurrently if the plan defines dependencies
 func (p *plan) Run(ro ifc.PlanRunOptions) error {
-	if ro.ResumeFromPhase != "" {
-		for i, phase := range p.apiObj.Phases {
-			if phase.Name == ro.ResumeFromPhase {
-				p.apiObj.Phases = p.apiObj.Phases[i:]
+	g, err := newPlanGraph(p.apiObj)
+	if err != nil {
+		return err
+	}
+	policies, err := newStepPolicies(p.apiObj)
+	if err != nil {
+		return err
+	}
+	skipped, lastRun, err := p.skippedSteps(g, ro)
+	if err != nil {
+		return err
+	}
+	if allSkipped(skipped) && len(p.apiObj.Phases) != 0 {
+		log.Printf("all phases of plan %s succeeded during the last run, nothing to resume\n", p.apiObj.Name)
+		return nil
+	}
+
+	recorder, err := p.recorder(ro)
+	if err != nil {
+		return err
+	}
+
+	for i, step := range p.apiObj.Phases {
+		if !skipped[i] {
+			continue
+		}
+		log.Printf("skipping phase: %s\n", step.Name)
+		if err = recorder.phaseSkipped(i, lastRun); err != nil {
+			return err
+		}
+	}
+
+	err = p.runGraph(g, policies, skipped, ro, recorder)
+	if err != nil {
+		p.runOnFailure(ro.RunOptions, recorder)
+	}
+	if recErr := recorder.finished(); recErr != nil {
+		log.Printf("failed to record execution state of plan %s: %v\n", p.apiObj.Name, recErr)
+	}
+	return err
+}
+
+// runGraph executes plan steps as soon as their dependencies succeed, at most ro.MaxParallel steps
+// are executed at a time unless it's 0. Once any step fails no more steps are started and the first
+// error is returned after the running ones finish, failures of the steps allowed to continue on
+// error are treated as success
+func (p *plan) runGraph(g *planGraph, policies []stepPolicy, skipped []bool, ro ifc.PlanRunOptions,
+	recorder *planRunRecorder) error {
+	type result struct {
+		index int
+		err   error
+	}
+	results := make(chan result)
+	// skipped steps are considered done, so steps depending on them can be started
+	done := append([]bool{}, skipped...)
+	started := append([]bool{}, skipped...)
+	running := 0
+
+	var runErr error
+	for {
+		for i := range p.apiObj.Phases {
+			if runErr != nil || (ro.MaxParallel > 0 && running >= ro.MaxParallel) {
 				break
 			}
+			if started[i] || !g.ready(i, done) {
+				continue
+			}
+			started[i] = true
+			run, err := p.startStep(i, policies[i], g.concurrent && ro.MaxParallel != 1, ro.RunOptions, recorder)
+			if err != nil {
+				runErr = err
+				break
+			}
+			running++
+			go func(i int) {
+				results <- result{index: i, err: run()}
+			}(i)
+		}
+
+		if running == 0 {
+			return runErr
+		}
+		res := <-results
+		running--
+		if res.err != nil && p.apiObj.Phases[res.index].ContinueOnError {
+			log.Printf("phase %s is allowed to fail, continuing plan execution\n", p.apiObj.Phases[res.index].Name)
+			res.err = nil
 		}
+		if res.err != nil && runErr == nil {
+			runErr = res.err
+		}
+		done[res.index] = res.err == nil
 	}
+}
 
-	for _, step := range p.apiObj.Phases {
-		phaseRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: step.Name})
+// startStep records start of the plan step and returns function executing it, output the phase
+// writes to ro.Out is prefixed with phase name if prefixOutput is set. Messages written to the
+// airshipctl log, e.g. by log.Print or as container stderr, are shared by all steps and aren't prefixed
+func (p *plan) startStep(index int, policy stepPolicy, prefixOutput bool, ro ifc.RunOptions,
+	recorder *planRunRecorder) (func() error, error) {
+	step := p.apiObj.Phases[index]
+	met, err := p.conditionMet(step)
+	if err != nil {
+		return nil, err
+	}
+	if !met {
+		log.Printf("skipping phase: %s, condition is not met\n", step.Name)
+		return func() error { return nil }, recorder.phaseSkipped(index, nil)
+	}
+
+	var hash string
+	phaseRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: step.Name})
+	if err == nil && recorder != nil {
+		hash, err = bundleHash(phaseRunner)
+	}
+	if recErr := recorder.phaseStarted(index, hash); recErr != nil {
+		return nil, recErr
+	}
+
+	return func() error {
+		attempts := 0
+		if err == nil {
+			if prefixOutput {
+				out := ro.Out
+				if out == nil {
+					out = os.Stdout
+				}
+				w := log.NewPrefixedWriter(out, "["+step.Name+"] ")
+				defer w.Flush() //nolint:errcheck
+				ro.Out = w
+			}
+			log.Printf("executing phase: %s\n", step.Name)
+			attempts, err = p.runStepPhases(step, policy, phaseRunner, ro)
+		}
 		if err != nil {
-			return err
+			log.Printf("phase %s failed: %v\n", step.Name, err)
+		}
+		if recErr := recorder.phaseFinished(index, attempts, err); recErr != nil {
+			log.Printf("failed to record execution state of phase %s: %v\n", step.Name, recErr)
 		}
+		return err
+	}, nil
+}
 
-		log.Printf("executing phase: %s\n", step.Name)
-		if err = phaseRunner.Run(ro.RunOptions); err != nil {
-			return err
+func allSkipped(skipped []bool) bool {
+	for _, s := range skipped {
+		if !s {
+			return false
 		}
 	}
-	return nil
+	return true
+}
+
+// recorder returns plan run recorder which persists execution state in airshipctl work directory,
+// execution state is not recorded for dry runs
+func (p *plan) recorder(ro ifc.PlanRunOptions) (*planRunRecorder, error) {
+	if ro.DryRun {
+		return nil, nil
+	}
+	return newPlanRunRecorder(PlanRunPath(p.helper.WorkDir(), p.id()), p.apiObj)
 }
 
 // Status returns the status of phases in a given plan
 func (p *plan) Status(_ ifc.StatusOptions) (ifc.PlanStatus, error) {
-	for _, step := range p.apiObj.Phases {
-		phase, err := p.phaseClient.PhaseByID(ifc.ID{Name: step.Name})
-		if err != nil {
-			return ifc.PlanStatus{}, err
-		}
-		if _, err = phase.Status(); err != nil {
-			return ifc.PlanStatus{}, err
-		}
+	run, err := ReadPlanRun(PlanRunPath(p.helper.WorkDir(), p.id()))
+	if err != nil {
+		return ifc.PlanStatus{}, err
 	}
-	return ifc.PlanStatus{}, nil
+	return ifc.PlanStatus{LastRun: run}, nil
+}
+
+func (p *plan) id() ifc.ID {
+	return ifc.ID{Name: p.apiObj.Name, Namespace: p.apiObj.Namespace}
 }
 
 var _ ifc.Client = &client{}
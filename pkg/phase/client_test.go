@@ -25,6 +25,8 @@ import (
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/document/images"
 	"opendev.org/airship/airshipctl/pkg/phase"
 	"opendev.org/airship/airshipctl/pkg/phase/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
@@ -137,6 +139,74 @@ func TestPhaseRun(t *testing.T) {
 	}
 }
 
+func TestPhaseRunImageRegistry(t *testing.T) {
+	conf := config.NewConfig()
+	manifest := conf.Manifests[config.AirshipDefaultManifest]
+	manifest.TargetPath = testTargetPath
+	manifest.MetadataPath = "images_site/metadata.yaml"
+	manifest.Repositories[config.DefaultTestPhaseRepo].URLString = ""
+	helper, err := phase.NewHelper(conf)
+	require.NoError(t, err)
+
+	// executors receive documents with the images pointing to the registry, except the local ones
+	var docs []document.Document
+	collectDocs := func(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+		applier, err := cfg.PhaseConfigBundle.SelectOne(document.NewApplierContainerExecutorSelector())
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, cfg.ExecutorDocument, applier)
+		if bundle, bundleErr := cfg.BundleFactory(); bundleErr == nil {
+			rendered, err := bundle.GetAllDocuments()
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, rendered...)
+		}
+		return fakeExecutor{}, nil
+	}
+	registry := func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+		return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+			{Group: "airshipit.org", Version: "v1alpha1", Kind: "KubernetesApply"}:  collectDocs,
+			{Group: "airshipit.org", Version: "v1alpha1", Kind: "GenericContainer"}: collectDocs,
+		}
+	}
+	client := phase.NewClient(helper, phase.InjectRegistry(registry))
+	for _, name := range []string{"initinfra", "check-nodes"} {
+		p, err := client.PhaseByID(ifc.ID{Name: name})
+		require.NoError(t, err)
+		require.NoError(t, p.Run(ifc.RunOptions{ImageRegistry: "registry.local:5000"}))
+	}
+
+	refs, err := images.Collect(docs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"localhost/applier:latest",
+		"registry.local:5000/airshipit/ipa-wallaby:latest",
+		"registry.local:5000/airshipit/toolbox:latest",
+		"registry.local:5000/calico/kube-controllers:v3.15.1",
+		"registry.local:5000/fluxcd/helm-controller:v0.11.1",
+		"registry.local:5000/library/busybox:1.33",
+		"registry.local:5000/metal3-io/ironic:capm3-v0.5.0",
+	}, refs)
+}
+
+func TestPhaseStatus(t *testing.T) {
+	helper, err := phase.NewHelper(testConfig(t))
+	require.NoError(t, err)
+	client := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry))
+	p, err := client.PhaseByID(ifc.ID{Name: "capi_init"})
+	require.NoError(t, err)
+
+	status, err := p.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "PhaseStatus", status.Kind)
+	assert.Equal(t, v1alpha1.GroupVersion.String(), status.APIVersion)
+	assert.Equal(t, "capi_init", status.Name)
+	assert.Equal(t, "Clusterctl", status.Executor)
+	assert.Equal(t, v1alpha1.StatusUnknown, status.State)
+}
+
 func TestPhaseValidate(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -280,6 +350,73 @@ func (e fakeExecutor) Status() (ifc.ExecutorStatus, error) {
 	return ifc.ExecutorStatus{}, nil
 }
 
+func TestPhaseDetails(t *testing.T) {
+	tests := []struct {
+		name            string
+		errContains     string
+		phaseID         ifc.ID
+		registryFunc    phase.ExecutorRegistry
+		expectedDetails string
+	}{
+		{
+			name:         "success",
+			phaseID:      ifc.ID{Name: "capi_init"},
+			registryFunc: fakeRegistry,
+			expectedDetails: `Phase: capi_init
+Executor: Clusterctl clusterctl-v1
+Document entry point: valid_site/phases
+Description: Initializes Cluster API providers
+
+Fake executor description
+`,
+		},
+		{
+			name:        "error executor description",
+			phaseID:     ifc.ID{Name: "capi_init"},
+			errContains: "describe error",
+			registryFunc: func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+				gvk := schema.GroupVersionKind{
+					Group:   "airshipit.org",
+					Version: "v1alpha1",
+					Kind:    "Clusterctl",
+				}
+				return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+					gvk: func(config ifc.ExecutorConfig) (ifc.Executor, error) {
+						return fakeExecutor{describe: fmt.Errorf("describe error")}, nil
+					},
+				}
+			},
+		},
+		{
+			name:        "error executor is not registered",
+			phaseID:     ifc.ID{Name: "capi_init"},
+			errContains: "executor identified by 'airshipit.org/v1alpha1, Kind=Clusterctl' is not found",
+			registryFunc: func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+				return make(map[schema.GroupVersionKind]ifc.ExecutorFactory)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			helper, err := phase.NewHelper(testConfig(t))
+			require.NoError(t, err)
+			client := phase.NewClient(helper, phase.InjectRegistry(tt.registryFunc))
+			p, err := client.PhaseByID(tt.phaseID)
+			require.NoError(t, err)
+			details, err := p.Details()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedDetails, details)
+			}
+		})
+	}
+}
+
 // TODO develop tests, when we add phase object validation
 func TestClientByAPIObj(t *testing.T) {
 	helper, err := phase.NewHelper(testConfig(t))
@@ -476,6 +613,37 @@ func TestPlanValidate(t *testing.T) {
 			errContains: `document filtered by selector [Group="airshipit.org", Version="v1alpha1", ` +
 				`Kind="Phase", Name="non_existent_name"] found no documents`,
 		},
+		{
+			name:         "Phase dependency cycle",
+			configFunc:   testConfig,
+			planID:       ifc.ID{Name: "dag_plan_cycle"},
+			registryFunc: fakeRegistry,
+			errContains:  "plan 'dag_plan_cycle' has a dependency cycle: isogen -> initinfra -> isogen",
+		},
+		{
+			name:         "Unknown phase dependency",
+			configFunc:   testConfig,
+			planID:       ifc.ID{Name: "dag_plan_unknown_dependency"},
+			registryFunc: fakeRegistry,
+			errContains: "phase 'isogen' of the plan 'dag_plan_unknown_dependency' depends on phase " +
+				"'non_existent_name' which is not a part of the plan",
+		},
+		{
+			name:         "Duplicate phase in plan with dependencies",
+			configFunc:   testConfig,
+			planID:       ifc.ID{Name: "dag_plan_duplicate_phase"},
+			registryFunc: fakeRegistry,
+			errContains: "phase 'capi_init' is listed more than once in the plan 'dag_plan_duplicate_phase', " +
+				"phase names must be unique when dependencies are defined",
+		},
+		{
+			name:         "Invalid phase step options",
+			configFunc:   testConfig,
+			planID:       ifc.ID{Name: "invalid_step_options_plan"},
+			registryFunc: fakeRegistry,
+			errContains: "phase 'capi_init' of the plan 'invalid_step_options_plan' is not valid: " +
+				"retry attempts must be greater than zero",
+		},
 	}
 	for _, tc := range testCases {
 		tt := tc
@@ -507,6 +675,12 @@ var _ ifc.Executor = fakeExecutor{}
 
 type fakeExecutor struct {
 	validate error
+	run      error
+	describe error
+}
+
+func (e fakeExecutor) Describe() (string, error) {
+	return "Fake executor description\n", e.describe
 }
 
 func (e fakeExecutor) Render(_ io.Writer, _ ifc.RenderOptions) error {
@@ -514,7 +688,7 @@ func (e fakeExecutor) Render(_ io.Writer, _ ifc.RenderOptions) error {
 }
 
 func (e fakeExecutor) Run(_ ifc.RunOptions) error {
-	return nil
+	return e.run
 }
 
 func (e fakeExecutor) Validate() error {
@@ -15,12 +15,15 @@
 package phase
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
@@ -35,12 +38,18 @@ const (
 	TableOutputFormat = "table"
 	// YamlOutputFormat yaml
 	YamlOutputFormat = "yaml"
+	// JSONOutputFormat json
+	JSONOutputFormat = "json"
 )
 
+// statusOutputFormats lists formats supported by status commands
+var statusOutputFormats = []string{TableOutputFormat, YamlOutputFormat, JSONOutputFormat}
+
 // GenericRunFlags generic options for run command
 type GenericRunFlags struct {
-	DryRun  bool
-	Timeout time.Duration
+	DryRun        bool
+	Timeout       time.Duration
+	ImageRegistry string
 }
 
 // RunFlags options for phase run command
@@ -203,6 +212,9 @@ func (c *PlanListCommand) RunE() error {
 type PlanRunFlags struct {
 	GenericRunFlags
 	ResumeFromPhase string
+	Resume          bool
+	Force           bool
+	MaxParallel     int
 }
 
 // PlanRunCommand phase run command
@@ -233,6 +245,79 @@ func (c *PlanRunCommand) RunE() error {
 	return plan.Run(c.Options)
 }
 
+// PlanStatusFlags flags given for plan status command
+type PlanStatusFlags struct {
+	PlanID     ifc.ID
+	FormatType string
+}
+
+// PlanStatusCommand plan status command
+type PlanStatusCommand struct {
+	Options PlanStatusFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE prints execution state of the phases recorded during the last plan run
+func (c *PlanStatusCommand) RunE() error {
+	if err := checkStatusFormat(c.Options.FormatType); err != nil {
+		return err
+	}
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	plan, err := NewClient(helper).PlanByID(c.Options.PlanID)
+	if err != nil {
+		return err
+	}
+
+	status, err := plan.Status(ifc.StatusOptions{})
+	if err != nil {
+		return err
+	}
+	if status.LastRun == nil {
+		return phaseerrors.ErrPlanRunNotFound{PlanName: c.Options.PlanID.Name}
+	}
+	return writeStatus(c.Writer, c.Options.FormatType, status.LastRun, func() error {
+		phases := make([]*v1alpha1.PhaseRun, len(status.LastRun.Phases))
+		for i := range status.LastRun.Phases {
+			phases[i] = &status.LastRun.Phases[i]
+		}
+		return util.PrintObjects(phases, util.PlanRunFormat, c.Writer, false)
+	})
+}
+
+// checkStatusFormat makes sure that output format is supported by status commands
+func checkStatusFormat(format string) error {
+	for _, f := range statusOutputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return phaseerrors.ErrInvalidFormat{RequestedFormat: format, AllowedFormats: statusOutputFormats}
+}
+
+// writeStatus writes status object in yaml or json format, printTable is called for the table format
+func writeStatus(w io.Writer, format string, obj interface{}, printTable func() error) error {
+	switch format {
+	case YamlOutputFormat:
+		return yaml.WriteOut(w, obj)
+	case JSONOutputFormat:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(obj)
+	default:
+		return printTable()
+	}
+}
+
 // ClusterListCommand options for cluster list command
 type ClusterListCommand struct {
 	Factory config.Factory
@@ -296,21 +381,63 @@ func (c *ValidateCommand) RunE() error {
 	return phase.Validate()
 }
 
+// DescribeFlags options for phase describe command
+type DescribeFlags struct {
+	PhaseID ifc.ID
+}
+
+// DescribeCommand phase describe command
+type DescribeCommand struct {
+	Options DescribeFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE prints the description of the phase along with the actions performed by its executor
+func (c *DescribeCommand) RunE() error {
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	phase, err := NewClient(helper).PhaseByID(c.Options.PhaseID)
+	if err != nil {
+		return err
+	}
+
+	details, err := phase.Details()
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(c.Writer, details)
+	return err
+}
+
 // StatusFlags is a struct to define status type
 type StatusFlags struct {
-	Timeout  time.Duration
-	PhaseID  ifc.ID
-	Progress bool
+	Timeout    time.Duration
+	PhaseID    ifc.ID
+	Progress   bool
+	FormatType string
 }
 
 // StatusCommand is a struct which defines status
 type StatusCommand struct {
 	Options StatusFlags
 	Factory config.Factory
+	Writer  io.Writer
 }
 
-// RunE returns the status of the given phase
+// RunE prints the status of the given phase
 func (s *StatusCommand) RunE() error {
+	if err := checkStatusFormat(s.Options.FormatType); err != nil {
+		return err
+	}
 	cfg, err := s.Factory()
 	if err != nil {
 		return err
@@ -326,8 +453,27 @@ func (s *StatusCommand) RunE() error {
 		return err
 	}
 
-	_, err = ph.Status()
-	return err
+	status, err := ph.Status()
+	if err != nil {
+		return err
+	}
+
+	return writeStatus(s.Writer, s.Options.FormatType, status, func() error {
+		if err = util.PrintObjects(&status, util.PhaseStatusFormat, s.Writer, false); err != nil {
+			return err
+		}
+		if len(status.Resources) == 0 {
+			return nil
+		}
+		if _, err = fmt.Fprintln(s.Writer); err != nil {
+			return err
+		}
+		resources := make([]*v1alpha1.ResourceStatus, len(status.Resources))
+		for i := range status.Resources {
+			resources[i] = &status.Resources[i]
+		}
+		return util.PrintObjects(resources, util.ResourceStatusFormat, s.Writer, false)
+	})
 }
 
 // PlanValidateFlags options for plan validate command
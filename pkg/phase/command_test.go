@@ -501,6 +501,75 @@ func TestPlanRunCommand(t *testing.T) {
 	}
 }
 
+func TestPlanStatusCommand(t *testing.T) {
+	testErr := fmt.Errorf(testFactoryErr)
+	testCases := []struct {
+		name        string
+		factory     config.Factory
+		format      string
+		expectedErr string
+		planID      ifc.ID
+	}{
+		{
+			name:        "Error invalid format",
+			format:      "xml",
+			expectedErr: "invalid output format specified xml. Allowed values are table|yaml|json",
+		},
+		{
+			name: "Error config factory",
+			factory: func() (*config.Config, error) {
+				return nil, testErr
+			},
+			format:      "table",
+			expectedErr: testFactoryErr,
+		},
+		{
+			name: "Error new helper",
+			factory: func() (*config.Config, error) {
+				return &config.Config{
+					CurrentContext: "does not exist",
+					Contexts:       make(map[string]*config.Context),
+				}, nil
+			},
+			format:      "yaml",
+			expectedErr: "missing configuration: context with name 'does not exist'",
+		},
+		{
+			name: "Error plan by id",
+			planID: ifc.ID{
+				Name: "doesn't exist",
+			},
+			factory: func() (*config.Config, error) {
+				conf := config.NewConfig()
+				manifest := conf.Manifests[config.AirshipDefaultManifest]
+				manifest.TargetPath = testTargetPath
+				manifest.MetadataPath = testMetadataPath
+				manifest.Repositories[config.DefaultTestPhaseRepo].URLString = ""
+				return conf, nil
+			},
+			format:      "table",
+			expectedErr: `found no documents`,
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := phase.PlanStatusCommand{
+				Options: phase.PlanStatusFlags{PlanID: tt.planID, FormatType: tt.format},
+				Factory: tt.factory,
+				Writer:  &bytes.Buffer{},
+			}
+			err := cmd.RunE()
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestClusterListCommand_RunE(t *testing.T) {
 	testErr := fmt.Errorf(testFactoryErr)
 	testCases := []struct {
@@ -680,12 +749,18 @@ func TestStatusCommand(t *testing.T) {
 		statusFlags phase.StatusFlags
 		factory     config.Factory
 	}{
+		{
+			name:        "Error invalid format",
+			statusFlags: phase.StatusFlags{FormatType: "xml"},
+			errContains: "invalid output format specified xml. Allowed values are table|yaml|json",
+		},
 		{
 			name: "Error config factory",
 			factory: func() (*config.Config, error) {
 				return nil, fmt.Errorf(testFactoryErr)
 			},
 			errContains: testFactoryErr,
+			statusFlags: phase.StatusFlags{FormatType: "table"},
 		},
 		{
 			name: "Error new helper",
@@ -696,6 +771,7 @@ func TestStatusCommand(t *testing.T) {
 				}, nil
 			},
 			errContains: testNewHelperErr,
+			statusFlags: phase.StatusFlags{FormatType: "table"},
 		},
 		{
 			name: "Error phase by id",
@@ -722,6 +798,7 @@ func TestStatusCommand(t *testing.T) {
 				return conf, nil
 			},
 			errContains: testNoBundlePath,
+			statusFlags: phase.StatusFlags{FormatType: "table"},
 		},
 	}
 
@@ -731,6 +808,77 @@ func TestStatusCommand(t *testing.T) {
 			command := phase.StatusCommand{
 				Options: tt.statusFlags,
 				Factory: tt.factory,
+				Writer:  &bytes.Buffer{},
+			}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDescribeCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		factory     config.Factory
+	}{
+		{
+			name: "Error config factory",
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name: "Error new helper",
+			factory: func() (*config.Config, error) {
+				return &config.Config{
+					CurrentContext: "does not exist",
+					Contexts:       make(map[string]*config.Context),
+				}, nil
+			},
+			errContains: testNewHelperErr,
+		},
+		{
+			name: "Error phase by id",
+			factory: func() (*config.Config, error) {
+				conf := config.NewConfig()
+				conf.Manifests = map[string]*config.Manifest{
+					"manifest": {
+						MetadataPath:        "broken_metadata.yaml",
+						TargetPath:          "testdata",
+						PhaseRepositoryName: config.DefaultTestPhaseRepo,
+						Repositories: map[string]*config.Repository{
+							config.DefaultTestPhaseRepo: {
+								URLString: "",
+							},
+						},
+					},
+				}
+				conf.CurrentContext = "context"
+				conf.Contexts = map[string]*config.Context{
+					"context": {
+						Manifest: "manifest",
+					},
+				}
+				return conf, nil
+			},
+			errContains: testNoBundlePath,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			command := phase.DescribeCommand{
+				Options: phase.DescribeFlags{PhaseID: ifc.ID{Name: "capi_init"}},
+				Factory: tt.factory,
+				Writer:  &bytes.Buffer{},
 			}
 			err := command.RunE()
 			if tt.errContains != "" {
@@ -810,3 +958,109 @@ func TestPlanValidateCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestImagesCommand(t *testing.T) {
+	factory := func() (*config.Config, error) {
+		conf := config.NewConfig()
+		manifest := conf.Manifests[config.AirshipDefaultManifest]
+		manifest.TargetPath = testTargetPath
+		manifest.MetadataPath = "images_site/metadata.yaml"
+		manifest.Repositories[config.DefaultTestPhaseRepo].URLString = ""
+		return conf, nil
+	}
+
+	tests := []struct {
+		name        string
+		options     phase.ImagesFlags
+		factory     config.Factory
+		expectedOut string
+		errContains string
+	}{
+		{
+			name:        "Error phase or plan not specified",
+			factory:     factory,
+			errContains: "either phase name or plan name must be specified",
+		},
+		{
+			name: "Error both phase and plan specified",
+			options: phase.ImagesFlags{
+				PhaseID: ifc.ID{Name: "initinfra"},
+				PlanID:  ifc.ID{Name: "deploy"},
+			},
+			factory:     factory,
+			errContains: "either phase name or plan name must be specified",
+		},
+		{
+			name:    "Error config factory",
+			options: phase.ImagesFlags{PhaseID: ifc.ID{Name: "initinfra"}},
+			factory: func() (*config.Config, error) {
+				return nil, fmt.Errorf(testFactoryErr)
+			},
+			errContains: testFactoryErr,
+		},
+		{
+			name:        "Error phase not found",
+			options:     phase.ImagesFlags{PhaseID: ifc.ID{Name: "does-not-exist"}},
+			factory:     factory,
+			errContains: "found no documents",
+		},
+		{
+			name:    "Phase images",
+			options: phase.ImagesFlags{PhaseID: ifc.ID{Name: "initinfra"}},
+			factory: factory,
+			expectedOut: "busybox:1.33\n" +
+				"ghcr.io/fluxcd/helm-controller:v0.11.1\n" +
+				"localhost/applier:latest\n" +
+				"quay.io/airshipit/ipa-wallaby:latest\n" +
+				"quay.io/calico/kube-controllers:v3.15.1\n" +
+				"quay.io/metal3-io/ironic:capm3-v0.5.0\n",
+		},
+		{
+			name:    "Plan images",
+			options: phase.ImagesFlags{PlanID: ifc.ID{Name: "deploy"}},
+			factory: factory,
+			expectedOut: "busybox:1.33\n" +
+				"gcr.io/k8s-staging-cluster-api/cluster-api-controller:v0.3.3\n" +
+				"ghcr.io/fluxcd/helm-controller:v0.11.1\n" +
+				"localhost/applier:latest\n" +
+				"localhost/clusterctl:latest\n" +
+				"quay.io/airshipit/ipa-wallaby:latest\n" +
+				"quay.io/airshipit/toolbox:latest\n" +
+				"quay.io/calico/kube-controllers:v3.15.1\n" +
+				"quay.io/metal3-io/ironic:capm3-v0.5.0\n",
+		},
+		{
+			name: "Phase images with registry",
+			options: phase.ImagesFlags{
+				PhaseID:       ifc.ID{Name: "initinfra"},
+				ImageRegistry: "registry.local:5000",
+			},
+			factory: factory,
+			expectedOut: "busybox:1.33 registry.local:5000/library/busybox:1.33\n" +
+				"ghcr.io/fluxcd/helm-controller:v0.11.1 registry.local:5000/fluxcd/helm-controller:v0.11.1\n" +
+				"quay.io/airshipit/ipa-wallaby:latest registry.local:5000/airshipit/ipa-wallaby:latest\n" +
+				"quay.io/calico/kube-controllers:v3.15.1 registry.local:5000/calico/kube-controllers:v3.15.1\n" +
+				"quay.io/metal3-io/ironic:capm3-v0.5.0 registry.local:5000/metal3-io/ironic:capm3-v0.5.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			command := phase.ImagesCommand{
+				Options: tt.options,
+				Factory: tt.factory,
+				Writer:  out,
+			}
+			err := command.RunE()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOut, out.String())
+		})
+	}
+}
@@ -16,6 +16,7 @@ package errors
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ErrDocumentEntrypointNotDefined returned when phase has no entrypoint defined and phase needs it
@@ -55,10 +56,16 @@ func (e ErrRenderPhaseNameNotSpecified) Error() string {
 // ErrInvalidFormat is called when the user provides format other than yaml/json
 type ErrInvalidFormat struct {
 	RequestedFormat string
+	// AllowedFormats lists supported formats, table and yaml are assumed if empty
+	AllowedFormats []string
 }
 
 func (e ErrInvalidFormat) Error() string {
-	return fmt.Sprintf("invalid output format specified %s. Allowed values are table|yaml", e.RequestedFormat)
+	allowed := "table|yaml"
+	if len(e.AllowedFormats) > 0 {
+		allowed = strings.Join(e.AllowedFormats, "|")
+	}
+	return fmt.Sprintf("invalid output format specified %s. Allowed values are %s", e.RequestedFormat, allowed)
 }
 
 // ErrInvalidPhase is returned if the phase is invalid
@@ -78,3 +85,115 @@ type ErrInvalidOutputFormat struct {
 func (e ErrInvalidOutputFormat) Error() string {
 	return fmt.Sprintf("invalid output format specified %s. Allowed values are table|name", e.RequestedFormat)
 }
+
+// ErrPlanRunNotFound is returned when there is no recorded execution state for the plan
+type ErrPlanRunNotFound struct {
+	PlanName string
+}
+
+func (e ErrPlanRunNotFound) Error() string {
+	return fmt.Sprintf("plan '%s' has no recorded runs, execute 'airshipctl plan run %s' first",
+		e.PlanName, e.PlanName)
+}
+
+// ErrPhaseNotInPlan is returned when requested phase is not a part of the plan
+type ErrPhaseNotInPlan struct {
+	PlanName  string
+	PhaseName string
+}
+
+func (e ErrPhaseNotInPlan) Error() string {
+	return fmt.Sprintf("phase '%s' is not a part of the plan '%s'", e.PhaseName, e.PlanName)
+}
+
+// ErrConflictingResumeOptions is returned when both resume and resume from phase options are specified
+type ErrConflictingResumeOptions struct{}
+
+func (e ErrConflictingResumeOptions) Error() string {
+	return "resume and resume from phase options can not be used together"
+}
+
+// ErrPlanChanged is returned when plan definition has changed since the recorded run
+type ErrPlanChanged struct {
+	PlanName string
+}
+
+func (e ErrPlanChanged) Error() string {
+	return fmt.Sprintf("plan '%s' has changed since the last run, use force option to resume anyway", e.PlanName)
+}
+
+// ErrPhaseBundleChanged is returned when rendered documents of the completed phase have changed
+// since the recorded run
+type ErrPhaseBundleChanged struct {
+	PlanName  string
+	PhaseName string
+}
+
+func (e ErrPhaseBundleChanged) Error() string {
+	return fmt.Sprintf("documents of the completed phase '%s' of the plan '%s' have changed since the last run, "+
+		"use force option to resume anyway", e.PhaseName, e.PlanName)
+}
+
+// ErrDuplicatePhaseInPlan is returned when phase is listed more than once in the plan with dependencies
+type ErrDuplicatePhaseInPlan struct {
+	PlanName  string
+	PhaseName string
+}
+
+func (e ErrDuplicatePhaseInPlan) Error() string {
+	return fmt.Sprintf("phase '%s' is listed more than once in the plan '%s', "+
+		"phase names must be unique when dependencies are defined", e.PhaseName, e.PlanName)
+}
+
+// ErrUnknownPhaseDependency is returned when phase depends on a phase which is not a part of the plan
+type ErrUnknownPhaseDependency struct {
+	PlanName   string
+	PhaseName  string
+	Dependency string
+}
+
+func (e ErrUnknownPhaseDependency) Error() string {
+	return fmt.Sprintf("phase '%s' of the plan '%s' depends on phase '%s' which is not a part of the plan",
+		e.PhaseName, e.PlanName, e.Dependency)
+}
+
+// ErrPhaseDependencyCycle is returned when phase dependencies of the plan form a cycle
+type ErrPhaseDependencyCycle struct {
+	PlanName string
+	Cycle    []string
+}
+
+func (e ErrPhaseDependencyCycle) Error() string {
+	return fmt.Sprintf("plan '%s' has a dependency cycle: %s", e.PlanName, strings.Join(e.Cycle, " -> "))
+}
+
+// ErrInvalidPhaseStep is returned when execution options of the plan phase are not valid
+type ErrInvalidPhaseStep struct {
+	PlanName  string
+	PhaseName string
+	Reason    string
+}
+
+func (e ErrInvalidPhaseStep) Error() string {
+	return fmt.Sprintf("phase '%s' of the plan '%s' is not valid: %s", e.PhaseName, e.PlanName, e.Reason)
+}
+
+// ErrPhaseHookFailed is returned when pre or post hook phase of the plan phase fails
+type ErrPhaseHookFailed struct {
+	PhaseName     string
+	HookType      string
+	HookPhaseName string
+	Err           error
+}
+
+func (e ErrPhaseHookFailed) Error() string {
+	return fmt.Sprintf("%s hook phase '%s' of the phase '%s' failed: %v",
+		e.HookType, e.HookPhaseName, e.PhaseName, e.Err)
+}
+
+// ErrPhaseOrPlanNotSpecified is returned when command requires either phase name or plan name
+type ErrPhaseOrPlanNotSpecified struct{}
+
+func (e ErrPhaseOrPlanNotSpecified) Error() string {
+	return "either phase name or plan name must be specified"
+}
@@ -17,22 +17,26 @@ package executors
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
-	commonerrors "opendev.org/airship/airshipctl/pkg/errors"
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory"
 	inventoryifc "opendev.org/airship/airshipctl/pkg/inventory/ifc"
 	"opendev.org/airship/airshipctl/pkg/log"
 	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
 )
 
 // BaremetalManagerExecutor is abstraction built on top of baremetal commands of airshipctl
 type BaremetalManagerExecutor struct {
-	inventory inventoryifc.Inventory
-	options   *airshipv1.BaremetalManager
+	inventory         inventoryifc.Inventory
+	options           *airshipv1.BaremetalManager
+	phaseConfigBundle document.Bundle
 }
 
 // NewBaremetalExecutor constructor for baremetal executor
@@ -42,8 +46,9 @@ func NewBaremetalExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		return nil, err
 	}
 	return &BaremetalManagerExecutor{
-		inventory: cfg.Inventory,
-		options:   options,
+		inventory:         cfg.Inventory,
+		options:           options,
+		phaseConfigBundle: cfg.PhaseConfigBundle,
 	}, nil
 }
 
@@ -57,11 +62,24 @@ func (e *BaremetalManagerExecutor) Run(opts ifc.RunOptions) error {
 	if err != nil {
 		return err
 	}
-	if !opts.DryRun {
+	switch {
+	case e.options.Spec.Operation == airshipv1.BaremetalOperationConfigureBIOS:
+		// dry run reports BIOS settings that differ from the desired ones without changing them
+		var settings hardware.BIOSSettings
+		if settings, err = e.biosSettings(); err == nil {
+			err = commandOptions.ConfigureBIOS(settings, opts.DryRun, outputWriter(opts))
+		}
+	case e.options.Spec.Operation == airshipv1.BaremetalOperationFirmwareUpdate:
+		// dry run reports firmware components which versions differ from the desired ones without updating them
+		var targets []hardware.FirmwareTarget
+		if targets, err = e.firmwareTargets(); err == nil {
+			err = commandOptions.UpdateFirmware(targets, opts.DryRun, outputWriter(opts))
+		}
+	case !opts.DryRun:
 		switch e.options.Spec.Operation {
 		case airshipv1.BaremetalOperationPowerOn, airshipv1.BaremetalOperationPowerOff,
 			airshipv1.BaremetalOperationReboot, airshipv1.BaremetalOperationEjectVirtualMedia:
-			err = commandOptions.BMHAction(op)
+			err = commandOptions.BMHAction(op, outputWriter(opts))
 		case airshipv1.BaremetalOperationRemoteDirect:
 			err = commandOptions.RemoteDirect()
 		}
@@ -97,6 +115,16 @@ func (e *BaremetalManagerExecutor) validate() (inventoryifc.BaremetalOperation,
 	case airshipv1.BaremetalOperationRemoteDirect:
 		// TODO add remote direct validation, make sure that ISO-URL is specified
 		result = ""
+	case airshipv1.BaremetalOperationConfigureBIOS:
+		result = inventoryifc.BaremetalOperationConfigureBIOS
+		if e.options.Spec.OperationOptions.ConfigureBIOS.ConfigRef == nil {
+			err = errors.ErrBIOSConfigRefNotDefined{Name: e.options.GetName()}
+		}
+	case airshipv1.BaremetalOperationFirmwareUpdate:
+		result = inventoryifc.BaremetalOperationFirmwareUpdate
+		if e.options.Spec.OperationOptions.FirmwareUpdate.ConfigRef == nil {
+			err = errors.ErrFirmwareConfigRefNotDefined{Name: e.options.GetName()}
+		}
 	default:
 		err = errors.ErrUnknownExecutorAction{Action: string(e.options.Spec.Operation), ExecutorName: BMHManager}
 	}
@@ -110,6 +138,82 @@ func (e *BaremetalManagerExecutor) Render(w io.Writer, _ ifc.RenderOptions) erro
 	return err
 }
 
+// Describe returns the operation along with the hosts it's performed against
+func (e *BaremetalManagerExecutor) Describe() (string, error) {
+	if _, err := e.validate(); err != nil {
+		return "", err
+	}
+
+	hosts, err := e.selectHosts()
+	if err != nil {
+		return "", err
+	}
+
+	spec := e.options.Spec
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Performs '%s' operation against %d hosts selected by name '%s', namespace '%s', labels '%s':\n",
+		spec.Operation, len(hosts), spec.HostSelector.Name, spec.HostSelector.Namespace,
+		spec.HostSelector.LabelSelector)
+	for _, host := range hosts {
+		fmt.Fprintf(sb, "  %s\n", host.NodeName())
+	}
+	if spec.Operation == airshipv1.BaremetalOperationRemoteDirect {
+		fmt.Fprintf(sb, "ISO URL: %s\n", spec.OperationOptions.RemoteDirect.ISOURL)
+	}
+	if spec.Operation == airshipv1.BaremetalOperationConfigureBIOS {
+		ref := spec.OperationOptions.ConfigureBIOS.ConfigRef
+		fmt.Fprintf(sb, "BIOS configuration: %s '%s'\n", ref.Kind, ref.Name)
+	}
+	if spec.Operation == airshipv1.BaremetalOperationFirmwareUpdate {
+		ref := spec.OperationOptions.FirmwareUpdate.ConfigRef
+		fmt.Fprintf(sb, "Firmware configuration: %s '%s'\n", ref.Kind, ref.Name)
+	}
+	if spec.Timeout > 0 {
+		fmt.Fprintf(sb, "Timeout: %ds\n", spec.Timeout)
+	}
+	if len(hosts) > 1 {
+		fmt.Fprintf(sb, "Concurrency: %d, fail fast: %t\n", spec.Concurrency, spec.FailFast)
+	}
+	return sb.String(), nil
+}
+
+// biosSettings returns the desired BIOS settings from BIOSConfiguration document referenced by the executor
+func (e *BaremetalManagerExecutor) biosSettings() (hardware.BIOSSettings, error) {
+	ref := e.options.Spec.OperationOptions.ConfigureBIOS.ConfigRef
+	log.Debugf("Looking for BIOS configuration referenced by '%v'", ref)
+	doc, err := e.phaseConfigBundle.SelectOne(document.NewSelector().ByObjectReference(ref))
+	if err != nil {
+		return hardware.BIOSSettings{}, err
+	}
+	return inventory.BIOSSettingsFromDocument(doc)
+}
+
+// firmwareTargets returns the desired firmware versions from FirmwareConfiguration document referenced by
+// the executor
+func (e *BaremetalManagerExecutor) firmwareTargets() ([]hardware.FirmwareTarget, error) {
+	ref := e.options.Spec.OperationOptions.FirmwareUpdate.ConfigRef
+	log.Debugf("Looking for firmware configuration referenced by '%v'", ref)
+	doc, err := e.phaseConfigBundle.SelectOne(document.NewSelector().ByObjectReference(ref))
+	if err != nil {
+		return nil, err
+	}
+	return inventory.FirmwareTargetsFromDocument(doc)
+}
+
+// selectHosts returns remote clients of the hosts matching host selector of the executor
+func (e *BaremetalManagerExecutor) selectHosts() ([]remoteifc.Client, error) {
+	bmhInventory, err := e.inventory.BaremetalInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	selector := (inventoryifc.BaremetalHostSelector{}).
+		ByLabel(e.options.Spec.HostSelector.LabelSelector).
+		ByName(e.options.Spec.HostSelector.Name).
+		ByNamespace(e.options.Spec.HostSelector.Namespace)
+	return bmhInventory.Select(selector)
+}
+
 func toCommandOptions(i inventoryifc.Inventory,
 	spec v1alpha1.BaremetalManagerSpec,
 	opts ifc.RunOptions) *inventory.CommandOptions {
@@ -125,10 +229,8 @@ func toCommandOptions(i inventoryifc.Inventory,
 		Name:      spec.HostSelector.Name,
 		Namespace: spec.HostSelector.Namespace,
 		Timeout:   timeout,
-	}
-}
 
-// Status returns the status of the given phase
-func (e *BaremetalManagerExecutor) Status() (ifc.ExecutorStatus, error) {
-	return ifc.ExecutorStatus{}, commonerrors.ErrNotImplemented{What: BMHManager}
+		Concurrency: spec.Concurrency,
+		FailFast:    spec.FailFast,
+	}
 }
@@ -0,0 +1,270 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+)
+
+const (
+	bmhGroup = "metal3.io"
+
+	desiredStateConditionType = "DesiredStateReached"
+	poweredOnConditionType    = "PoweredOn"
+	mediaConditionType        = "VirtualMediaInserted"
+	biosConditionType         = "BIOSConfigured"
+	firmwareConditionType     = "FirmwareUpdated"
+)
+
+// hostState is the observed state of a baremetal host
+type hostState struct {
+	power           power.Status
+	inserted        bool
+	biosConfigured  bool
+	firmwareUpdated bool
+}
+
+// desiredSettings are the settings referenced by the operation, the hosts are compared with the ones that are set
+type desiredSettings struct {
+	bios *hardware.BIOSSettings
+	// firmware is nil unless the operation updates firmware
+	firmware []hardware.FirmwareTarget
+}
+
+// Status returns power and virtual media state of the hosts selected by the phase and reports
+// whether they've reached the end state of the configured operation, BIOS settings of the hosts are
+// compared with the desired ones for configure-bios operation and firmware versions for firmware-update
+// operation
+func (e *BaremetalManagerExecutor) Status() (ifc.ExecutorStatus, error) {
+	if _, err := e.validate(); err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	hosts, err := e.selectHosts()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	spec := e.options.Spec
+
+	ctx := context.Background()
+	if spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(spec.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	desired, err := e.desiredSettings()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	sts := ifc.ExecutorStatus{
+		Details: map[string]string{"operation": string(spec.Operation)},
+	}
+	for _, host := range hosts {
+		sts.Resources = append(sts.Resources, e.hostStatus(ctx, host, desired))
+	}
+	summarizeHostStatus(&sts)
+	return sts, nil
+}
+
+// desiredSettings reads the settings referenced by the operation
+func (e *BaremetalManagerExecutor) desiredSettings() (desiredSettings, error) {
+	switch e.options.Spec.Operation {
+	case airshipv1.BaremetalOperationConfigureBIOS:
+		settings, err := e.biosSettings()
+		if err != nil {
+			return desiredSettings{}, err
+		}
+		return desiredSettings{bios: &settings}, nil
+	case airshipv1.BaremetalOperationFirmwareUpdate:
+		targets, err := e.firmwareTargets()
+		if err != nil {
+			return desiredSettings{}, err
+		}
+		if targets == nil {
+			targets = []hardware.FirmwareTarget{}
+		}
+		return desiredSettings{firmware: targets}, nil
+	default:
+		return desiredSettings{}, nil
+	}
+}
+
+// hostStatus compares observed state of the host with the end state of the operation, BIOS settings and firmware
+// versions of the host are compared with the desired ones if they are given
+func (e *BaremetalManagerExecutor) hostStatus(
+	ctx context.Context,
+	host remoteifc.Client,
+	desired desiredSettings) airshipv1.ResourceStatus {
+	rs := airshipv1.ResourceStatus{
+		Group:  bmhGroup,
+		Kind:   document.BareMetalHostKind,
+		Name:   host.NodeName(),
+		Exists: true,
+		State:  airshipv1.StatusUnknown,
+	}
+
+	var err error
+	state := hostState{}
+	if state.power, err = host.SystemPowerStatus(ctx); err != nil {
+		rs.Message = fmt.Sprintf("Unable to get power status: %v", err)
+		return rs
+	}
+	if state.inserted, err = host.VirtualMediaInserted(ctx); err != nil {
+		rs.Message = fmt.Sprintf("Unable to get virtual media status: %v", err)
+		return rs
+	}
+
+	media := "ejected"
+	if state.inserted {
+		media = "inserted"
+	}
+	rs.Message = fmt.Sprintf("Power: %s, virtual media: %s", state.power, media)
+	rs.Conditions = []airshipv1.StatusCondition{
+		{Type: poweredOnConditionType, Status: conditionStatus(state.power == power.StatusOn)},
+		{Type: mediaConditionType, Status: conditionStatus(state.inserted)},
+	}
+
+	if desired.bios != nil {
+		var bios string
+		if state.biosConfigured, bios, err = biosState(ctx, host, *desired.bios); err != nil {
+			rs.Message = fmt.Sprintf("Unable to get BIOS settings: %v", err)
+			rs.Conditions = nil
+			return rs
+		}
+		rs.Message = fmt.Sprintf("%s, BIOS: %s", rs.Message, bios)
+		rs.Conditions = append(rs.Conditions,
+			airshipv1.StatusCondition{Type: biosConditionType, Status: conditionStatus(state.biosConfigured)})
+	}
+	if desired.firmware != nil {
+		var firmware string
+		if state.firmwareUpdated, firmware, err = firmwareState(ctx, host, desired.firmware); err != nil {
+			rs.Message = fmt.Sprintf("Unable to get firmware versions: %v", err)
+			rs.Conditions = nil
+			return rs
+		}
+		rs.Message = fmt.Sprintf("%s, firmware: %s", rs.Message, firmware)
+		rs.Conditions = append(rs.Conditions,
+			airshipv1.StatusCondition{Type: firmwareConditionType, Status: conditionStatus(state.firmwareUpdated)})
+	}
+
+	rs.State = airshipv1.StatusInProgress
+	if e.desiredState(state) {
+		rs.State = airshipv1.StatusCurrent
+	}
+	return rs
+}
+
+// biosState reports whether BIOS settings of the host are the desired ones along with a short description
+func biosState(ctx context.Context, host remoteifc.Client, desired hardware.BIOSSettings) (bool, string, error) {
+	diff, err := inventory.HostBIOSSettingsDiff(ctx, host, desired)
+	if err != nil {
+		return false, "", err
+	}
+	if diff.Empty() {
+		return true, "configured", nil
+	}
+
+	changes := len(diff.Attributes)
+	if len(diff.BootOrder) != 0 {
+		changes++
+	}
+	return false, fmt.Sprintf("%d settings differ", changes), nil
+}
+
+// firmwareState reports whether firmware components of the host have the desired versions along with a short
+// description
+func firmwareState(ctx context.Context, host remoteifc.Client, targets []hardware.FirmwareTarget) (bool, string,
+	error) {
+	updates, err := inventory.HostFirmwareUpdates(ctx, host, targets)
+	if err != nil {
+		return false, "", err
+	}
+	if len(updates) == 0 {
+		return true, "up to date", nil
+	}
+	return false, fmt.Sprintf("%d components to update", len(updates)), nil
+}
+
+// desiredState returns true if the host is in the end state of the configured operation
+func (e *BaremetalManagerExecutor) desiredState(state hostState) bool {
+	switch e.options.Spec.Operation {
+	case airshipv1.BaremetalOperationPowerOn, airshipv1.BaremetalOperationReboot:
+		return state.power == power.StatusOn
+	case airshipv1.BaremetalOperationPowerOff:
+		return state.power == power.StatusOff
+	case airshipv1.BaremetalOperationEjectVirtualMedia:
+		return !state.inserted
+	case airshipv1.BaremetalOperationRemoteDirect:
+		return state.power == power.StatusOn && state.inserted
+	case airshipv1.BaremetalOperationConfigureBIOS:
+		return state.biosConfigured
+	case airshipv1.BaremetalOperationFirmwareUpdate:
+		return state.firmwareUpdated
+	default:
+		return false
+	}
+}
+
+// summarizeHostStatus sets overall state of the phase according to the host statuses
+func summarizeHostStatus(sts *ifc.ExecutorStatus) {
+	states := map[airshipv1.StatusState]int{}
+	for _, rs := range sts.Resources {
+		states[rs.State]++
+	}
+
+	total := len(sts.Resources)
+	switch {
+	case total == 0:
+		sts.State = airshipv1.StatusNotFound
+		sts.Message = "No hosts are selected"
+	case states[airshipv1.StatusUnknown] > 0:
+		sts.State = airshipv1.StatusUnknown
+		sts.Message = fmt.Sprintf("Status of %d of %d hosts is unknown", states[airshipv1.StatusUnknown], total)
+	case states[airshipv1.StatusCurrent] == total:
+		sts.State = airshipv1.StatusCurrent
+		sts.Message = fmt.Sprintf("All %d hosts are in the desired state", total)
+	default:
+		sts.State = airshipv1.StatusInProgress
+		sts.Message = fmt.Sprintf("%d of %d hosts are in the desired state", states[airshipv1.StatusCurrent], total)
+	}
+
+	sts.Conditions = append(sts.Conditions, airshipv1.StatusCondition{
+		Type:    desiredStateConditionType,
+		Status:  conditionStatus(total > 0 && sts.State == airshipv1.StatusCurrent),
+		Message: sts.Message,
+	})
+}
+
+func conditionStatus(value bool) string {
+	if value {
+		return string(metav1.ConditionTrue)
+	}
+	return string(metav1.ConditionFalse)
+}
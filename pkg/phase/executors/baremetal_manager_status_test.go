@@ -0,0 +1,273 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	inventoryifc "opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+	testinventory "opendev.org/airship/airshipctl/testutil/inventory"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+func testHost(t *testing.T, name string, powerStatus power.Status, powerErr error,
+	inserted bool) remoteifc.Client {
+	host, err := redfishutils.NewClient(name, "redfish+https://localhost/Systems/"+name,
+		false, false, "", "")
+	require.NoError(t, err)
+	host.On("NodeName").Return(name)
+	host.On("SystemPowerStatus").Return(powerStatus, powerErr)
+	host.On("VirtualMediaInserted").Return(inserted, nil)
+	return host
+}
+
+func testHostsInventory(hosts []remoteifc.Client, selectErr error) inventoryifc.Inventory {
+	bmhi := &testinventory.MockBMHInventory{}
+	bmhi.On("Select").Return(hosts, selectErr)
+	bi := &testinventory.MockInventory{}
+	bi.On("BaremetalInventory").Return(bmhi, nil)
+	return bi
+}
+
+func hostStatus(name string, state v1alpha1.StatusState, message string,
+	poweredOn, inserted string) v1alpha1.ResourceStatus {
+	return v1alpha1.ResourceStatus{
+		Group:   "metal3.io",
+		Kind:    "BareMetalHost",
+		Name:    name,
+		Exists:  true,
+		State:   state,
+		Message: message,
+		Conditions: []v1alpha1.StatusCondition{
+			{Type: "PoweredOn", Status: poweredOn},
+			{Type: "VirtualMediaInserted", Status: inserted},
+		},
+	}
+}
+
+func TestBMHExecutorStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		operation         string
+		inventory         func(t *testing.T) inventoryifc.Inventory
+		containsErr       string
+		expectedState     v1alpha1.StatusState
+		expectedMessage   string
+		expectedResources []v1alpha1.ResourceStatus
+	}{
+		{
+			name:        "unknown operation",
+			operation:   "unknown",
+			inventory:   func(_ *testing.T) inventoryifc.Inventory { return testBaremetalInventory() },
+			containsErr: "unknown action type",
+		},
+		{
+			name:      "inventory error",
+			operation: "power-on",
+			inventory: func(_ *testing.T) inventoryifc.Inventory {
+				return testBaremetalInventoryNoKustomization()
+			},
+			containsErr: "kustomization.yaml",
+		},
+		{
+			name:      "select error",
+			operation: "power-on",
+			inventory: func(_ *testing.T) inventoryifc.Inventory {
+				return testHostsInventory(nil, errors.New("select error"))
+			},
+			containsErr: "select error",
+		},
+		{
+			name:      "no hosts selected",
+			operation: "power-on",
+			inventory: func(_ *testing.T) inventoryifc.Inventory {
+				return testHostsInventory([]remoteifc.Client{}, nil)
+			},
+			expectedState:   v1alpha1.StatusNotFound,
+			expectedMessage: "No hosts are selected",
+		},
+		{
+			name:      "power-on reached",
+			operation: "power-on",
+			inventory: func(t *testing.T) inventoryifc.Inventory {
+				return testHostsInventory([]remoteifc.Client{
+					testHost(t, "node01", power.StatusOn, nil, false),
+					testHost(t, "node02", power.StatusOn, nil, true),
+				}, nil)
+			},
+			expectedState:   v1alpha1.StatusCurrent,
+			expectedMessage: "All 2 hosts are in the desired state",
+			expectedResources: []v1alpha1.ResourceStatus{
+				hostStatus("node01", v1alpha1.StatusCurrent, "Power: ON, virtual media: ejected", "True", "False"),
+				hostStatus("node02", v1alpha1.StatusCurrent, "Power: ON, virtual media: inserted", "True", "True"),
+			},
+		},
+		{
+			name:      "power-off in progress",
+			operation: "power-off",
+			inventory: func(t *testing.T) inventoryifc.Inventory {
+				return testHostsInventory([]remoteifc.Client{
+					testHost(t, "node01", power.StatusOff, nil, false),
+					testHost(t, "node02", power.StatusPoweringOff, nil, false),
+				}, nil)
+			},
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "1 of 2 hosts are in the desired state",
+			expectedResources: []v1alpha1.ResourceStatus{
+				hostStatus("node01", v1alpha1.StatusCurrent, "Power: OFF, virtual media: ejected", "False", "False"),
+				hostStatus("node02", v1alpha1.StatusInProgress, "Power: POWERING OFF, virtual media: ejected",
+					"False", "False"),
+			},
+		},
+		{
+			name:      "eject-virtual-media not reached",
+			operation: "eject-virtual-media",
+			inventory: func(t *testing.T) inventoryifc.Inventory {
+				return testHostsInventory([]remoteifc.Client{
+					testHost(t, "node01", power.StatusOn, nil, true),
+				}, nil)
+			},
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "0 of 1 hosts are in the desired state",
+			expectedResources: []v1alpha1.ResourceStatus{
+				hostStatus("node01", v1alpha1.StatusInProgress, "Power: ON, virtual media: inserted", "True", "True"),
+			},
+		},
+		{
+			name:      "remote-direct reached",
+			operation: "remote-direct",
+			inventory: func(t *testing.T) inventoryifc.Inventory {
+				return testHostsInventory([]remoteifc.Client{
+					testHost(t, "node01", power.StatusOn, nil, true),
+				}, nil)
+			},
+			expectedState:   v1alpha1.StatusCurrent,
+			expectedMessage: "All 1 hosts are in the desired state",
+			expectedResources: []v1alpha1.ResourceStatus{
+				hostStatus("node01", v1alpha1.StatusCurrent, "Power: ON, virtual media: inserted", "True", "True"),
+			},
+		},
+		{
+			name:      "power status error",
+			operation: "power-on",
+			inventory: func(t *testing.T) inventoryifc.Inventory {
+				return testHostsInventory([]remoteifc.Client{
+					testHost(t, "node01", power.StatusUnknown, errors.New("bmc is unreachable"), false),
+				}, nil)
+			},
+			expectedState:   v1alpha1.StatusUnknown,
+			expectedMessage: "Status of 1 of 1 hosts is unknown",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Group:   "metal3.io",
+					Kind:    "BareMetalHost",
+					Name:    "node01",
+					Exists:  true,
+					State:   v1alpha1.StatusUnknown,
+					Message: "Unable to get power status: bmc is unreachable",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			executor, err := executors.NewBaremetalExecutor(ifc.ExecutorConfig{
+				ExecutorDocument: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, tt.operation, "/some/url")),
+				Inventory:        tt.inventory(t),
+			})
+			require.NoError(t, err)
+
+			sts, err := executor.Status()
+			if tt.containsErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedState, sts.State)
+			assert.Equal(t, tt.expectedMessage, sts.Message)
+			assert.Equal(t, tt.expectedResources, sts.Resources)
+			assert.Equal(t, tt.operation, sts.Details["operation"])
+			require.Len(t, sts.Conditions, 1)
+			assert.Equal(t, "DesiredStateReached", sts.Conditions[0].Type)
+		})
+	}
+}
+
+func TestBMHExecutorStatusConfigureBIOS(t *testing.T) {
+	configured := testBIOSHost("node01", map[string]interface{}{"ProcVirtualization": "Enabled"})
+	pending := testBIOSHost("node02", map[string]interface{}{"ProcVirtualization": "Disabled"})
+	unknown := testBIOSHost("node03", map[string]interface{}{})
+
+	sts, err := newConfigureBIOSExecutor(t, bmhConfigureBIOSExecutor, configured, pending, unknown).Status()
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.StatusUnknown, sts.State)
+	require.Len(t, sts.Resources, 3)
+
+	biosConfigured := hostStatus("node01", v1alpha1.StatusCurrent,
+		"Power: ON, virtual media: ejected, BIOS: configured", "True", "False")
+	biosConfigured.Conditions = append(biosConfigured.Conditions,
+		v1alpha1.StatusCondition{Type: "BIOSConfigured", Status: "True"})
+	assert.Equal(t, biosConfigured, sts.Resources[0])
+
+	assert.Equal(t, v1alpha1.StatusInProgress, sts.Resources[1].State)
+	assert.Equal(t, "Power: ON, virtual media: ejected, BIOS: 1 settings differ", sts.Resources[1].Message)
+
+	assert.Equal(t, v1alpha1.StatusUnknown, sts.Resources[2].State)
+	assert.Equal(t, "Unable to get BIOS settings: BIOS doesn't have attributes 'ProcVirtualization'",
+		sts.Resources[2].Message)
+}
+
+func TestBMHExecutorStatusFirmwareUpdate(t *testing.T) {
+	updated := testFirmwareHost("node01", "2.12.2")
+	pending := testFirmwareHost("node02", "2.10.2")
+	unknown := &testinventory.MockBaremetalHost{}
+	unknown.On("NodeName").Return("node03")
+	unknown.On("NodeID").Return("node03-id")
+	unknown.On("SystemPowerStatus").Return(power.StatusOn, nil)
+	unknown.On("VirtualMediaInserted").Return(false, nil)
+	unknown.On("FirmwareInventory").Return([]hardware.Firmware{{ID: "iDRAC", Version: "4.40.00.00"}}, nil)
+
+	sts, err := newFirmwareUpdateExecutor(t, bmhFirmwareUpdateExecutor, updated, pending, unknown).Status()
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.StatusUnknown, sts.State)
+	require.Len(t, sts.Resources, 3)
+
+	firmwareUpdated := hostStatus("node01", v1alpha1.StatusCurrent,
+		"Power: ON, virtual media: ejected, firmware: up to date", "True", "False")
+	firmwareUpdated.Conditions = append(firmwareUpdated.Conditions,
+		v1alpha1.StatusCondition{Type: "FirmwareUpdated", Status: "True"})
+	assert.Equal(t, firmwareUpdated, sts.Resources[0])
+
+	assert.Equal(t, v1alpha1.StatusInProgress, sts.Resources[1].State)
+	assert.Equal(t, "Power: ON, virtual media: ejected, firmware: 1 components to update", sts.Resources[1].Message)
+
+	assert.Equal(t, v1alpha1.StatusUnknown, sts.Resources[2].State)
+	assert.Equal(t, "Unable to get firmware versions: host doesn't have firmware components 'BIOS'",
+		sts.Resources[2].Message)
+}
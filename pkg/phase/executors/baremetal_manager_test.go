@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,9 @@ import (
 	inventoryifc "opendev.org/airship/airshipctl/pkg/inventory/ifc"
 	"opendev.org/airship/airshipctl/pkg/phase/executors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
 	testdoc "opendev.org/airship/airshipctl/testutil/document"
 	testinventory "opendev.org/airship/airshipctl/testutil/inventory"
 )
@@ -156,6 +160,28 @@ func TestBMHExecutorRun(t *testing.T) {
 	}
 }
 
+func TestBMHExecutorRunResults(t *testing.T) {
+	bmhi := &testinventory.MockBMHInventory{}
+	bmhi.On("RunOperation").Return(nil, inventoryifc.BaremetalBatchRunResults{
+		Operation: inventoryifc.BaremetalOperationPowerOn,
+		Hosts: []inventoryifc.BaremetalHostResult{
+			{NodeName: "node02", NodeID: "node02-id", Duration: time.Second},
+		},
+	})
+	bi := &testinventory.MockInventory{}
+	bi.On("BaremetalInventory").Return(bmhi, nil)
+
+	executor, err := executors.NewBaremetalExecutor(ifc.ExecutorConfig{
+		ExecutorDocument: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "power-on", "")),
+		Inventory:        bi,
+	})
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, executor.Run(ifc.RunOptions{Out: buf}))
+	assert.Contains(t, buf.String(), "node02")
+	assert.Contains(t, buf.String(), "Operation 'power-on' succeeded against 1 of 1 hosts, failed against 0, skipped 0")
+}
+
 func TestBMHValidate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -220,3 +246,200 @@ func TestBMHManagerRender(t *testing.T) {
 	err = executor.Render(bytes.NewBuffer([]byte{}), ifc.RenderOptions{})
 	assert.NoError(t, err)
 }
+
+var bmhConfigureBIOSExecutor = `apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: ConfigureBIOS
+spec:
+  operation: configure-bios
+  hostSelector:
+    name: node02
+  operationOptions:
+    configureBIOS:
+      configRef:
+        apiVersion: airshipit.org/v1alpha1
+        kind: BIOSConfiguration
+        name: r640-bios
+    remoteDirect:
+      isoURL: ""`
+
+var biosConfigurationDoc = `apiVersion: airshipit.org/v1alpha1
+kind: BIOSConfiguration
+metadata:
+  name: r640-bios
+spec:
+  attributes:
+    ProcVirtualization: Enabled
+  bootOrder:
+  - Boot0002
+`
+
+func testBIOSHost(name string, attributes map[string]interface{}) *testinventory.MockBaremetalHost {
+	host := &testinventory.MockBaremetalHost{}
+	host.On("NodeName").Return(name)
+	host.On("NodeID").Return(name + "-id")
+	host.On("SystemPowerStatus").Return(power.StatusOn, nil)
+	host.On("VirtualMediaInserted").Return(false, nil)
+	host.On("BIOSSettings").Return(hardware.BIOSSettings{
+		Attributes: attributes,
+		BootOrder:  []string{"Boot0002"},
+	}, nil)
+	return host
+}
+
+func newConfigureBIOSExecutor(t *testing.T, execDoc string, hosts ...remoteifc.Client) ifc.Executor {
+	bundle, err := document.NewBundleFromBytes([]byte(biosConfigurationDoc))
+	require.NoError(t, err)
+	executor, err := executors.NewBaremetalExecutor(ifc.ExecutorConfig{
+		ExecutorDocument:  executorDoc(t, execDoc),
+		Inventory:         testHostsInventory(hosts, nil),
+		PhaseConfigBundle: bundle,
+	})
+	require.NoError(t, err)
+	return executor
+}
+
+func TestBMHExecutorConfigureBIOS(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		host := testBIOSHost("node02", map[string]interface{}{"ProcVirtualization": "Disabled"})
+		host.On("SetBIOSSettings", hardware.BIOSSettings{
+			Attributes: map[string]interface{}{"ProcVirtualization": "Enabled"},
+		}).Return(nil)
+		host.On("RebootSystem").Return(nil)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, newConfigureBIOSExecutor(t, bmhConfigureBIOSExecutor, host).Run(ifc.RunOptions{Out: buf}))
+		host.AssertExpectations(t)
+		assert.Contains(t, buf.String(), "ProcVirtualization: Disabled -> Enabled")
+		assert.Contains(t, buf.String(), "Operation 'configure-bios' succeeded against 1 of 1 hosts")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		host := testBIOSHost("node02", map[string]interface{}{"ProcVirtualization": "Disabled"})
+		buf := &bytes.Buffer{}
+		executor := newConfigureBIOSExecutor(t, bmhConfigureBIOSExecutor, host)
+		require.NoError(t, executor.Run(ifc.RunOptions{Out: buf, DryRun: true}))
+		host.AssertNotCalled(t, "RebootSystem")
+		assert.Contains(t, buf.String(), "ProcVirtualization: Disabled -> Enabled")
+		assert.Contains(t, buf.String(), "Dry run, BIOS settings of the hosts are not changed")
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		host := testBIOSHost("node02", nil)
+		description, err := newConfigureBIOSExecutor(t, bmhConfigureBIOSExecutor, host).Describe()
+		require.NoError(t, err)
+		assert.Contains(t, description, "Performs 'configure-bios' operation against 1 hosts")
+		assert.Contains(t, description, "BIOS configuration: BIOSConfiguration 'r640-bios'\n")
+	})
+
+	t.Run("error config not found", func(t *testing.T) {
+		host := testBIOSHost("node02", nil)
+		execDoc := strings.Replace(bmhConfigureBIOSExecutor, "name: r640-bios", "name: r740-bios", 1)
+		err := newConfigureBIOSExecutor(t, execDoc, host).Run(ifc.RunOptions{Out: &bytes.Buffer{}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "r740-bios")
+	})
+
+	t.Run("error no config ref", func(t *testing.T) {
+		execDoc := fmt.Sprintf(bmhExecutorTemplate, "configure-bios", "")
+		err := newConfigureBIOSExecutor(t, execDoc).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "BaremetalManager 'RemoteDirectEphemeral' must reference BIOSConfiguration")
+	})
+}
+
+var bmhFirmwareUpdateExecutor = `apiVersion: airshipit.org/v1alpha1
+kind: BaremetalManager
+metadata:
+  name: FirmwareUpdate
+spec:
+  operation: firmware-update
+  hostSelector:
+    name: node02
+  operationOptions:
+    firmwareUpdate:
+      configRef:
+        apiVersion: airshipit.org/v1alpha1
+        kind: FirmwareConfiguration
+        name: r640-firmware
+    remoteDirect:
+      isoURL: ""`
+
+var firmwareConfigurationDoc = `apiVersion: airshipit.org/v1alpha1
+kind: FirmwareConfiguration
+metadata:
+  name: r640-firmware
+spec:
+  firmware:
+  - component: BIOS
+    version: 2.12.2
+    imageURL: http://localhost:8099/bios-2.12.2.exe
+`
+
+func testFirmwareHost(name, biosVersion string) *testinventory.MockBaremetalHost {
+	host := &testinventory.MockBaremetalHost{}
+	host.On("NodeName").Return(name)
+	host.On("NodeID").Return(name + "-id")
+	host.On("SystemPowerStatus").Return(power.StatusOn, nil)
+	host.On("VirtualMediaInserted").Return(false, nil)
+	host.On("FirmwareInventory").Return([]hardware.Firmware{{ID: "BIOS", Version: biosVersion}}, nil)
+	return host
+}
+
+func newFirmwareUpdateExecutor(t *testing.T, execDoc string, hosts ...remoteifc.Client) ifc.Executor {
+	bundle, err := document.NewBundleFromBytes([]byte(firmwareConfigurationDoc))
+	require.NoError(t, err)
+	executor, err := executors.NewBaremetalExecutor(ifc.ExecutorConfig{
+		ExecutorDocument:  executorDoc(t, execDoc),
+		Inventory:         testHostsInventory(hosts, nil),
+		PhaseConfigBundle: bundle,
+	})
+	require.NoError(t, err)
+	return executor
+}
+
+func TestBMHExecutorFirmwareUpdate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		host := testFirmwareHost("node02", "2.10.2")
+		host.On("UpdateFirmware", "http://localhost:8099/bios-2.12.2.exe").Return(nil)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, newFirmwareUpdateExecutor(t, bmhFirmwareUpdateExecutor, host).Run(ifc.RunOptions{Out: buf}))
+		host.AssertExpectations(t)
+		assert.Contains(t, buf.String(), "BIOS: 2.10.2 -> 2.12.2")
+		assert.Contains(t, buf.String(), "Operation 'firmware-update' succeeded against 1 of 1 hosts")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		host := testFirmwareHost("node02", "2.10.2")
+		buf := &bytes.Buffer{}
+		executor := newFirmwareUpdateExecutor(t, bmhFirmwareUpdateExecutor, host)
+		require.NoError(t, executor.Run(ifc.RunOptions{Out: buf, DryRun: true}))
+		host.AssertNotCalled(t, "UpdateFirmware", "http://localhost:8099/bios-2.12.2.exe")
+		assert.Contains(t, buf.String(), "BIOS: 2.10.2 -> 2.12.2")
+		assert.Contains(t, buf.String(), "Dry run, firmware of the hosts is not updated")
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		host := testFirmwareHost("node02", "2.12.2")
+		description, err := newFirmwareUpdateExecutor(t, bmhFirmwareUpdateExecutor, host).Describe()
+		require.NoError(t, err)
+		assert.Contains(t, description, "Performs 'firmware-update' operation against 1 hosts")
+		assert.Contains(t, description, "Firmware configuration: FirmwareConfiguration 'r640-firmware'\n")
+	})
+
+	t.Run("error update failed", func(t *testing.T) {
+		host := testFirmwareHost("node02", "2.10.2")
+		host.On("UpdateFirmware", "http://localhost:8099/bios-2.12.2.exe").Return(fmt.Errorf("task failed"))
+		err := newFirmwareUpdateExecutor(t, bmhFirmwareUpdateExecutor, host).Run(ifc.RunOptions{Out: &bytes.Buffer{}})
+		require.Error(t, err)
+	})
+
+	t.Run("error no config ref", func(t *testing.T) {
+		execDoc := fmt.Sprintf(bmhExecutorTemplate, "firmware-update", "")
+		err := newFirmwareUpdateExecutor(t, execDoc).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "BaremetalManager 'RemoteDirectEphemeral' must reference FirmwareConfiguration")
+	})
+}
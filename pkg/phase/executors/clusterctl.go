@@ -19,18 +19,19 @@ import (
 	"fmt"
 	"io"
 	"net/url"
-	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
 
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
-	airerrors "opendev.org/airship/airshipctl/pkg/errors"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
 	"opendev.org/airship/airshipctl/pkg/log"
 	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
@@ -52,6 +53,8 @@ type ClusterctlExecutor struct {
 	execObj    *airshipv1.GenericContainer
 	clientFunc container.ClientV1Alpha1FactoryFunc
 	cctlOpts   *airshipv1.ClusterctlOptions
+
+	dynamicClientFunc utils.DynamicClientFunc
 }
 
 var typeMap = map[string]string{
@@ -73,6 +76,9 @@ func NewClusterctlExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 	if err := initRepoData(options, cctlOpts, cfg.TargetPath); err != nil {
 		return nil, err
 	}
+	if err := initTemplateData(options, cctlOpts, cfg.TargetPath); err != nil {
+		return nil, err
+	}
 
 	doc, err := cfg.PhaseConfigBundle.SelectOne(document.NewClusterctlContainerExecutorSelector())
 	if err != nil {
@@ -90,6 +96,11 @@ func NewClusterctlExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		clientFunc = cfg.ContainerFunc
 	}
 
+	dynamicClientFunc := utils.DynamicClientFromKubeConfig
+	if cfg.DynamicClientFunc != nil {
+		dynamicClientFunc = cfg.DynamicClientFunc
+	}
+
 	return &ClusterctlExecutor{
 		clusterName: cfg.ClusterName,
 		options:     options,
@@ -99,9 +110,37 @@ func NewClusterctlExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		targetPath:  cfg.TargetPath,
 		execObj:     apiObj,
 		clientFunc:  clientFunc,
+
+		dynamicClientFunc: dynamicClientFunc,
 	}, nil
 }
 
+// ClusterctlComponents returns cluster API provider components the Clusterctl executor document builds from
+// the kustomize paths under the target path, keyed by their paths relative to the clusterctl overrides
+// directory. Providers referenced by remote URLs are not included
+func ClusterctlComponents(doc document.Document, targetPath string) (map[string]string, error) {
+	options := airshipv1.DefaultClusterctl()
+	if err := doc.ToAPIObject(options, airshipv1.Scheme); err != nil {
+		return nil, err
+	}
+	cctlOpts := &airshipv1.ClusterctlOptions{
+		Components: map[string]string{},
+	}
+	if err := initRepoData(options, cctlOpts, targetPath); err != nil {
+		return nil, err
+	}
+
+	components := make(map[string]string, len(cctlOpts.Components))
+	for path, data := range cctlOpts.Components {
+		rel, err := filepath.Rel(clusterAPIOverrides, path)
+		if err != nil {
+			return nil, err
+		}
+		components[rel] = data
+	}
+	return components, nil
+}
+
 func initRepoData(c *airshipv1.Clusterctl, o *airshipv1.ClusterctlOptions, targetPath string) error {
 	for _, prv := range c.Providers {
 		rURL, err := url.Parse(prv.URL)
@@ -150,6 +189,65 @@ func initRepoData(c *airshipv1.Clusterctl, o *airshipv1.ClusterctlOptions, targe
 	return nil
 }
 
+// initTemplateData puts the cluster template built from kustomize entrypoint of config-cluster options into the
+// component override tree next to the components of the infrastructure provider, clusterctl reads the template
+// of the provider from there
+func initTemplateData(c *airshipv1.Clusterctl, o *airshipv1.ClusterctlOptions, targetPath string) error {
+	opts := c.ConfigClusterOptions
+	if c.Action != airshipv1.ConfigCluster || opts.Template == "" {
+		return nil
+	}
+	prv, version, err := clusterInfrastructure(c)
+	if err != nil {
+		return err
+	}
+
+	kustomizePath := filepath.Join(targetPath, opts.Template)
+	log.Debugf("Building cluster template from kustomize path at '%s'", kustomizePath)
+	bundle, err := document.NewBundleByPath(kustomizePath)
+	if err != nil {
+		return err
+	}
+	buffer := &bytes.Buffer{}
+	if err = bundle.Write(buffer); err != nil {
+		return err
+	}
+
+	name := "cluster-template.yaml"
+	if opts.Flavor != "" {
+		name = fmt.Sprintf("cluster-template-%s.yaml", opts.Flavor)
+	}
+	o.Components[filepath.Join(clusterAPIOverrides, providerLabelValue(prv), version, name)] = buffer.String()
+	return nil
+}
+
+// clusterInfrastructure returns the infrastructure provider config-cluster action takes the cluster template of
+// along with its version, it must be the only infrastructure provider listed in init options
+func clusterInfrastructure(c *airshipv1.Clusterctl) (*airshipv1.Provider, string, error) {
+	e := &ClusterctlExecutor{options: c}
+	var found *airshipv1.Provider
+	for _, prv := range c.Providers {
+		if _, requested := e.requestedVersion(prv); !requested || prv.Type != airshipv1.InfrastructureProviderType {
+			continue
+		}
+		if found != nil {
+			return nil, "", phaseerrors.ErrInvalidPhase{Reason: fmt.Sprintf("Clusterctl '%s': config-cluster "+
+				"requires single infrastructure provider in init options", c.GetName())}
+		}
+		found = prv
+	}
+	if found == nil {
+		return nil, "", phaseerrors.ErrInvalidPhase{Reason: fmt.Sprintf("Clusterctl '%s': config-cluster "+
+			"requires infrastructure provider in init options", c.GetName())}
+	}
+	version := e.repositoryVersion(found)
+	if version == "" {
+		return nil, "", phaseerrors.ErrInvalidPhase{Reason: fmt.Sprintf("Clusterctl '%s': version of %s %s "+
+			"is not defined", c.GetName(), found.Type, found.Name)}
+	}
+	return found, version, nil
+}
+
 // Run clusterctl init as a phase runner
 func (c *ClusterctlExecutor) Run(opts ifc.RunOptions) error {
 	if log.DebugEnabled() {
@@ -172,21 +270,29 @@ func (c *ClusterctlExecutor) Run(opts ifc.RunOptions) error {
 
 	switch c.options.Action {
 	case airshipv1.Init:
-		return c.init()
+		return c.init(outputWriter(opts))
 	case airshipv1.Move:
-		return c.move(opts.DryRun)
+		return c.move(opts.DryRun, outputWriter(opts))
+	case airshipv1.Upgrade:
+		return c.upgrade(opts.DryRun, outputWriter(opts))
+	case airshipv1.Delete:
+		return c.deleteProviders(opts.DryRun, outputWriter(opts))
+	case airshipv1.Describe:
+		return c.describe(outputWriter(opts))
+	case airshipv1.ConfigCluster:
+		return c.configCluster(outputWriter(opts))
 	default:
 		return errors.ErrUnknownExecutorAction{Action: string(c.options.Action), ExecutorName: "clusterctl"}
 	}
 }
 
-func (c *ClusterctlExecutor) run() error {
+func (c *ClusterctlExecutor) run(out io.Writer) error {
 	opts, err := yaml.Marshal(c.cctlOpts)
 	if err != nil {
 		return err
 	}
 	c.execObj.Config = string(opts)
-	return c.clientFunc("", &bytes.Buffer{}, os.Stdout, c.execObj, c.targetPath).Run()
+	return c.clientFunc("", &bytes.Buffer{}, out, c.execObj, c.targetPath).Run()
 }
 
 func (c *ClusterctlExecutor) getKubeconfig() (string, string, func(), error) {
@@ -210,7 +316,7 @@ func (c *ClusterctlExecutor) getKubeconfig() (string, string, func(), error) {
 	return kubeConfigFile, context, cleanup, nil
 }
 
-func (c *ClusterctlExecutor) init() error {
+func (c *ClusterctlExecutor) init(out io.Writer) error {
 	log.Print("starting clusterctl init executor")
 
 	kubecfg, context, cleanup, err := c.getKubeconfig()
@@ -225,19 +331,13 @@ func (c *ClusterctlExecutor) init() error {
 		"--kubeconfig-context", context,
 	)
 
-	initMap := map[string]string{
-		typeMap[airshipv1.BootstrapProviderType]:      c.options.InitOptions.BootstrapProviders,
-		typeMap[airshipv1.ControlPlaneProviderType]:   c.options.InitOptions.ControlPlaneProviders,
-		typeMap[airshipv1.InfrastructureProviderType]: c.options.InitOptions.InfrastructureProviders,
-		typeMap[airshipv1.CoreProviderType]:           c.options.InitOptions.CoreProvider,
-	}
-	for k, v := range initMap {
+	for k, v := range c.initProviders() {
 		if v != "" {
-			c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, fmt.Sprintf("--%s=%s", k, v))
+			c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, fmt.Sprintf("--%s=%s", typeMap[k], v))
 		}
 	}
 
-	if err = c.run(); err != nil {
+	if err = c.run(out); err != nil {
 		return err
 	}
 
@@ -245,7 +345,17 @@ func (c *ClusterctlExecutor) init() error {
 	return nil
 }
 
-func (c *ClusterctlExecutor) move(dryRun bool) error {
+// initProviders returns providers and their versions requested by init options, mapped by provider type
+func (c *ClusterctlExecutor) initProviders() map[string]string {
+	return map[string]string{
+		airshipv1.BootstrapProviderType:      c.options.InitOptions.BootstrapProviders,
+		airshipv1.ControlPlaneProviderType:   c.options.InitOptions.ControlPlaneProviders,
+		airshipv1.InfrastructureProviderType: c.options.InitOptions.InfrastructureProviders,
+		airshipv1.CoreProviderType:           c.options.InitOptions.CoreProvider,
+	}
+}
+
+func (c *ClusterctlExecutor) move(dryRun bool, out io.Writer) error {
 	log.Print("starting clusterctl move executor")
 
 	kubecfg, context, cleanup, err := c.getKubeconfig()
@@ -280,7 +390,7 @@ func (c *ClusterctlExecutor) move(dryRun bool) error {
 		)
 	}
 
-	if err = c.run(); err != nil {
+	if err = c.run(out); err != nil {
 		return err
 	}
 
@@ -288,6 +398,239 @@ func (c *ClusterctlExecutor) move(dryRun bool) error {
 	return nil
 }
 
+// upgrade upgrades providers to the requested versions using the component override tree, dry run shows
+// the upgrade plan instead
+func (c *ClusterctlExecutor) upgrade(dryRun bool, out io.Writer) error {
+	log.Print("starting clusterctl upgrade executor")
+
+	kubecfg, context, cleanup, err := c.getKubeconfig()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if dryRun {
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, "upgrade", "plan")
+	} else {
+		namespaces, nsErr := c.providerNamespaces(kubecfg)
+		if nsErr != nil {
+			return nsErr
+		}
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, "upgrade", "apply")
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, c.providerFlags(func(prv *airshipv1.Provider) string {
+			return fmt.Sprintf("%s/%s:%s", namespaces[providerLabelValue(prv)], prv.Name, c.repositoryVersion(prv))
+		})...)
+	}
+	c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions,
+		"--kubeconfig", kubecfg,
+		"--kubeconfig-context", context,
+	)
+
+	if err = c.run(out); err != nil {
+		return err
+	}
+
+	log.Print("clusterctl upgrade completed successfully")
+	return nil
+}
+
+// deleteProviders deletes providers requested by init options or all the providers, clusterctl doesn't support
+// dry run for delete so the command is only printed
+func (c *ClusterctlExecutor) deleteProviders(dryRun bool, out io.Writer) error {
+	log.Print("starting clusterctl delete executor")
+
+	kubecfg, context, cleanup, err := c.getKubeconfig()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions,
+		"delete",
+		"--kubeconfig", kubecfg,
+		"--kubeconfig-context", context,
+	)
+	if c.options.DeleteOptions.All {
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, "--all")
+	} else {
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, c.providerFlags(nil)...)
+	}
+	if c.options.DeleteOptions.IncludeNamespace {
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, "--include-namespace")
+	}
+	if c.options.DeleteOptions.IncludeCRDs {
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, "--include-crd")
+	}
+
+	if dryRun {
+		log.Printf("dry run, skipping clusterctl %s", strings.Join(c.cctlOpts.CmdOptions, " "))
+		return nil
+	}
+
+	if err = c.run(out); err != nil {
+		return err
+	}
+
+	log.Print("clusterctl delete completed successfully")
+	return nil
+}
+
+// describe shows Cluster API objects of the cluster, the cluster isn't changed
+func (c *ClusterctlExecutor) describe(out io.Writer) error {
+	log.Print("starting clusterctl describe executor")
+
+	kubecfg, context, cleanup, err := c.getKubeconfig()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	name, namespace := c.describedCluster()
+	c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions,
+		"describe", "cluster", name,
+		"--kubeconfig", kubecfg,
+		"--kubeconfig-context", context,
+		"--show-conditions", "all",
+	)
+	if namespace != "" {
+		c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, "--namespace", namespace)
+	}
+	return c.run(out)
+}
+
+// configCluster prints the cluster rendered from the cluster template of the infrastructure provider, the
+// cluster isn't changed
+func (c *ClusterctlExecutor) configCluster(out io.Writer) error {
+	log.Print("starting clusterctl config cluster executor")
+
+	prv, version, err := clusterInfrastructure(c.options)
+	if err != nil {
+		return err
+	}
+
+	kubecfg, context, cleanup, err := c.getKubeconfig()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	opts := c.options.ConfigClusterOptions
+	c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions,
+		"config", "cluster", c.configuredCluster(),
+		"--infrastructure", fmt.Sprintf("%s:%s", prv.Name, version),
+		"--kubeconfig", kubecfg,
+		"--kubeconfig-context", context,
+	)
+	for _, flag := range []struct{ name, value string }{
+		{"--flavor", opts.Flavor},
+		{"--kubernetes-version", opts.KubernetesVersion},
+		{"--target-namespace", opts.TargetNamespace},
+		{"--control-plane-machine-count", machineCount(opts.ControlPlaneMachineCount)},
+		{"--worker-machine-count", machineCount(opts.WorkerMachineCount)},
+	} {
+		if flag.value != "" {
+			c.cctlOpts.CmdOptions = append(c.cctlOpts.CmdOptions, flag.name, flag.value)
+		}
+	}
+	return c.run(out)
+}
+
+// configuredCluster returns name of the Cluster API cluster rendered by config-cluster action
+func (c *ClusterctlExecutor) configuredCluster() string {
+	if c.options.ConfigClusterOptions.Cluster != "" {
+		return c.options.ConfigClusterOptions.Cluster
+	}
+	return c.clusterName
+}
+
+// machineCount returns the machine count flag value, clusterctl default is used for 0
+func machineCount(count int) string {
+	if count == 0 {
+		return ""
+	}
+	return strconv.Itoa(count)
+}
+
+// describedCluster returns name and namespace of the Cluster API cluster shown by describe action
+func (c *ClusterctlExecutor) describedCluster() (string, string) {
+	name := c.options.DescribeOptions.Cluster
+	if name == "" {
+		name = c.clusterName
+	}
+	return name, c.options.DescribeOptions.Namespace
+}
+
+// providerFlags returns clusterctl flags selecting providers requested by init options grouped by type,
+// e.g. --bootstrap kubeadm, ref returns reference to the provider, provider names are used if it's nil
+func (c *ClusterctlExecutor) providerFlags(ref func(*airshipv1.Provider) string) []string {
+	byType := map[string][]string{}
+	for _, prv := range c.options.Providers {
+		if _, requested := c.requestedVersion(prv); !requested {
+			continue
+		}
+		name := prv.Name
+		if ref != nil {
+			name = ref(prv)
+		}
+		byType[prv.Type] = append(byType[prv.Type], name)
+	}
+
+	var flags []string
+	for _, prvType := range []string{
+		airshipv1.CoreProviderType,
+		airshipv1.BootstrapProviderType,
+		airshipv1.ControlPlaneProviderType,
+		airshipv1.InfrastructureProviderType,
+	} {
+		if len(byType[prvType]) > 0 {
+			flags = append(flags, "--"+typeMap[prvType], strings.Join(byType[prvType], ","))
+		}
+	}
+	return flags
+}
+
+// repositoryVersion returns version of the provider used by upgrade and config-cluster actions, if init options
+// don't define the version it's taken from the component override tree built from kustomize entrypoint of the
+// provider
+func (c *ClusterctlExecutor) repositoryVersion(prv *airshipv1.Provider) string {
+	if version, _ := c.requestedVersion(prv); version != "" {
+		return version
+	}
+	if strings.HasPrefix(prv.URL, clusterAPIOverrides) {
+		return filepath.Base(filepath.Dir(prv.URL))
+	}
+	return ""
+}
+
+// providerNamespaces returns namespaces of the requested providers installed in the cluster mapped by the
+// provider label value, clusterctl upgrade apply requires providers to be referenced as namespace/name:version
+func (c *ClusterctlExecutor) providerNamespaces(kubeconfig string) (map[string]string, error) {
+	client, mapper, err := c.clusterClient(kubeconfig, c.clusterName)
+	if err != nil {
+		return nil, err
+	}
+	installed, err := utils.ListObjects(client, mapper, providerKind, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string]string{}
+	for _, obj := range installed {
+		name, _, _ := unstructured.NestedString(obj.Object, "providerName")
+		prvType, _, _ := unstructured.NestedString(obj.Object, "type")
+		namespaces[providerLabelValue(&airshipv1.Provider{Name: name, Type: prvType})] = obj.GetNamespace()
+	}
+	for _, prv := range c.options.Providers {
+		if _, requested := c.requestedVersion(prv); !requested {
+			continue
+		}
+		if _, ok := namespaces[providerLabelValue(prv)]; !ok {
+			return nil, errors.ErrProviderNotInstalled{Name: prv.Name, Type: prv.Type, ClusterName: c.clusterName}
+		}
+	}
+	return namespaces, nil
+}
+
 // Validate executor configuration and documents
 func (c *ClusterctlExecutor) Validate() error {
 	switch c.options.Action {
@@ -297,7 +640,18 @@ func (c *ClusterctlExecutor) Validate() error {
 		if c.options.InitOptions.CoreProvider == "" {
 			log.Printf("ClusterctlExecutor.InitOptions.CoreProvider is empty")
 		}
-	case airshipv1.Move:
+	case airshipv1.Upgrade:
+		for _, prv := range c.options.Providers {
+			if _, requested := c.requestedVersion(prv); requested && c.repositoryVersion(prv) == "" {
+				return phaseerrors.ErrInvalidPhase{Reason: fmt.Sprintf("Clusterctl '%s': version to upgrade %s %s "+
+					"to is not defined", c.options.GetName(), prv.Type, prv.Name)}
+			}
+		}
+	case airshipv1.ConfigCluster:
+		if _, _, err := clusterInfrastructure(c.options); err != nil {
+			return err
+		}
+	case airshipv1.Move, airshipv1.Delete, airshipv1.Describe:
 	default:
 		return errors.ErrUnknownExecutorAction{Action: string(c.options.Action)}
 	}
@@ -325,7 +679,98 @@ func (c *ClusterctlExecutor) Render(w io.Writer, ro ifc.RenderOptions) error {
 	return filtered.Write(w)
 }
 
-// Status returns the status of the given phase
-func (c *ClusterctlExecutor) Status() (ifc.ExecutorStatus, error) {
-	return ifc.ExecutorStatus{}, airerrors.ErrNotImplemented{What: Clusterctl}
+// Describe returns the clusterctl action along with the providers it installs or the clusters objects are moved
+// between
+func (c *ClusterctlExecutor) Describe() (string, error) {
+	sb := &strings.Builder{}
+	switch c.options.Action {
+	case airshipv1.Init:
+		fmt.Fprintf(sb, "Initializes Cluster API providers in cluster '%s':\n", c.clusterName)
+		c.describeProviders(sb, func(prv *airshipv1.Provider) string {
+			version, _ := c.requestedVersion(prv)
+			return version
+		})
+	case airshipv1.Upgrade:
+		fmt.Fprintf(sb, "Upgrades Cluster API providers in cluster '%s':\n", c.clusterName)
+		c.describeProviders(sb, c.repositoryVersion)
+	case airshipv1.Delete:
+		c.describeDelete(sb)
+	case airshipv1.Describe:
+		name, namespace := c.describedCluster()
+		fmt.Fprintf(sb, "Shows Cluster API objects of cluster '%s'", name)
+		if namespace != "" {
+			fmt.Fprintf(sb, " in namespace '%s'", namespace)
+		}
+		fmt.Fprintf(sb, " managed by cluster '%s'\n", c.clusterName)
+	case airshipv1.ConfigCluster:
+		if err := c.describeConfigCluster(sb); err != nil {
+			return "", err
+		}
+	case airshipv1.Move:
+		parent, err := c.clusterMap.ParentCluster(c.clusterName)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(sb, "Moves Cluster API objects from cluster '%s' to cluster '%s'", parent, c.clusterName)
+		if c.options.MoveOptions.Namespace != "" {
+			fmt.Fprintf(sb, ", namespace '%s'", c.options.MoveOptions.Namespace)
+		}
+		fmt.Fprintln(sb)
+	default:
+		return "", errors.ErrUnknownExecutorAction{Action: string(c.options.Action), ExecutorName: Clusterctl}
+	}
+	return sb.String(), nil
+}
+
+// describeProviders lists providers requested by init options along with their versions
+func (c *ClusterctlExecutor) describeProviders(sb *strings.Builder, version func(*airshipv1.Provider) string) {
+	for _, prv := range c.options.Providers {
+		if _, requested := c.requestedVersion(prv); !requested {
+			continue
+		}
+		v := version(prv)
+		if v == "" {
+			v = "default version"
+		}
+		fmt.Fprintf(sb, "  %s %s (%s) from '%s'\n", prv.Type, prv.Name, v, prv.URL)
+	}
+}
+
+// describeConfigCluster shows the rendered cluster and the template it's rendered from
+func (c *ClusterctlExecutor) describeConfigCluster(sb *strings.Builder) error {
+	prv, version, err := clusterInfrastructure(c.options)
+	if err != nil {
+		return err
+	}
+	opts := c.options.ConfigClusterOptions
+	fmt.Fprintf(sb, "Prints Cluster API cluster '%s' rendered from the cluster template of %s %s (%s)",
+		c.configuredCluster(), prv.Type, prv.Name, version)
+	if opts.Flavor != "" {
+		fmt.Fprintf(sb, ", flavor '%s'", opts.Flavor)
+	}
+	if opts.Template != "" {
+		fmt.Fprintf(sb, ", built from '%s'", opts.Template)
+	}
+	fmt.Fprintln(sb)
+	return nil
+}
+
+// describeDelete lists providers deleted by delete action and the objects deleted along with them
+func (c *ClusterctlExecutor) describeDelete(sb *strings.Builder) {
+	if c.options.DeleteOptions.All {
+		fmt.Fprintf(sb, "Deletes all Cluster API providers from cluster '%s'\n", c.clusterName)
+	} else {
+		fmt.Fprintf(sb, "Deletes Cluster API providers from cluster '%s':\n", c.clusterName)
+		for _, prv := range c.options.Providers {
+			if _, requested := c.requestedVersion(prv); requested {
+				fmt.Fprintf(sb, "  %s %s\n", prv.Type, prv.Name)
+			}
+		}
+	}
+	if c.options.DeleteOptions.IncludeNamespace {
+		fmt.Fprintln(sb, "Provider namespaces are deleted")
+	}
+	if c.options.DeleteOptions.IncludeCRDs {
+		fmt.Fprintln(sb, "Provider CRDs are deleted along with their objects")
+	}
 }
@@ -0,0 +1,450 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/k8s/kstatus"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// providerLabel is set by clusterctl on every component of the provider
+	providerLabel = "cluster.x-k8s.io/provider"
+	// ownerDetail is a key of the status details which shows the cluster owning Cluster API objects
+	ownerDetail = "owner"
+	// clusterNameLabel is set by Cluster API on the objects which belong to the cluster
+	clusterNameLabel = "cluster.x-k8s.io/cluster-name"
+)
+
+var (
+	providerKind   = schema.GroupKind{Group: "clusterctl.cluster.x-k8s.io", Kind: "Provider"}
+	deploymentKind = schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	crdKind        = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+	clusterKind    = schema.GroupKind{Group: "cluster.x-k8s.io", Kind: "Cluster"}
+
+	// capiKinds are checked to find out which cluster owns Cluster API objects after move
+	capiKinds = []schema.GroupKind{
+		clusterKind,
+		{Group: "controlplane.cluster.x-k8s.io", Kind: "KubeadmControlPlane"},
+		{Group: "cluster.x-k8s.io", Kind: "MachineDeployment"},
+		{Group: "cluster.x-k8s.io", Kind: "Machine"},
+	}
+
+	// defaultProviders are installed by clusterctl init if no provider of the type is requested
+	defaultProviders = map[string]string{
+		airshipv1.CoreProviderType:         "cluster-api",
+		airshipv1.BootstrapProviderType:    "kubeadm",
+		airshipv1.ControlPlaneProviderType: "kubeadm",
+	}
+)
+
+// Status returns status of the provider components installed by init or upgrade action, shows which
+// cluster owns Cluster API objects for move action, reports deleted providers for delete action and
+// Cluster API objects of the cluster for describe action. Nothing is checked for config-cluster action since
+// it doesn't change the cluster
+func (c *ClusterctlExecutor) Status() (ifc.ExecutorStatus, error) {
+	switch c.options.Action {
+	case airshipv1.Init:
+		return c.initStatus(func(prv *airshipv1.Provider) string {
+			version, _ := c.requestedVersion(prv)
+			return version
+		})
+	case airshipv1.Upgrade:
+		return c.initStatus(c.repositoryVersion)
+	case airshipv1.Move:
+		return c.moveStatus()
+	case airshipv1.Delete:
+		return c.deleteStatus()
+	case airshipv1.Describe:
+		return c.describeStatus()
+	case airshipv1.ConfigCluster:
+		return ifc.ExecutorStatus{}, nil
+	default:
+		return ifc.ExecutorStatus{}, errors.ErrUnknownExecutorAction{
+			Action:       string(c.options.Action),
+			ExecutorName: Clusterctl,
+		}
+	}
+}
+
+// initStatus checks that Provider objects, Deployments and CRDs of every requested provider are present
+// in the target cluster and that providers are installed at the versions returned by version function
+func (c *ClusterctlExecutor) initStatus(version func(*airshipv1.Provider) string) (ifc.ExecutorStatus, error) {
+	kubeconfig, cleanup, err := c.kubecfg.GetFile()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	defer cleanup()
+
+	client, mapper, err := c.clusterClient(kubeconfig, c.clusterName)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	installed, err := utils.ListObjects(client, mapper, providerKind, "", "")
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	sts := ifc.ExecutorStatus{}
+	for _, prv := range c.options.Providers {
+		if _, requested := c.requestedVersion(prv); !requested {
+			continue
+		}
+		label := providerLabelValue(prv)
+		sts.Resources = append(sts.Resources, providerStatus(installed, prv, label, version(prv)))
+		for _, gk := range []schema.GroupKind{deploymentKind, crdKind} {
+			components, listErr := componentStatus(client, mapper, gk, label)
+			if listErr != nil {
+				return ifc.ExecutorStatus{}, listErr
+			}
+			sts.Resources = append(sts.Resources, components...)
+		}
+	}
+	summarizeStates(&sts)
+	return sts, nil
+}
+
+// deleteStatus reports whether Provider objects and Deployments of the deleted providers are removed from
+// the target cluster, providers being absent is the desired state
+func (c *ClusterctlExecutor) deleteStatus() (ifc.ExecutorStatus, error) {
+	kubeconfig, cleanup, err := c.kubecfg.GetFile()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	defer cleanup()
+
+	client, mapper, err := c.clusterClient(kubeconfig, c.clusterName)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	deleted := map[string]bool{}
+	for _, prv := range c.options.Providers {
+		if _, requested := c.requestedVersion(prv); requested {
+			deleted[providerLabelValue(prv)] = true
+		}
+	}
+
+	providers, err := utils.ListObjects(client, mapper, providerKind, "", "")
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	deployments, err := utils.ListObjects(client, mapper, deploymentKind, "", providerLabel)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	sts := ifc.ExecutorStatus{}
+	for _, obj := range append(providers, deployments...) {
+		label := obj.GetLabels()[providerLabel]
+		if obj.GetKind() == providerKind.Kind {
+			name, _, _ := unstructured.NestedString(obj.Object, "providerName")
+			prvType, _, _ := unstructured.NestedString(obj.Object, "type")
+			label = providerLabelValue(&airshipv1.Provider{Name: name, Type: prvType})
+		}
+		if !c.options.DeleteOptions.All && !deleted[label] {
+			continue
+		}
+		sts.Resources = append(sts.Resources, airshipv1.ResourceStatus{
+			Group:     obj.GroupVersionKind().Group,
+			Kind:      obj.GetKind(),
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Exists:    true,
+			State:     airshipv1.StatusInProgress,
+			Message:   "Resource is not deleted",
+		})
+	}
+
+	sts.State = airshipv1.StatusCurrent
+	sts.Message = "Providers are deleted"
+	if len(sts.Resources) > 0 {
+		sts.State = airshipv1.StatusInProgress
+		sts.Message = fmt.Sprintf("%d provider resources are not deleted", len(sts.Resources))
+	}
+	return sts, nil
+}
+
+// describeStatus returns status of the Cluster API cluster and the objects which belong to it
+func (c *ClusterctlExecutor) describeStatus() (ifc.ExecutorStatus, error) {
+	kubeconfig, cleanup, err := c.kubecfg.GetFile()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	defer cleanup()
+
+	client, mapper, err := c.clusterClient(kubeconfig, c.clusterName)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	name, namespace := c.describedCluster()
+	sts := ifc.ExecutorStatus{Details: map[string]string{"cluster": name}}
+	for _, gk := range capiKinds {
+		// the cluster itself is selected by name, the rest of the objects by the cluster name label
+		labelSelector := fmt.Sprintf("%s=%s", clusterNameLabel, name)
+		if gk == clusterKind {
+			labelSelector = ""
+		}
+		objs, listErr := utils.ListObjects(client, mapper, gk, namespace, labelSelector)
+		if listErr != nil {
+			return ifc.ExecutorStatus{}, listErr
+		}
+		for j := range objs {
+			live := &objs[j]
+			if gk == clusterKind && live.GetName() != name {
+				continue
+			}
+			kres := kstatus.Compute(live)
+			sts.Resources = append(sts.Resources, airshipv1.ResourceStatus{
+				Group:      gk.Group,
+				Kind:       gk.Kind,
+				Namespace:  live.GetNamespace(),
+				Name:       live.GetName(),
+				Exists:     true,
+				State:      airshipv1.StatusState(kres.Status),
+				Message:    kres.Message,
+				Conditions: resourceConditions(live),
+			})
+		}
+	}
+	summarizeStates(&sts)
+	if sts.State == airshipv1.StatusNotFound {
+		sts.Message = fmt.Sprintf("Cluster '%s' is not found", name)
+	}
+	return sts, nil
+}
+
+// moveStatus reports whether Cluster API objects from the move namespace are present in the target
+// cluster and in its parent cluster
+func (c *ClusterctlExecutor) moveStatus() (ifc.ExecutorStatus, error) {
+	kubeconfig, cleanup, err := c.kubecfg.GetFile()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	defer cleanup()
+
+	parent, err := c.clusterMap.ParentCluster(c.clusterName)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	namespace := c.options.MoveOptions.Namespace
+	onTarget, err := c.capiObjects(kubeconfig, c.clusterName, namespace)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	onParent, err := c.capiObjects(kubeconfig, parent, namespace)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	keys := append([]objectKey{}, onTarget...)
+	targetKeys := make(map[objectKey]bool, len(onTarget))
+	for _, key := range onTarget {
+		targetKeys[key] = true
+	}
+	parentKeys := make(map[objectKey]bool, len(onParent))
+	for _, key := range onParent {
+		parentKeys[key] = true
+		if !targetKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	sts := ifc.ExecutorStatus{
+		Details: map[string]string{
+			"parentCluster": parent,
+			"targetCluster": c.clusterName,
+		},
+	}
+	for _, key := range keys {
+		rs := airshipv1.ResourceStatus{
+			Group:     key.Group,
+			Kind:      key.Kind,
+			Namespace: key.Namespace,
+			Name:      key.Name,
+			Exists:    targetKeys[key],
+		}
+		switch {
+		case targetKeys[key] && parentKeys[key]:
+			rs.State = airshipv1.StatusInProgress
+			rs.Message = fmt.Sprintf("Resource exists in both '%s' and '%s' clusters", c.clusterName, parent)
+		case targetKeys[key]:
+			rs.State = airshipv1.StatusCurrent
+			rs.Message = fmt.Sprintf("Resource is moved to cluster '%s'", c.clusterName)
+		default:
+			rs.State = airshipv1.StatusNotFound
+			rs.Message = fmt.Sprintf("Resource is not moved from cluster '%s'", parent)
+		}
+		sts.Resources = append(sts.Resources, rs)
+	}
+
+	switch {
+	case len(onTarget) > 0 && len(onParent) > 0:
+		sts.Details[ownerDetail] = fmt.Sprintf("%s,%s", c.clusterName, parent)
+	case len(onTarget) > 0:
+		sts.Details[ownerDetail] = c.clusterName
+	case len(onParent) > 0:
+		sts.Details[ownerDetail] = parent
+	}
+	summarizeStates(&sts)
+	if sts.State == airshipv1.StatusNotFound {
+		sts.Message = fmt.Sprintf("Resources are not moved to cluster '%s'", c.clusterName)
+	}
+	return sts, nil
+}
+
+// clusterClient returns dynamic client of the cluster from the cluster map
+func (c *ClusterctlExecutor) clusterClient(kubeconfig, clusterName string) (dynamic.Interface,
+	meta.RESTMapper, error) {
+	kctx, err := c.clusterMap.ClusterKubeconfigContext(clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.dynamicClientFunc(kubeconfig, kctx)
+}
+
+// capiObjects returns references to Cluster API objects of the cluster
+func (c *ClusterctlExecutor) capiObjects(kubeconfig, clusterName, namespace string) ([]objectKey, error) {
+	client, mapper, err := c.clusterClient(kubeconfig, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []objectKey
+	for _, gk := range capiKinds {
+		objs, listErr := utils.ListObjects(client, mapper, gk, namespace, "")
+		if listErr != nil {
+			return nil, listErr
+		}
+		for _, obj := range objs {
+			keys = append(keys, objectKey{
+				Group:     gk.Group,
+				Kind:      gk.Kind,
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+			})
+		}
+	}
+	return keys, nil
+}
+
+// requestedVersion returns version of the provider requested by init options, provider is requested if it's
+// listed in init options or if it's installed by clusterctl by default and no providers of its type are listed
+func (c *ClusterctlExecutor) requestedVersion(prv *airshipv1.Provider) (string, bool) {
+	requested := c.initProviders()[prv.Type]
+	if requested == "" {
+		return "", defaultProviders[prv.Type] == prv.Name
+	}
+	for _, entry := range strings.Split(requested, ",") {
+		name, version := strings.TrimSpace(entry), ""
+		if i := strings.Index(name, ":"); i != -1 {
+			name, version = name[:i], name[i+1:]
+		}
+		if name == prv.Name {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// providerLabelValue returns value of the label clusterctl sets on provider components,
+// e.g. cluster-api, bootstrap-kubeadm or infrastructure-metal3
+func providerLabelValue(prv *airshipv1.Provider) string {
+	if prv.Type == airshipv1.CoreProviderType {
+		return prv.Name
+	}
+	return fmt.Sprintf("%s-%s", typeMap[prv.Type], prv.Name)
+}
+
+// providerStatus returns status of the clusterctl Provider object, version of the provider is checked
+// only if it's requested explicitly
+func providerStatus(installed []unstructured.Unstructured, prv *airshipv1.Provider,
+	label, version string) airshipv1.ResourceStatus {
+	rs := airshipv1.ResourceStatus{
+		Group:   providerKind.Group,
+		Kind:    providerKind.Kind,
+		Name:    label,
+		State:   airshipv1.StatusNotFound,
+		Message: "Provider is not installed",
+	}
+	for _, obj := range installed {
+		name, _, _ := unstructured.NestedString(obj.Object, "providerName")
+		prvType, _, _ := unstructured.NestedString(obj.Object, "type")
+		if name != prv.Name || prvType != prv.Type {
+			continue
+		}
+
+		actual, _, _ := unstructured.NestedString(obj.Object, "version")
+		rs.Namespace, rs.Name, rs.Exists = obj.GetNamespace(), obj.GetName(), true
+		rs.State = airshipv1.StatusCurrent
+		rs.Message = fmt.Sprintf("Provider version %s is installed", actual)
+		if version != "" && actual != version {
+			rs.State = airshipv1.StatusInProgress
+			rs.Message = fmt.Sprintf("Provider version %s is installed, expected %s", actual, version)
+		}
+		break
+	}
+	return rs
+}
+
+// componentStatus returns status of the provider components of the given kind, single not found
+// status is returned if the provider has no such components
+func componentStatus(client dynamic.Interface, mapper meta.RESTMapper, gk schema.GroupKind,
+	label string) ([]airshipv1.ResourceStatus, error) {
+	objs, err := utils.ListObjects(client, mapper, gk, "", fmt.Sprintf("%s=%s", providerLabel, label))
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return []airshipv1.ResourceStatus{{
+			Group:   gk.Group,
+			Kind:    gk.Kind,
+			Name:    label,
+			State:   airshipv1.StatusNotFound,
+			Message: fmt.Sprintf("No %s resources found for provider", gk.Kind),
+		}}, nil
+	}
+
+	res := make([]airshipv1.ResourceStatus, 0, len(objs))
+	for i := range objs {
+		live := &objs[i]
+		kres := kstatus.Compute(live)
+		res = append(res, airshipv1.ResourceStatus{
+			Group:      gk.Group,
+			Kind:       gk.Kind,
+			Namespace:  live.GetNamespace(),
+			Name:       live.GetName(),
+			Exists:     true,
+			State:      airshipv1.StatusState(kres.Status),
+			Message:    kres.Message,
+			Conditions: resourceConditions(live),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,515 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors_test
+
+import (
+	goerrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	capiProviderTmpl = `apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3
+kind: Provider
+metadata:
+  name: cluster-api
+  namespace: capi-system
+providerName: cluster-api
+type: CoreProvider
+version: %s
+`
+	capiDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: capi-controller-manager
+  namespace: capi-system
+  labels:
+    cluster.x-k8s.io/provider: cluster-api
+spec:
+  replicas: 1
+status:
+  replicas: 1
+  updatedReplicas: 1
+  readyReplicas: 1
+  availableReplicas: 1
+`
+	kubeadmDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: capi-kubeadm-bootstrap-controller-manager
+  namespace: capi-kubeadm-bootstrap-system
+  labels:
+    cluster.x-k8s.io/provider: bootstrap-kubeadm
+spec:
+  replicas: 1
+`
+	capiCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: clusters.cluster.x-k8s.io
+  labels:
+    cluster.x-k8s.io/provider: cluster-api
+status:
+  conditions:
+  - type: Established
+    status: "True"
+`
+	capiClusterTmpl = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Cluster
+metadata:
+  name: %s
+  namespace: %s
+`
+	capiMachine = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Machine
+metadata:
+  name: workload-cp-0
+  namespace: some-namespace
+`
+	targetMachine = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Machine
+metadata:
+  name: target-cp-0
+  namespace: some-namespace
+  labels:
+    cluster.x-k8s.io/cluster-name: target
+`
+)
+
+// testClusterClientFunc returns dynamic client with objects of the kubeconfig context
+func testClusterClientFunc(t *testing.T, objsByContext map[string][]string) utils.DynamicClientFunc {
+	gvrs := map[schema.GroupVersionResource]string{
+		{Group: "clusterctl.cluster.x-k8s.io", Version: "v1alpha3", Resource: "providers"}: "ProviderList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}:                            "DeploymentList",
+		{Group: "apiextensions.k8s.io", Version: "v1",
+			Resource: "customresourcedefinitions"}: "CustomResourceDefinitionList",
+		{Group: "cluster.x-k8s.io", Version: "v1alpha3", Resource: "clusters"}: "ClusterList",
+		{Group: "cluster.x-k8s.io", Version: "v1alpha3", Resource: "machines"}: "MachineList",
+		{Version: "v1", Resource: "secrets"}:                                   "SecretList",
+	}
+	gvs := []schema.GroupVersion{}
+	for gvr := range gvrs {
+		gvs = append(gvs, gvr.GroupVersion())
+	}
+	mapper := meta.NewDefaultRESTMapper(gvs)
+	for gvr, listKind := range gvrs {
+		scope := meta.RESTScopeNamespace
+		if gvr.Resource == "customresourcedefinitions" {
+			scope = meta.RESTScopeRoot
+		}
+		mapper.Add(gvr.GroupVersion().WithKind(listKind[:len(listKind)-len("List")]), scope)
+	}
+
+	return func(_, kctx string) (dynamic.Interface, meta.RESTMapper, error) {
+		scheme := runtime.NewScheme()
+		runtimeObjs := []runtime.Object{}
+		for gvr, listKind := range gvrs {
+			scheme.AddKnownTypeWithName(gvr.GroupVersion().WithKind(listKind), &unstructured.UnstructuredList{})
+		}
+		for _, obj := range objsByContext[kctx] {
+			m := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal([]byte(obj), &m))
+			runtimeObjs = append(runtimeObjs, &unstructured.Unstructured{Object: m})
+		}
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, gvrs, runtimeObjs...), mapper, nil
+	}
+}
+
+func TestClusterctlExecutorStatus(t *testing.T) {
+	errParent := goerrors.New("parent cluster error")
+	clusterMap := ClusterMapMockInterface{
+		MockClusterKubeconfigContext: func(s string) (string, error) { return s, nil },
+		MockParentCluster:            func(string) (string, error) { return "ephemeral", nil },
+	}
+	notFoundComponents := []v1alpha1.ResourceStatus{
+		{
+			Group:   "apps",
+			Kind:    "Deployment",
+			Name:    "cluster-api",
+			State:   v1alpha1.StatusNotFound,
+			Message: "No Deployment resources found for provider",
+		},
+		{
+			Group:   "apiextensions.k8s.io",
+			Kind:    "CustomResourceDefinition",
+			Name:    "cluster-api",
+			State:   v1alpha1.StatusNotFound,
+			Message: "No CustomResourceDefinition resources found for provider",
+		},
+	}
+	installedComponents := []v1alpha1.ResourceStatus{
+		{
+			Group:     "apps",
+			Kind:      "Deployment",
+			Namespace: "capi-system",
+			Name:      "capi-controller-manager",
+			Exists:    true,
+			State:     v1alpha1.StatusCurrent,
+			Message:   "Deployment is available. Replicas: 1",
+		},
+		{
+			Group:      "apiextensions.k8s.io",
+			Kind:       "CustomResourceDefinition",
+			Name:       "clusters.cluster.x-k8s.io",
+			Exists:     true,
+			State:      v1alpha1.StatusCurrent,
+			Message:    "CRD is established",
+			Conditions: []v1alpha1.StatusCondition{{Type: "Established", Status: "True"}},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		action            string
+		clusterMap        clustermap.ClusterMap
+		dynamicClientFunc utils.DynamicClientFunc
+		containsErr       string
+		expectedState     v1alpha1.StatusState
+		expectedMessage   string
+		expectedResources []v1alpha1.ResourceStatus
+		expectedDetails   map[string]string
+	}{
+		{
+			name:        "unknown action",
+			action:      "someAction",
+			clusterMap:  clusterMap,
+			containsErr: "unknown action type 'someAction'",
+		},
+		{
+			name:       "init client error",
+			action:     "init",
+			clusterMap: clusterMap,
+			dynamicClientFunc: func(_, _ string) (dynamic.Interface, meta.RESTMapper, error) {
+				return nil, nil, goerrors.New("client error")
+			},
+			containsErr: "client error",
+		},
+		{
+			name:       "init providers are not installed",
+			action:     "init",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target": {kubeadmDeployment},
+			}),
+			expectedState:   v1alpha1.StatusNotFound,
+			expectedMessage: "Resources are not deployed",
+			expectedResources: append([]v1alpha1.ResourceStatus{
+				{
+					Group:   "clusterctl.cluster.x-k8s.io",
+					Kind:    "Provider",
+					Name:    "cluster-api",
+					State:   v1alpha1.StatusNotFound,
+					Message: "Provider is not installed",
+				},
+			}, notFoundComponents...),
+		},
+		{
+			name:       "init providers are installed",
+			action:     "init",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target": {fmt.Sprintf(capiProviderTmpl, "v0.3.2"), capiDeployment, kubeadmDeployment, capiCRD},
+			}),
+			expectedState:   v1alpha1.StatusCurrent,
+			expectedMessage: "All 3 resources are current",
+			expectedResources: append([]v1alpha1.ResourceStatus{
+				{
+					Group:     "clusterctl.cluster.x-k8s.io",
+					Kind:      "Provider",
+					Namespace: "capi-system",
+					Name:      "cluster-api",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Provider version v0.3.2 is installed",
+				},
+			}, installedComponents...),
+		},
+		{
+			name:       "init provider version mismatch",
+			action:     "init",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target": {fmt.Sprintf(capiProviderTmpl, "v0.3.1"), capiDeployment, capiCRD},
+			}),
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "2 of 3 resources are current",
+			expectedResources: append([]v1alpha1.ResourceStatus{
+				{
+					Group:     "clusterctl.cluster.x-k8s.io",
+					Kind:      "Provider",
+					Namespace: "capi-system",
+					Name:      "cluster-api",
+					Exists:    true,
+					State:     v1alpha1.StatusInProgress,
+					Message:   "Provider version v0.3.1 is installed, expected v0.3.2",
+				},
+			}, installedComponents...),
+		},
+		{
+			name:       "upgrade provider is not upgraded",
+			action:     "upgrade",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target": {fmt.Sprintf(capiProviderTmpl, "v0.3.1"), capiDeployment, capiCRD},
+			}),
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "2 of 3 resources are current",
+			expectedResources: append([]v1alpha1.ResourceStatus{
+				{
+					Group:     "clusterctl.cluster.x-k8s.io",
+					Kind:      "Provider",
+					Namespace: "capi-system",
+					Name:      "cluster-api",
+					Exists:    true,
+					State:     v1alpha1.StatusInProgress,
+					Message:   "Provider version v0.3.1 is installed, expected v0.3.2",
+				},
+			}, installedComponents...),
+		},
+		{
+			name:       "delete providers are being deleted",
+			action:     "delete",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target": {fmt.Sprintf(capiProviderTmpl, "v0.3.2"), capiDeployment, kubeadmDeployment},
+			}),
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "2 provider resources are not deleted",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Group:     "clusterctl.cluster.x-k8s.io",
+					Kind:      "Provider",
+					Namespace: "capi-system",
+					Name:      "cluster-api",
+					Exists:    true,
+					State:     v1alpha1.StatusInProgress,
+					Message:   "Resource is not deleted",
+				},
+				{
+					Group:     "apps",
+					Kind:      "Deployment",
+					Namespace: "capi-system",
+					Name:      "capi-controller-manager",
+					Exists:    true,
+					State:     v1alpha1.StatusInProgress,
+					Message:   "Resource is not deleted",
+				},
+			},
+		},
+		{
+			name:       "delete providers are deleted",
+			action:     "delete",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target": {kubeadmDeployment},
+			}),
+			expectedState:   v1alpha1.StatusCurrent,
+			expectedMessage: "Providers are deleted",
+		},
+		{
+			name:       "describe cluster",
+			action:     "describe",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target": {fmt.Sprintf(capiClusterTmpl, "target", "some-namespace"),
+					fmt.Sprintf(capiClusterTmpl, "workload", "some-namespace"), capiMachine, targetMachine},
+			}),
+			expectedState:   v1alpha1.StatusCurrent,
+			expectedMessage: "All 2 resources are current",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Group:     "cluster.x-k8s.io",
+					Kind:      "Cluster",
+					Namespace: "some-namespace",
+					Name:      "target",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Resource is current",
+				},
+				{
+					Group:     "cluster.x-k8s.io",
+					Kind:      "Machine",
+					Namespace: "some-namespace",
+					Name:      "target-cp-0",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Resource is current",
+				},
+			},
+			expectedDetails: map[string]string{"cluster": "target"},
+		},
+		{
+			name:              "describe cluster is not found",
+			action:            "describe",
+			clusterMap:        clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, nil),
+			expectedState:     v1alpha1.StatusNotFound,
+			expectedMessage:   "Cluster 'target' is not found",
+			expectedDetails:   map[string]string{"cluster": "target"},
+		},
+		{
+			name:   "move parent cluster error",
+			action: "move",
+			clusterMap: ClusterMapMockInterface{
+				MockParentCluster: func(string) (string, error) { return "", errParent },
+			},
+			dynamicClientFunc: testClusterClientFunc(t, nil),
+			containsErr:       "parent cluster error",
+		},
+		{
+			name:       "move is completed",
+			action:     "move",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target":    {fmt.Sprintf(capiClusterTmpl, "workload", "some-namespace"), capiMachine},
+				"ephemeral": {fmt.Sprintf(capiClusterTmpl, "other", "other-namespace")},
+			}),
+			expectedState:   v1alpha1.StatusCurrent,
+			expectedMessage: "All 2 resources are current",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Group:     "cluster.x-k8s.io",
+					Kind:      "Cluster",
+					Namespace: "some-namespace",
+					Name:      "workload",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Resource is moved to cluster 'target'",
+				},
+				{
+					Group:     "cluster.x-k8s.io",
+					Kind:      "Machine",
+					Namespace: "some-namespace",
+					Name:      "workload-cp-0",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Resource is moved to cluster 'target'",
+				},
+			},
+			expectedDetails: map[string]string{
+				"owner":         "target",
+				"parentCluster": "ephemeral",
+				"targetCluster": "target",
+			},
+		},
+		{
+			name:       "move is not started",
+			action:     "move",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"ephemeral": {fmt.Sprintf(capiClusterTmpl, "workload", "some-namespace")},
+			}),
+			expectedState:   v1alpha1.StatusNotFound,
+			expectedMessage: "Resources are not moved to cluster 'target'",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Group:     "cluster.x-k8s.io",
+					Kind:      "Cluster",
+					Namespace: "some-namespace",
+					Name:      "workload",
+					State:     v1alpha1.StatusNotFound,
+					Message:   "Resource is not moved from cluster 'ephemeral'",
+				},
+			},
+			expectedDetails: map[string]string{
+				"owner":         "ephemeral",
+				"parentCluster": "ephemeral",
+				"targetCluster": "target",
+			},
+		},
+		{
+			name:       "move is interrupted",
+			action:     "move",
+			clusterMap: clusterMap,
+			dynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+				"target":    {fmt.Sprintf(capiClusterTmpl, "workload", "some-namespace")},
+				"ephemeral": {fmt.Sprintf(capiClusterTmpl, "workload", "some-namespace"), capiMachine},
+			}),
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "0 of 2 resources are current",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Group:     "cluster.x-k8s.io",
+					Kind:      "Cluster",
+					Namespace: "some-namespace",
+					Name:      "workload",
+					Exists:    true,
+					State:     v1alpha1.StatusInProgress,
+					Message:   "Resource exists in both 'target' and 'ephemeral' clusters",
+				},
+				{
+					Group:     "cluster.x-k8s.io",
+					Kind:      "Machine",
+					Namespace: "some-namespace",
+					Name:      "workload-cp-0",
+					State:     v1alpha1.StatusNotFound,
+					Message:   "Resource is not moved from cluster 'ephemeral'",
+				},
+			},
+			expectedDetails: map[string]string{
+				"owner":         "target,ephemeral",
+				"parentCluster": "ephemeral",
+				"targetCluster": "target",
+			},
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			executor, err := executors.NewClusterctlExecutor(
+				ifc.ExecutorConfig{
+					TargetPath:        "testdata",
+					PhaseConfigBundle: executorBundle(t, krmExecDoc),
+					ExecutorDocument:  executorDoc(t, fmt.Sprintf(executorConfigTmplGood, tt.action)),
+					KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+						return "kubeconfig", func() {}, nil
+					}},
+					ClusterMap:        tt.clusterMap,
+					ClusterName:       "target",
+					DynamicClientFunc: tt.dynamicClientFunc,
+				})
+			require.NoError(t, err)
+
+			sts, err := executor.Status()
+			if tt.containsErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedState, sts.State)
+			assert.Equal(t, tt.expectedMessage, sts.Message)
+			assert.Equal(t, tt.expectedResources, sts.Resources)
+			assert.Equal(t, tt.expectedDetails, sts.Details)
+		})
+	}
+}
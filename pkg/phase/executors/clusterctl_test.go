@@ -19,10 +19,12 @@ import (
 	goerrors "errors"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
@@ -72,6 +74,34 @@ providers:
     type: "CoreProvider"
     url: functions/capi/v0.3.2`
 
+	executorConfigTmplConfigCluster = `
+apiVersion: airshipit.org/v1alpha1
+kind: Clusterctl
+metadata:
+  name: clusterctl-v1
+action: config-cluster
+init-options:
+  core-provider: "cluster-api:v0.3.2"
+  infrastructure-providers: "metal3:v0.3.2"
+config-cluster-options:
+  template: functions/cluster-template
+  %s
+providers:
+  - name: "cluster-api"
+    type: "CoreProvider"
+    url: functions/capi/v0.3.2
+  - name: "metal3"
+    type: "InfrastructureProvider"
+    url: functions/capi/v0.3.2`
+
+	renderedTemplate = `---
+apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Cluster
+metadata:
+  name: ${CLUSTER_NAME}
+  namespace: ${NAMESPACE}
+...
+`
 	renderedDocs = `---
 apiVersion: v1
 kind: Namespace
@@ -365,6 +395,91 @@ func TestClusterctlExecutorRun(t *testing.T) {
 	}
 }
 
+func TestClusterctlExecutorRunCommand(t *testing.T) {
+	installed := []string{fmt.Sprintf(capiProviderTmpl, "v0.3.1")}
+	testCases := []struct {
+		name        string
+		action      string
+		dryRun      bool
+		installed   []string
+		expectedCmd []string
+		expectedErr error
+	}{
+		{
+			name:      "upgrade",
+			action:    "upgrade",
+			installed: installed,
+			expectedCmd: []string{"upgrade", "apply", "--core", "capi-system/cluster-api:v0.3.2",
+				"--kubeconfig", "kubeconfig", "--kubeconfig-context", "target-cluster"},
+		},
+		{
+			name:   "upgrade provider is not installed",
+			action: "upgrade",
+			expectedErr: errors.ErrProviderNotInstalled{
+				Name:        "cluster-api",
+				Type:        "CoreProvider",
+				ClusterName: "target-cluster",
+			},
+		},
+		{
+			name:   "upgrade dry run",
+			action: "upgrade",
+			dryRun: true,
+			expectedCmd: []string{"upgrade", "plan",
+				"--kubeconfig", "kubeconfig", "--kubeconfig-context", "target-cluster"},
+		},
+		{
+			name:   "delete",
+			action: "delete",
+			expectedCmd: []string{"delete", "--kubeconfig", "kubeconfig", "--kubeconfig-context", "target-cluster",
+				"--core", "cluster-api"},
+		},
+		{
+			name:   "delete dry run",
+			action: "delete",
+			dryRun: true,
+		},
+		{
+			name:   "describe",
+			action: "describe",
+			expectedCmd: []string{"describe", "cluster", "target-cluster",
+				"--kubeconfig", "kubeconfig", "--kubeconfig-context", "target-cluster", "--show-conditions", "all"},
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			var actualCmd []string
+			executor, err := executors.NewClusterctlExecutor(
+				ifc.ExecutorConfig{
+					ClusterName:       "target-cluster",
+					TargetPath:        "testdata",
+					PhaseConfigBundle: executorBundle(t, krmExecDoc),
+					ExecutorDocument:  executorDoc(t, fmt.Sprintf(executorConfigTmplGood, tt.action)),
+					KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+						return "kubeconfig", func() {}, nil
+					}},
+					ClusterMap: ClusterMapMockInterface{MockClusterKubeconfigContext: func(s string) (string, error) {
+						return s, nil
+					}},
+					ContainerFunc: func(_ string, _ io.Reader, _ io.Writer,
+						conf *v1alpha1.GenericContainer, _ string) container.ClientV1Alpha1 {
+						return MockClientFuncInterface{MockRun: func() error {
+							opts := &v1alpha1.ClusterctlOptions{}
+							require.NoError(t, yaml.Unmarshal([]byte(conf.Config), opts))
+							actualCmd = opts.CmdOptions
+							return nil
+						}}
+					},
+					DynamicClientFunc: testClusterClientFunc(t, map[string][]string{"target-cluster": tt.installed}),
+				})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedErr, executor.Run(ifc.RunOptions{DryRun: tt.dryRun}))
+			assert.Equal(t, tt.expectedCmd, actualCmd)
+		})
+	}
+}
+
 func TestClusterctlExecutorValidate(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -378,6 +493,36 @@ func TestClusterctlExecutorValidate(t *testing.T) {
 			actionType:         "init",
 			executorConfigTmpl: executorConfigTmplGood,
 		},
+		{
+			name:               "Success upgrade action",
+			actionType:         "upgrade",
+			executorConfigTmpl: executorConfigTmplGood,
+		},
+		{
+			name:       "Success upgrade action with version of override tree",
+			actionType: "upgrade",
+			executorConfigTmpl: strings.Replace(executorConfigTmplGood, `core-provider: "cluster-api:v0.3.2"`,
+				`core-provider: "cluster-api"`, 1),
+		},
+		{
+			name:       "Error upgrade version is not defined",
+			actionType: "upgrade",
+			executorConfigTmpl: strings.Replace(strings.Replace(executorConfigTmplGood,
+				`core-provider: "cluster-api:v0.3.2"`, `core-provider: "cluster-api"`, 1),
+				"url: functions/capi/v0.3.2", "url: https://example.com/core-components.yaml", 1),
+			expectedErrString: "version to upgrade CoreProvider cluster-api to is not defined",
+		},
+		{
+			name:               "Success delete action",
+			actionType:         "delete",
+			executorConfigTmpl: executorConfigTmplGood,
+		},
+		{
+			name:               "Error config-cluster infrastructure provider is not requested",
+			actionType:         "config-cluster",
+			executorConfigTmpl: executorConfigTmplGood,
+			expectedErrString:  "config-cluster requires infrastructure provider in init options",
+		},
 		{
 			name:               "Error any other action",
 			actionType:         "any",
@@ -413,6 +558,154 @@ func TestClusterctlExecutorValidate(t *testing.T) {
 	}
 }
 
+func TestClusterctlExecutorDescribe(t *testing.T) {
+	testCases := []struct {
+		name                string
+		actionType          string
+		clusterMap          clustermap.ClusterMap
+		expectedDescription string
+		expectedErrString   string
+	}{
+		{
+			name:       "Success init action",
+			actionType: "init",
+			expectedDescription: "Initializes Cluster API providers in cluster 'target-cluster':\n" +
+				"  CoreProvider cluster-api (v0.3.2) from " +
+				"'/workdir/.cluster-api/overrides/cluster-api/v0.3.2/core-components.yaml'\n",
+		},
+		{
+			name:       "Success upgrade action",
+			actionType: "upgrade",
+			expectedDescription: "Upgrades Cluster API providers in cluster 'target-cluster':\n" +
+				"  CoreProvider cluster-api (v0.3.2) from " +
+				"'/workdir/.cluster-api/overrides/cluster-api/v0.3.2/core-components.yaml'\n",
+		},
+		{
+			name:       "Success delete action",
+			actionType: "delete",
+			expectedDescription: "Deletes Cluster API providers from cluster 'target-cluster':\n" +
+				"  CoreProvider cluster-api\n",
+		},
+		{
+			name:       "Success describe action",
+			actionType: "describe",
+			expectedDescription: "Shows Cluster API objects of cluster 'target-cluster' " +
+				"managed by cluster 'target-cluster'\n",
+		},
+		{
+			name:       "Success move action",
+			actionType: "move",
+			clusterMap: ClusterMapMockInterface{MockParentCluster: func(s string) (string, error) {
+				return "ephemeral-cluster", nil
+			}},
+			expectedDescription: "Moves Cluster API objects from cluster 'ephemeral-cluster' to cluster " +
+				"'target-cluster', namespace 'some-namespace'\n",
+		},
+		{
+			name:       "Error get parent cluster",
+			actionType: "move",
+			clusterMap: ClusterMapMockInterface{MockParentCluster: func(s string) (string, error) {
+				return "", goerrors.New("parent cluster error")
+			}},
+			expectedErrString: "parent cluster error",
+		},
+		{
+			name:              "Error unknown action",
+			actionType:        "any",
+			expectedErrString: "unknown action type 'any'",
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			executor, err := executors.NewClusterctlExecutor(
+				ifc.ExecutorConfig{
+					ClusterName:       "target-cluster",
+					ClusterMap:        tt.clusterMap,
+					TargetPath:        "testdata",
+					ExecutorDocument:  executorDoc(t, fmt.Sprintf(executorConfigTmplGood, tt.actionType)),
+					PhaseConfigBundle: executorBundle(t, krmExecDoc),
+				})
+			require.NoError(t, err)
+			description, err := executor.Describe()
+			if tt.expectedErrString != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrString)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedDescription, description)
+			}
+		})
+	}
+}
+
+func TestClusterctlExecutorConfigCluster(t *testing.T) {
+	testCases := []struct {
+		name                string
+		options             string
+		expectedCmd         []string
+		expectedTemplate    string
+		expectedDescription string
+	}{
+		{
+			name:    "default flavor",
+			options: "cluster: workload-cluster",
+			expectedCmd: []string{"config", "cluster", "workload-cluster", "--infrastructure", "metal3:v0.3.2",
+				"--kubeconfig", "kubeconfig", "--kubeconfig-context", "target-cluster"},
+			expectedTemplate: "/workdir/.cluster-api/overrides/infrastructure-metal3/v0.3.2/cluster-template.yaml",
+			expectedDescription: "Prints Cluster API cluster 'workload-cluster' rendered from the cluster template " +
+				"of InfrastructureProvider metal3 (v0.3.2), built from 'functions/cluster-template'\n",
+		},
+		{
+			name:    "flavor and machine count",
+			options: "flavor: ha\n  worker-machine-count: 2",
+			expectedCmd: []string{"config", "cluster", "target-cluster", "--infrastructure", "metal3:v0.3.2",
+				"--kubeconfig", "kubeconfig", "--kubeconfig-context", "target-cluster",
+				"--flavor", "ha", "--worker-machine-count", "2"},
+			expectedTemplate: "/workdir/.cluster-api/overrides/infrastructure-metal3/v0.3.2/cluster-template-ha.yaml",
+			expectedDescription: "Prints Cluster API cluster 'target-cluster' rendered from the cluster template " +
+				"of InfrastructureProvider metal3 (v0.3.2), flavor 'ha', built from 'functions/cluster-template'\n",
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			var actualOpts *v1alpha1.ClusterctlOptions
+			executor, err := executors.NewClusterctlExecutor(
+				ifc.ExecutorConfig{
+					ClusterName:       "target-cluster",
+					TargetPath:        "testdata",
+					PhaseConfigBundle: executorBundle(t, krmExecDoc),
+					ExecutorDocument:  executorDoc(t, fmt.Sprintf(executorConfigTmplConfigCluster, tt.options)),
+					KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+						return "kubeconfig", func() {}, nil
+					}},
+					ClusterMap: ClusterMapMockInterface{MockClusterKubeconfigContext: func(s string) (string, error) {
+						return s, nil
+					}},
+					ContainerFunc: func(_ string, _ io.Reader, _ io.Writer,
+						conf *v1alpha1.GenericContainer, _ string) container.ClientV1Alpha1 {
+						return MockClientFuncInterface{MockRun: func() error {
+							actualOpts = &v1alpha1.ClusterctlOptions{}
+							return yaml.Unmarshal([]byte(conf.Config), actualOpts)
+						}}
+					},
+				})
+			require.NoError(t, err)
+			require.NoError(t, executor.Validate())
+
+			description, err := executor.Describe()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDescription, description)
+
+			require.NoError(t, executor.Run(ifc.RunOptions{}))
+			require.NotNil(t, actualOpts)
+			assert.Equal(t, tt.expectedCmd, actualOpts.CmdOptions)
+			assert.Equal(t, renderedTemplate, actualOpts.Components[tt.expectedTemplate])
+		})
+	}
+}
+
 func TestClusterctlExecutorRender(t *testing.T) {
 	sampleCfgDoc := executorDoc(t, fmt.Sprintf(executorConfigTmpl, "init"))
 	executor, err := executors.NewClusterctlExecutor(
@@ -427,3 +720,15 @@ func TestClusterctlExecutorRender(t *testing.T) {
 	assert.Equal(t, renderedDocs, actualOut.String())
 	assert.NoError(t, actualErr)
 }
+
+func TestClusterctlComponents(t *testing.T) {
+	doc := executorDoc(t, fmt.Sprintf(executorConfigTmpl, "init"))
+	components, err := executors.ClusterctlComponents(doc, "testdata")
+	require.NoError(t, err)
+	require.Len(t, components, 2)
+	assert.Contains(t, components, "cluster-api/v0.3.2/metadata.yaml")
+	assert.Equal(t, renderedDocs, components["cluster-api/v0.3.2/core-components.yaml"])
+
+	_, err = executors.ClusterctlComponents(doc, "/not/exist")
+	assert.Error(t, err)
+}
@@ -15,10 +15,12 @@
 package executors
 
 import (
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
-	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 )
 
@@ -29,37 +31,57 @@ const (
 	GenericContainer = "generic-container"
 	Ephemeral        = "ephemeral"
 	BMHManager       = "BaremetalManager"
+	ExecPlugin       = "exec-plugin"
+	Helm             = "helm"
+	Wait             = "wait"
 )
 
-// RegisterExecutor adds executor to phase executor registry
-func RegisterExecutor(executorName string, registry map[schema.GroupVersionKind]ifc.ExecutorFactory) error {
-	var gvks []schema.GroupVersionKind
-	var execObj ifc.ExecutorFactory
-	var err error
+// outputWriter returns writer for the output produced by executor, stdout is used by default
+func outputWriter(opts ifc.RunOptions) io.Writer {
+	if opts.Out != nil {
+		return opts.Out
+	}
+	return os.Stdout
+}
 
-	switch executorName {
-	case Clusterctl:
-		gvks, _, err = airshipv1.Scheme.ObjectKinds(&airshipv1.Clusterctl{})
-		execObj = NewClusterctlExecutor
-	case KubernetesApply:
-		gvks, _, err = airshipv1.Scheme.ObjectKinds(&airshipv1.KubernetesApply{})
-		execObj = NewKubeApplierExecutor
-	case GenericContainer:
-		gvks, _, err = airshipv1.Scheme.ObjectKinds(airshipv1.DefaultGenericContainer())
-		execObj = NewContainerExecutor
-	case Ephemeral:
-		gvks, _, err = airshipv1.Scheme.ObjectKinds(airshipv1.DefaultBootConfiguration())
-		execObj = NewEphemeralExecutor
-	case BMHManager:
-		gvks, _, err = airshipv1.Scheme.ObjectKinds(&airshipv1.BaremetalManager{})
-		execObj = NewBaremetalExecutor
+// summarizeStates sets overall state of the phase and its last transition time according to the resource statuses
+func summarizeStates(sts *ifc.ExecutorStatus) {
+	states := map[airshipv1.StatusState]int{}
+	for _, rs := range sts.Resources {
+		states[rs.State]++
+		for _, c := range rs.Conditions {
+			if c.LastTransitionTime != nil &&
+				(sts.LastTransitionTime == nil || sts.LastTransitionTime.Before(c.LastTransitionTime)) {
+				sts.LastTransitionTime = c.LastTransitionTime.DeepCopy()
+			}
+		}
+	}
+
+	total := len(sts.Resources)
+	switch {
+	case states[airshipv1.StatusFailed] > 0:
+		sts.State = airshipv1.StatusFailed
+		sts.Message = fmt.Sprintf("%d of %d resources failed", states[airshipv1.StatusFailed], total)
+	case total == 0 || states[airshipv1.StatusNotFound] == total:
+		sts.State = airshipv1.StatusNotFound
+		sts.Message = "Resources are not deployed"
+	case states[airshipv1.StatusUnknown] > 0:
+		sts.State = airshipv1.StatusUnknown
+		sts.Message = fmt.Sprintf("Status of %d of %d resources is unknown", states[airshipv1.StatusUnknown], total)
+	case states[airshipv1.StatusCurrent] == total:
+		sts.State = airshipv1.StatusCurrent
+		sts.Message = fmt.Sprintf("All %d resources are current", total)
 	default:
-		return errors.ErrUnknownExecutorName{ExecutorName: executorName}
+		sts.State = airshipv1.StatusInProgress
+		sts.Message = fmt.Sprintf("%d of %d resources are current", states[airshipv1.StatusCurrent], total)
 	}
+}
 
-	if err != nil {
-		return err
+// indent prefixes every line of the text, trailing new line is added if it's missing
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i := range lines {
+		lines[i] = prefix + lines[i]
 	}
-	registry[gvks[0]] = execObj
-	return nil
+	return strings.Join(lines, "\n") + "\n"
 }
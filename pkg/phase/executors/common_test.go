@@ -77,6 +77,36 @@ func TestRegisterExecutor(t *testing.T) {
 				Kind:    "BootConfiguration",
 			},
 		},
+		{
+			name:         "register exec plugin executor",
+			executorName: executors.ExecPlugin,
+			registry:     make(map[schema.GroupVersionKind]ifc.ExecutorFactory),
+			expectedGVK: schema.GroupVersionKind{
+				Group:   "airshipit.org",
+				Version: "v1alpha1",
+				Kind:    "ExecPlugin",
+			},
+		},
+		{
+			name:         "register helm executor",
+			executorName: executors.Helm,
+			registry:     make(map[schema.GroupVersionKind]ifc.ExecutorFactory),
+			expectedGVK: schema.GroupVersionKind{
+				Group:   "airshipit.org",
+				Version: "v1alpha1",
+				Kind:    "HelmRelease",
+			},
+		},
+		{
+			name:         "register wait executor",
+			executorName: executors.Wait,
+			registry:     make(map[schema.GroupVersionKind]ifc.ExecutorFactory),
+			expectedGVK: schema.GroupVersionKind{
+				Group:   "airshipit.org",
+				Version: "v1alpha1",
+				Kind:    "Wait",
+			},
+		},
 	}
 	for _, test := range testCases {
 		tt := test
@@ -17,9 +17,13 @@ package executors
 import (
 	"bytes"
 	goerrors "errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/container"
@@ -101,7 +105,7 @@ func (c *ContainerExecutor) Run(opts ifc.RunOptions) error {
 	var output io.Writer
 	if c.ResultsDir == "" {
 		// set output only if the output if resulting directory is not defined
-		output = os.Stdout
+		output = outputWriter(opts)
 	}
 	if err = c.setConfig(); err != nil {
 		return err
@@ -151,10 +155,56 @@ func bundleReader(bundle document.Bundle) (io.Reader, error) {
 
 // Validate executor configuration and documents
 func (c *ContainerExecutor) Validate() error {
-	log.Printf("Method Validate() for container isn't implemented")
+	spec := c.Container.Spec
+	switch spec.Type {
+	case v1alpha1.GenericContainerTypeAirship, "":
+		runtime := spec.Airship.ContainerRuntime
+		if runtime != "" && runtime != container.DriverDocker {
+			return c.errInvalid(fmt.Sprintf("container runtime '%s' is not supported", runtime))
+		}
+	case v1alpha1.GenericContainerTypeKrm:
+	default:
+		return c.errInvalid(fmt.Sprintf("unknown container type '%s'", spec.Type))
+	}
+
+	if spec.Image == "" {
+		return c.errInvalid("image is not specified")
+	}
+
+	// sources are expanded on the copy, so the container spec is left intact for Run
+	mounts := append([]v1alpha1.StorageMount{}, spec.StorageMounts...)
+	container.ExpandSourceMounts(mounts, c.MountBasePath)
+	for _, mount := range mounts {
+		if mount.MountType != "bind" {
+			continue
+		}
+		if _, err := os.Stat(mount.Src); err != nil {
+			return c.errInvalid(fmt.Sprintf("source of the mount '%s' is not accessible: %v", mount.DstPath, err))
+		}
+	}
+
+	// sink output dir is relative to the site root and must stay inside of it
+	if spec.SinkOutputDir != "" {
+		sinkDir := filepath.Clean(spec.SinkOutputDir)
+		if filepath.IsAbs(sinkDir) || sinkDir == ".." || strings.HasPrefix(sinkDir, ".."+string(filepath.Separator)) {
+			return c.errInvalid(fmt.Sprintf("sink output dir '%s' is outside of the site root", spec.SinkOutputDir))
+		}
+	}
+
+	config, err := c.config()
+	if err != nil {
+		return err
+	}
+	if err = yaml.Unmarshal([]byte(config), &map[string]interface{}{}); err != nil {
+		return c.errInvalid(fmt.Sprintf("config is not a valid YAML object: %v", err))
+	}
 	return nil
 }
 
+func (c *ContainerExecutor) errInvalid(reason string) error {
+	return errors.ErrInvalidPhase{Reason: fmt.Sprintf("GenericContainer '%s': %s", c.Container.Name, reason)}
+}
+
 // Render executor documents
 func (c *ContainerExecutor) Render(w io.Writer, o ifc.RenderOptions) error {
 	bundle, err := c.ExecutorBundle.SelectBundle(o.FilterSelector)
@@ -165,22 +215,68 @@ func (c *ContainerExecutor) Render(w io.Writer, o ifc.RenderOptions) error {
 }
 
 func (c *ContainerExecutor) setConfig() error {
-	if c.Container.ConfigRef != nil {
-		log.Debugf("Config reference is specified, looking for the object in config ref: '%v'", c.Container.ConfigRef)
-		doc, err := c.Options.PhaseConfigBundle.SelectOne(document.NewSelector().ByObjectReference(c.Container.ConfigRef))
-		if err != nil {
-			return err
-		}
-		config, err := doc.AsYAML()
-		if err != nil {
-			return err
-		}
-		c.Container.Config = string(config)
-		return nil
+	config, err := c.config()
+	if err != nil {
+		return err
 	}
+	c.Container.Config = config
 	return nil
 }
 
+// config returns config of the container, object referenced by ConfigRef is used instead if it's specified
+func (c *ContainerExecutor) config() (string, error) {
+	if c.Container.ConfigRef == nil {
+		return c.Container.Config, nil
+	}
+	log.Debugf("Config reference is specified, looking for the object in config ref: '%v'", c.Container.ConfigRef)
+	doc, err := c.Options.PhaseConfigBundle.SelectOne(document.NewSelector().ByObjectReference(c.Container.ConfigRef))
+	if err != nil {
+		return "", err
+	}
+	config, err := doc.AsYAML()
+	if err != nil {
+		return "", err
+	}
+	return string(config), nil
+}
+
+// Describe returns the image the container is started from along with its mounts and config
+func (c *ContainerExecutor) Describe() (string, error) {
+	config, err := c.config()
+	if err != nil {
+		return "", err
+	}
+
+	spec := c.Container.Spec
+	containerType := spec.Type
+	if containerType == "" {
+		containerType = v1alpha1.GenericContainerTypeAirship
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Runs %s container from image '%s'\n", containerType, spec.Image)
+	if containerType == v1alpha1.GenericContainerTypeAirship && spec.Airship.ContainerRuntime != "" {
+		fmt.Fprintf(sb, "Container runtime: %s\n", spec.Airship.ContainerRuntime)
+	}
+	if len(spec.StorageMounts) > 0 {
+		fmt.Fprintln(sb, "Mounts:")
+		for _, mount := range spec.StorageMounts {
+			mode := "ro"
+			if mount.ReadWriteMode {
+				mode = "rw"
+			}
+			fmt.Fprintf(sb, "  %s %s -> %s (%s)\n", mount.MountType, mount.Src, mount.DstPath, mode)
+		}
+	}
+	if spec.SinkOutputDir != "" {
+		fmt.Fprintf(sb, "Output is written to '%s'\n", spec.SinkOutputDir)
+	}
+	if config != "" {
+		fmt.Fprintf(sb, "Config:\n%s", indent(config, "  "))
+	}
+	return sb.String(), nil
+}
+
 // Status returns the status of the given phase
 func (c *ContainerExecutor) Status() (ifc.ExecutorStatus, error) {
 	return ifc.ExecutorStatus{}, commonerrors.ErrNotImplemented{What: GenericContainer}
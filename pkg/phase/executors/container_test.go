@@ -17,6 +17,8 @@ import (
 	goerrors "errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -263,6 +265,161 @@ func TestGenericContainer(t *testing.T) {
 	}
 }
 
+func TestContainerValidate(t *testing.T) {
+	targetPath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(targetPath, "mounts"), 0755))
+
+	tests := []struct {
+		name              string
+		expectedErr       string
+		containerAPI      *v1alpha1.GenericContainer
+		phaseConfigBundle document.Bundle
+	}{
+		{
+			name: "success airship container",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Type:  v1alpha1.GenericContainerTypeAirship,
+					Image: "quay.io/test/image:latest",
+					Airship: v1alpha1.AirshipContainerSpec{
+						ContainerRuntime: container.DriverDocker,
+					},
+					StorageMounts: []v1alpha1.StorageMount{
+						{MountType: "bind", Src: "mounts", DstPath: "/mounts"},
+						{MountType: "tmpfs", Src: "no-such-dir", DstPath: "/tmp"},
+					},
+					SinkOutputDir: "target/generator/results",
+				},
+				Config: "apiVersion: v1\nkind: ConfigMap\n",
+			},
+		},
+		{
+			name: "success krm container with referenced config",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Type:  v1alpha1.GenericContainerTypeKrm,
+					Image: "quay.io/test/image:latest",
+				},
+				ConfigRef: &v1.ObjectReference{
+					Kind:       "Secret",
+					Name:       "test-script",
+					APIVersion: "v1",
+				},
+			},
+			phaseConfigBundle: testContainerPhaseConfigBundleRefConfig(t),
+		},
+		{
+			name:        "error unknown container type",
+			expectedErr: "unknown container type 'unknown'",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Type:  "unknown",
+					Image: "quay.io/test/image:latest",
+				},
+			},
+		},
+		{
+			name:        "error unsupported container runtime",
+			expectedErr: "container runtime 'podman' is not supported",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Image: "quay.io/test/image:latest",
+					Airship: v1alpha1.AirshipContainerSpec{
+						ContainerRuntime: "podman",
+					},
+				},
+			},
+		},
+		{
+			name:        "error image is not specified",
+			expectedErr: "image is not specified",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Type: v1alpha1.GenericContainerTypeKrm,
+				},
+			},
+		},
+		{
+			name:        "error mount source doesn't exist",
+			expectedErr: "source of the mount '/my-mounts' is not accessible",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Image: "quay.io/test/image:latest",
+					StorageMounts: []v1alpha1.StorageMount{
+						{MountType: "bind", Src: "no-such-dir", DstPath: "/my-mounts"},
+					},
+				},
+			},
+		},
+		{
+			name:        "error sink output dir is outside of the site root",
+			expectedErr: "sink output dir '../../results' is outside of the site root",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Image:         "quay.io/test/image:latest",
+					SinkOutputDir: "../../results",
+				},
+			},
+		},
+		{
+			name:        "error absolute sink output dir",
+			expectedErr: "sink output dir '/results' is outside of the site root",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Image:         "quay.io/test/image:latest",
+					SinkOutputDir: "/results",
+				},
+			},
+		},
+		{
+			name:        "error no object referenced in config",
+			expectedErr: "found no documents",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Image: "quay.io/test/image:latest",
+				},
+				ConfigRef: &v1.ObjectReference{
+					Kind: "no such kind",
+					Name: "no such name",
+				},
+			},
+			phaseConfigBundle: testContainerPhaseConfigBundleNoDocs(),
+		},
+		{
+			name:        "error config is not a valid YAML object",
+			expectedErr: "config is not a valid YAML object",
+			containerAPI: &v1alpha1.GenericContainer{
+				Spec: v1alpha1.GenericContainerSpec{
+					Image: "quay.io/test/image:latest",
+				},
+				Config: "~:~",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			containerExecutor := executors.ContainerExecutor{
+				MountBasePath: targetPath,
+				Container:     tt.containerAPI,
+				Options: ifc.ExecutorConfig{
+					TargetPath:        targetPath,
+					PhaseConfigBundle: tt.phaseConfigBundle,
+				},
+			}
+
+			err := containerExecutor.Validate()
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestSetKubeConfig(t *testing.T) {
 	getFileErr := fmt.Errorf("failed to get file")
 	testCases := []struct {
@@ -16,7 +16,9 @@ package executors
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
@@ -121,3 +123,17 @@ func (c *EphemeralExecutor) Render(w io.Writer, _ ifc.RenderOptions) error {
 func (c *EphemeralExecutor) Status() (ifc.ExecutorStatus, error) {
 	return ifc.ExecutorStatus{}, errors.ErrNotImplemented{What: Ephemeral}
 }
+
+// Describe returns the bootstrap container image used to deploy the ephemeral cluster
+func (c *EphemeralExecutor) Describe() (string, error) {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Deploys ephemeral cluster using bootstrap container image '%s'\n",
+		c.BootConf.BootstrapContainer.Image)
+	if c.BootConf.BootstrapContainer.ContainerRuntime != "" {
+		fmt.Fprintf(sb, "Container runtime: %s\n", c.BootConf.BootstrapContainer.ContainerRuntime)
+	}
+	if c.BootConf.EphemeralCluster.BootstrapCommand != "" {
+		fmt.Fprintf(sb, "Bootstrap command: %s\n", c.BootConf.EphemeralCluster.BootstrapCommand)
+	}
+	return sb.String(), nil
+}
@@ -16,6 +16,7 @@ package errors
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -91,3 +92,79 @@ type ErrExecutorRegistration struct {
 func (e ErrExecutorRegistration) Error() string {
 	return fmt.Sprintf("failed to register executor %s, registration function returned %s", e.ExecutorName, e.Err.Error())
 }
+
+// ErrInvalidInventoryObject is returned when the object reference stored in the applier inventory can't be parsed
+type ErrInvalidInventoryObject struct {
+	Inventory string
+	Object    string
+}
+
+func (e ErrInvalidInventoryObject) Error() string {
+	return fmt.Sprintf("inventory '%s' contains invalid object reference '%s'", e.Inventory, e.Object)
+}
+
+// ErrExecutorAlreadyRegistered is returned when executor name or kind of its document is already taken
+// by another executor
+type ErrExecutorAlreadyRegistered struct {
+	ExecutorName string
+	GVK          schema.GroupVersionKind
+}
+
+func (e ErrExecutorAlreadyRegistered) Error() string {
+	return fmt.Sprintf("executor '%s' is already registered for '%s'", e.ExecutorName, e.GVK)
+}
+
+// ErrExecPlugin is returned when exec plugin fails to perform the requested operation
+type ErrExecPlugin struct {
+	Plugin    string
+	Operation string
+	Message   string
+}
+
+func (e ErrExecPlugin) Error() string {
+	return fmt.Sprintf("exec plugin '%s' failed to perform '%s' operation: %s", e.Plugin, e.Operation, e.Message)
+}
+
+// ErrWaitTimeout is returned when resources don't reach the desired state before the timeout
+type ErrWaitTimeout struct {
+	Name    string
+	Timeout time.Duration
+	Message string
+}
+
+func (e ErrWaitTimeout) Error() string {
+	return fmt.Sprintf("wait '%s' timed out after %s: %s", e.Name, e.Timeout, e.Message)
+}
+
+// ErrBIOSConfigRefNotDefined is returned when configure-bios operation of BaremetalManager doesn't reference
+// BIOSConfiguration document
+type ErrBIOSConfigRefNotDefined struct {
+	Name string
+}
+
+func (e ErrBIOSConfigRefNotDefined) Error() string {
+	return fmt.Sprintf("BaremetalManager '%s' must reference BIOSConfiguration document in "+
+		"operationOptions.configureBIOS.configRef", e.Name)
+}
+
+// ErrFirmwareConfigRefNotDefined is returned when firmware-update operation of BaremetalManager doesn't reference
+// FirmwareConfiguration document
+type ErrFirmwareConfigRefNotDefined struct {
+	Name string
+}
+
+func (e ErrFirmwareConfigRefNotDefined) Error() string {
+	return fmt.Sprintf("BaremetalManager '%s' must reference FirmwareConfiguration document in "+
+		"operationOptions.firmwareUpdate.configRef", e.Name)
+}
+
+// ErrProviderNotInstalled is returned when Cluster API provider to be upgraded isn't installed in the cluster
+type ErrProviderNotInstalled struct {
+	Name        string
+	Type        string
+	ClusterName string
+}
+
+func (e ErrProviderNotInstalled) Error() string {
+	return fmt.Sprintf("%s %s is not installed in cluster '%s'", e.Type, e.Name, e.ClusterName)
+}
@@ -0,0 +1,256 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/errors"
+	executorerrors "opendev.org/airship/airshipctl/pkg/phase/executors/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+var _ ifc.Executor = &ExecPluginExecutor{}
+
+// ExecPluginExecutor delegates phase operations to an external binary
+type ExecPluginExecutor struct {
+	ExecutorBundle document.Bundle
+
+	plugin      *airshipv1.ExecPlugin
+	phaseName   string
+	clusterName string
+	targetPath  string
+	clusterMap  clustermap.ClusterMap
+	kubeconfig  kubeconfig.Interface
+}
+
+// NewExecPluginExecutor creates instance of exec plugin executor
+func NewExecPluginExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+	bundle, err := cfg.BundleFactory()
+	// plugins may not need documents, so the phase is allowed to have no document entry point
+	if err != nil && goerrors.As(err, &errors.ErrDocumentEntrypointNotDefined{}) {
+		bundle, err = document.NewBundleFromBytes([]byte{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	apiObj := &airshipv1.ExecPlugin{}
+	if err = cfg.ExecutorDocument.ToAPIObject(apiObj, airshipv1.Scheme); err != nil {
+		return nil, err
+	}
+
+	return &ExecPluginExecutor{
+		ExecutorBundle: bundle,
+		plugin:         apiObj,
+		phaseName:      cfg.PhaseName,
+		clusterName:    cfg.ClusterName,
+		targetPath:     cfg.TargetPath,
+		clusterMap:     cfg.ClusterMap,
+		kubeconfig:     cfg.KubeConfig,
+	}, nil
+}
+
+// Run asks the plugin to run the phase, output of the plugin is written to the run options writer
+func (e *ExecPluginExecutor) Run(opts ifc.RunOptions) error {
+	log.Printf("starting exec plugin %s\n", e.plugin.Name)
+
+	req := airshipv1.ExecPluginRequest{Operation: airshipv1.ExecPluginRun, DryRun: opts.DryRun}
+	if opts.Timeout != nil {
+		req.Timeout = int(opts.Timeout.Seconds())
+	}
+	cleanup, err := e.setKubeconfig(&req)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := e.call(req)
+	if err != nil {
+		return err
+	}
+	if resp.Output != "" {
+		if _, err = io.WriteString(outputWriter(opts), resp.Output); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("execution of the exec plugin %s finished\n", e.plugin.Name)
+	return nil
+}
+
+// Render writes documents returned by the plugin
+func (e *ExecPluginExecutor) Render(w io.Writer, o ifc.RenderOptions) error {
+	resp, err := e.call(airshipv1.ExecPluginRequest{Operation: airshipv1.ExecPluginRender})
+	if err != nil {
+		return err
+	}
+	bundle, err := document.NewBundleFromBytes([]byte(resp.Output))
+	if err != nil {
+		return err
+	}
+	bundle, err = bundle.SelectBundle(o.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return bundle.Write(w)
+}
+
+// Validate checks that the plugin is defined and asks the plugin to validate its config and documents
+func (e *ExecPluginExecutor) Validate() error {
+	if e.plugin.Spec.Command == "" {
+		return errors.ErrInvalidPhase{Reason: fmt.Sprintf("ExecPlugin '%s': command is not specified", e.plugin.Name)}
+	}
+	_, err := e.call(airshipv1.ExecPluginRequest{Operation: airshipv1.ExecPluginValidate})
+	return err
+}
+
+// Status returns the status reported by the plugin, the state is unknown if the plugin doesn't report it
+func (e *ExecPluginExecutor) Status() (ifc.ExecutorStatus, error) {
+	req := airshipv1.ExecPluginRequest{Operation: airshipv1.ExecPluginStatus}
+	cleanup, err := e.setKubeconfig(&req)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	defer cleanup()
+
+	resp, err := e.call(req)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	if resp.Status == nil {
+		return ifc.ExecutorStatus{
+			State:   airshipv1.StatusUnknown,
+			Message: fmt.Sprintf("exec plugin '%s' doesn't report status", e.plugin.Name),
+		}, nil
+	}
+	return *resp.Status, nil
+}
+
+// Describe returns the plugin command along with the description provided by the plugin
+func (e *ExecPluginExecutor) Describe() (string, error) {
+	resp, err := e.call(airshipv1.ExecPluginRequest{Operation: airshipv1.ExecPluginDescribe})
+	if err != nil {
+		return "", err
+	}
+
+	sb := &strings.Builder{}
+	command := append([]string{e.command()}, e.plugin.Spec.Args...)
+	fmt.Fprintf(sb, "Runs exec plugin '%s'\n", strings.Join(command, " "))
+	if resp.Output != "" {
+		sb.WriteString(indent(resp.Output, "  "))
+	}
+	return sb.String(), nil
+}
+
+// setKubeconfig adds kubeconfig of the phase cluster to the request, phases without cluster name
+// don't get kubeconfig
+func (e *ExecPluginExecutor) setKubeconfig(req *airshipv1.ExecPluginRequest) (kubeconfig.Cleanup, error) {
+	if e.clusterName == "" {
+		return func() {}, nil
+	}
+	kctx, err := e.clusterMap.ClusterKubeconfigContext(e.clusterName)
+	if err != nil {
+		return nil, err
+	}
+	path, cleanup, err := e.kubeconfig.GetFile()
+	if err != nil {
+		return nil, err
+	}
+	req.Kubeconfig, req.KubeContext = path, kctx
+	return cleanup, nil
+}
+
+// call runs the plugin binary with the request written to its stdin and decodes the response from its stdout,
+// stderr of the plugin is written to the log
+func (e *ExecPluginExecutor) call(req airshipv1.ExecPluginRequest) (airshipv1.ExecPluginResponse, error) {
+	resp := airshipv1.ExecPluginResponse{}
+	pluginErr := func(msg string) error {
+		return executorerrors.ErrExecPlugin{Plugin: e.plugin.Name, Operation: string(req.Operation), Message: msg}
+	}
+
+	req.TypeMeta = metav1.TypeMeta{APIVersion: airshipv1.GroupVersion.String(), Kind: "ExecPluginRequest"}
+	req.PhaseName, req.ClusterName, req.Config = e.phaseName, e.clusterName, e.plugin.Config
+	docs := &bytes.Buffer{}
+	if err := e.ExecutorBundle.Write(docs); err != nil {
+		return resp, err
+	}
+	req.Documents = docs.String()
+	input, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	ctx := context.Background()
+	if e.plugin.Spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(e.plugin.Spec.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := log.NewPrefixedWriter(log.Writer(), fmt.Sprintf("[%s] ", e.plugin.Name))
+	cmd := exec.CommandContext(ctx, e.command(), e.plugin.Spec.Args...) //nolint:gosec
+	cmd.Env = append(os.Environ(), e.plugin.Spec.EnvVars...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	log.Debugf("Calling exec plugin '%s' to perform '%s' operation", e.plugin.Name, req.Operation)
+	runErr := cmd.Run()
+	if err = stderr.Flush(); err != nil {
+		log.Debugf("Failed to write stderr of exec plugin '%s': %v", e.plugin.Name, err)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return resp, pluginErr(fmt.Sprintf("timed out after %ds", e.plugin.Spec.Timeout))
+	}
+
+	decodeErr := json.Unmarshal(stdout.Bytes(), &resp)
+	switch {
+	case resp.Error != "":
+		return resp, pluginErr(resp.Error)
+	case runErr != nil:
+		return resp, pluginErr(runErr.Error())
+	case decodeErr != nil:
+		return resp, pluginErr(fmt.Sprintf("invalid response: %v", decodeErr))
+	}
+	return resp, nil
+}
+
+// command returns path to the plugin binary, relative paths are resolved against the target path
+func (e *ExecPluginExecutor) command() string {
+	command := e.plugin.Spec.Command
+	if strings.ContainsRune(command, filepath.Separator) && !filepath.IsAbs(command) {
+		return filepath.Join(e.targetPath, command)
+	}
+	return command
+}
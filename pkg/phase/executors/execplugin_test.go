@@ -0,0 +1,210 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	execPluginEnv = "AIRSHIP_TEST_EXEC_PLUGIN"
+
+	execPluginDocTmpl = `apiVersion: airshipit.org/v1alpha1
+kind: ExecPlugin
+metadata:
+  name: site-dns
+spec:
+  command: %s
+  args:
+  - -test.run=^TestExecPluginHelperProcess$
+  envVars:
+  - ` + execPluginEnv + `=1
+config:
+  mode: %s
+`
+	execPluginBundle = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: dns-records
+  namespace: site
+data:
+  zone: example.com
+`
+)
+
+// TestExecPluginHelperProcess isn't a real test, it's run as the exec plugin by the executor tests
+func TestExecPluginHelperProcess(t *testing.T) {
+	if os.Getenv(execPluginEnv) != "1" {
+		return
+	}
+
+	req := v1alpha1.ExecPluginRequest{}
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to decode request: %v\n", err)
+		os.Exit(2)
+	}
+	config := map[string]string{}
+	if req.Config != nil {
+		if err := json.Unmarshal(req.Config.Raw, &config); err != nil {
+			os.Exit(2)
+		}
+	}
+
+	resp := v1alpha1.ExecPluginResponse{}
+	switch {
+	case config["mode"] == "crash":
+		fmt.Fprintln(os.Stderr, "plugin crashed")
+		os.Exit(1)
+	case config["mode"] == "garbage":
+		fmt.Fprint(os.Stdout, "not a json")
+		os.Exit(0)
+	case config["mode"] == "invalid":
+		resp.Error = "zone is not defined"
+	case req.Operation == v1alpha1.ExecPluginRun:
+		resp.Output = fmt.Sprintf("phase %s: updated zone using %s, context %s, dry run %t\n",
+			req.PhaseName, req.Kubeconfig, req.KubeContext, req.DryRun)
+	case req.Operation == v1alpha1.ExecPluginRender:
+		resp.Output = req.Documents
+	case req.Operation == v1alpha1.ExecPluginStatus:
+		resp.Status = &v1alpha1.ExecutorStatus{State: v1alpha1.StatusCurrent, Message: "zone is up to date"}
+	case req.Operation == v1alpha1.ExecPluginDescribe:
+		resp.Output = "Updates DNS zone of the site\n"
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(resp); err != nil {
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
+
+func newExecPlugin(t *testing.T, mode string) ifc.Executor {
+	t.Helper()
+	executor, err := executors.NewExecPluginExecutor(ifc.ExecutorConfig{
+		PhaseName:        "dns",
+		ClusterName:      "target-cluster",
+		ExecutorDocument: executorDoc(t, fmt.Sprintf(execPluginDocTmpl, os.Args[0], mode)),
+		BundleFactory: func() (document.Bundle, error) {
+			return document.NewBundleFromBytes([]byte(execPluginBundle))
+		},
+		ClusterMap: ClusterMapMockInterface{MockClusterKubeconfigContext: func(s string) (string, error) {
+			return s, nil
+		}},
+		KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+			return "/tmp/kubeconfig", func() {}, nil
+		}},
+	})
+	require.NoError(t, err)
+	return executor
+}
+
+func TestExecPluginRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           string
+		dryRun         bool
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name: "success",
+			mode: "ok",
+			expectedOutput: "phase dns: updated zone using /tmp/kubeconfig, context target-cluster, " +
+				"dry run false\n",
+		},
+		{
+			name:   "success dry run",
+			mode:   "ok",
+			dryRun: true,
+			expectedOutput: "phase dns: updated zone using /tmp/kubeconfig, context target-cluster, " +
+				"dry run true\n",
+		},
+		{
+			name:        "error reported by plugin",
+			mode:        "invalid",
+			expectedErr: "exec plugin 'site-dns' failed to perform 'run' operation: zone is not defined",
+		},
+		{
+			name:        "error plugin exit code",
+			mode:        "crash",
+			expectedErr: "exec plugin 'site-dns' failed to perform 'run' operation: exit status 1",
+		},
+		{
+			name:        "error invalid response",
+			mode:        "garbage",
+			expectedErr: "exec plugin 'site-dns' failed to perform 'run' operation: invalid response",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := newExecPlugin(t, tt.mode).Run(ifc.RunOptions{DryRun: tt.dryRun, Out: out})
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestExecPluginRender(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := newExecPlugin(t, "ok").Render(out, ifc.RenderOptions{FilterSelector: document.NewSelector()})
+	require.NoError(t, err)
+
+	// the test plugin renders documents of the phase as is
+	bundle, err := document.NewBundleFromBytes([]byte(execPluginBundle))
+	require.NoError(t, err)
+	expected := &bytes.Buffer{}
+	require.NoError(t, bundle.Write(expected))
+	assert.Equal(t, expected.String(), out.String())
+}
+
+func TestExecPluginValidate(t *testing.T) {
+	require.NoError(t, newExecPlugin(t, "ok").Validate())
+
+	err := newExecPlugin(t, "invalid").Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to perform 'validate' operation: zone is not defined")
+}
+
+func TestExecPluginStatus(t *testing.T) {
+	sts, err := newExecPlugin(t, "ok").Status()
+	require.NoError(t, err)
+	assert.Equal(t, ifc.ExecutorStatus{State: v1alpha1.StatusCurrent, Message: "zone is up to date"}, sts)
+}
+
+func TestExecPluginDescribe(t *testing.T) {
+	description, err := newExecPlugin(t, "ok").Describe()
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("Runs exec plugin '%s -test.run=^TestExecPluginHelperProcess$'\n"+
+		"  Updates DNS zone of the site\n", os.Args[0]), description)
+}
@@ -0,0 +1,495 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/container"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/log"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// helmValuesFile is written by the helm KRM function, it contains merged values of the release
+	helmValuesFile = "values.yaml"
+	// defaultHelmValuesKey is the data key of ConfigMaps and Secrets holding values of the release
+	defaultHelmValuesKey = "values.yaml"
+	// helmReleaseUninstalled is the status of the release uninstalled with its history kept
+	helmReleaseUninstalled = "uninstalled"
+)
+
+// helmReleaseStates maps statuses of helm releases to the executor states
+var helmReleaseStates = map[string]airshipv1.StatusState{
+	"deployed":             airshipv1.StatusCurrent,
+	"failed":               airshipv1.StatusFailed,
+	"pending-install":      airshipv1.StatusInProgress,
+	"pending-upgrade":      airshipv1.StatusInProgress,
+	"pending-rollback":     airshipv1.StatusInProgress,
+	"uninstalling":         airshipv1.StatusTerminating,
+	helmReleaseUninstalled: airshipv1.StatusNotFound,
+}
+
+var _ ifc.Executor = &HelmExecutor{}
+
+// HelmExecutor installs, upgrades or uninstalls release of the Helm chart
+type HelmExecutor struct {
+	release     *airshipv1.HelmRelease
+	clusterName string
+	clusterMap  clustermap.ClusterMap
+	kubecfg     kubeconfig.Interface
+	targetPath  string
+	phaseBundle document.Bundle
+	execObj     *airshipv1.GenericContainer
+	clientFunc  container.ClientV1Alpha1FactoryFunc
+
+	dynamicClientFunc utils.DynamicClientFunc
+}
+
+// NewHelmExecutor creates instance of helm executor
+func NewHelmExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+	release := airshipv1.DefaultHelmRelease()
+	if err := cfg.ExecutorDocument.ToAPIObject(release, airshipv1.Scheme); err != nil {
+		return nil, err
+	}
+
+	doc, err := cfg.PhaseConfigBundle.SelectOne(document.NewHelmContainerExecutorSelector())
+	if err != nil {
+		return nil, err
+	}
+	apiObj := airshipv1.DefaultGenericContainer()
+	if err = doc.ToAPIObject(apiObj, airshipv1.Scheme); err != nil {
+		return nil, err
+	}
+
+	clientFunc := container.NewClientV1Alpha1
+	if cfg.ContainerFunc != nil {
+		clientFunc = cfg.ContainerFunc
+	}
+
+	dynamicClientFunc := utils.DynamicClientFromKubeConfig
+	if cfg.DynamicClientFunc != nil {
+		dynamicClientFunc = cfg.DynamicClientFunc
+	}
+
+	return &HelmExecutor{
+		release:     release,
+		clusterName: cfg.ClusterName,
+		clusterMap:  cfg.ClusterMap,
+		kubecfg:     cfg.KubeConfig,
+		targetPath:  cfg.TargetPath,
+		phaseBundle: cfg.PhaseConfigBundle,
+		execObj:     apiObj,
+		clientFunc:  clientFunc,
+
+		dynamicClientFunc: dynamicClientFunc,
+	}, nil
+}
+
+// Run performs the action of the release
+func (e *HelmExecutor) Run(opts ifc.RunOptions) error {
+	spec := e.release.Spec
+	log.Printf("starting helm %s executor", spec.Action)
+
+	var helmOpts *airshipv1.HelmOptions
+	var err error
+	switch spec.Action {
+	case airshipv1.HelmReleaseInstall:
+		helmOpts, err = e.chartOptions("upgrade", "--install")
+		if err == nil && spec.CreateNamespace {
+			helmOpts.CmdOptions = append(helmOpts.CmdOptions, "--create-namespace")
+		}
+	case airshipv1.HelmReleaseUpgrade:
+		helmOpts, err = e.chartOptions("upgrade")
+	case airshipv1.HelmReleaseUninstall:
+		helmOpts = &airshipv1.HelmOptions{CmdOptions: e.namespaceOptions("uninstall", e.releaseName())}
+	default:
+		return errors.ErrUnknownExecutorAction{Action: string(spec.Action), ExecutorName: Helm}
+	}
+	if err != nil {
+		return err
+	}
+
+	kubecfg, kctx, cleanup, err := e.getKubeconfig()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	helmOpts.CmdOptions = append(helmOpts.CmdOptions, "--kubeconfig", kubecfg, "--kube-context", kctx)
+
+	timeout := spec.Timeout
+	if opts.Timeout != nil {
+		timeout = int(opts.Timeout.Seconds())
+	}
+	if timeout > 0 {
+		helmOpts.CmdOptions = append(helmOpts.CmdOptions, "--timeout", fmt.Sprintf("%ds", timeout))
+	}
+	if spec.Wait && spec.Action != airshipv1.HelmReleaseUninstall {
+		helmOpts.CmdOptions = append(helmOpts.CmdOptions, "--wait")
+	}
+	if opts.DryRun {
+		helmOpts.CmdOptions = append(helmOpts.CmdOptions, "--dry-run")
+	}
+	if log.DebugEnabled() {
+		helmOpts.CmdOptions = append(helmOpts.CmdOptions, "--debug")
+	}
+
+	if err = e.run(helmOpts, outputWriter(opts)); err != nil {
+		return err
+	}
+	log.Printf("helm %s completed successfully", spec.Action)
+	return nil
+}
+
+// Render renders manifests of the chart with the values of the release
+func (e *HelmExecutor) Render(w io.Writer, ro ifc.RenderOptions) error {
+	helmOpts, err := e.chartOptions("template")
+	if err != nil {
+		return err
+	}
+	helmOpts.Template = true
+
+	out := &bytes.Buffer{}
+	if err = e.run(helmOpts, out); err != nil {
+		return err
+	}
+	bundle, err := document.NewBundleFromBytes(out.Bytes())
+	if err != nil {
+		return err
+	}
+	filtered, err := bundle.SelectBundle(ro.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return filtered.Write(w)
+}
+
+// Validate checks the action of the release and makes sure that the chart, its repository and the values
+// are defined
+func (e *HelmExecutor) Validate() error {
+	switch e.release.Spec.Action {
+	case airshipv1.HelmReleaseInstall, airshipv1.HelmReleaseUpgrade, airshipv1.HelmReleaseUninstall:
+	default:
+		return e.errInvalid(fmt.Sprintf("unknown action '%s'", e.release.Spec.Action))
+	}
+	if _, _, err := e.chart(); err != nil {
+		return err
+	}
+	_, err := e.values()
+	return err
+}
+
+// Status finds the latest revision of the release among the secrets helm keeps releases in and reports
+// whether the action of the release is completed
+func (e *HelmExecutor) Status() (ifc.ExecutorStatus, error) {
+	kubeconfig, cleanup, err := e.kubecfg.GetFile()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	defer cleanup()
+
+	kctx, err := e.clusterMap.ClusterKubeconfigContext(e.clusterName)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	client, mapper, err := e.dynamicClientFunc(kubeconfig, kctx)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	secrets, err := utils.ListObjects(client, mapper, schema.GroupKind{Kind: "Secret"}, e.release.Spec.Namespace,
+		fmt.Sprintf("owner=helm,name=%s", e.releaseName()))
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	sts := ifc.ExecutorStatus{Details: map[string]string{"release": e.releaseName()}}
+	latest, revision := latestHelmRevision(secrets)
+	releaseStatus := helmReleaseUninstalled
+	if latest != nil {
+		releaseStatus = latest.GetLabels()["status"]
+		sts.Details["revision"] = strconv.Itoa(revision)
+		sts.Details["releaseStatus"] = releaseStatus
+	}
+
+	state, found := helmReleaseStates[releaseStatus]
+	if !found {
+		state = airshipv1.StatusUnknown
+	}
+	if e.release.Spec.Action == airshipv1.HelmReleaseUninstall {
+		// release being uninstalled is the desired state of uninstall action
+		switch state {
+		case airshipv1.StatusNotFound:
+			state = airshipv1.StatusCurrent
+		case airshipv1.StatusCurrent:
+			state = airshipv1.StatusInProgress
+		}
+	}
+	sts.State = state
+	if latest == nil {
+		sts.Message = fmt.Sprintf("Release '%s' is not installed", e.releaseName())
+	} else {
+		sts.Message = fmt.Sprintf("Release '%s' revision %d is %s", e.releaseName(), revision, releaseStatus)
+	}
+	return sts, nil
+}
+
+// latestHelmRevision returns the release secret of the latest revision along with the revision number
+func latestHelmRevision(secrets []unstructured.Unstructured) (*unstructured.Unstructured, int) {
+	var latest *unstructured.Unstructured
+	revision := 0
+	for i := range secrets {
+		version, err := strconv.Atoi(secrets[i].GetLabels()["version"])
+		if err != nil {
+			log.Debugf("Skipping helm release secret %s with invalid version: %v", secrets[i].GetName(), err)
+			continue
+		}
+		if version > revision {
+			latest, revision = &secrets[i], version
+		}
+	}
+	return latest, revision
+}
+
+// Describe returns the action performed with the release along with the chart and the values used
+func (e *HelmExecutor) Describe() (string, error) {
+	spec := e.release.Spec
+	target := fmt.Sprintf("cluster '%s'", e.clusterName)
+	if spec.Namespace != "" {
+		target = fmt.Sprintf("namespace '%s' of %s", spec.Namespace, target)
+	}
+
+	sb := &strings.Builder{}
+	if spec.Action == airshipv1.HelmReleaseUninstall {
+		fmt.Fprintf(sb, "Uninstalls release '%s' from %s\n", e.releaseName(), target)
+		fmt.Fprintf(sb, "Timeout: %ds\n", spec.Timeout)
+		return sb.String(), nil
+	}
+
+	verb := "Installs or upgrades"
+	switch spec.Action {
+	case airshipv1.HelmReleaseInstall:
+	case airshipv1.HelmReleaseUpgrade:
+		verb = "Upgrades"
+	default:
+		return "", errors.ErrUnknownExecutorAction{Action: string(spec.Action), ExecutorName: Helm}
+	}
+	chart, repoURL, err := e.chart()
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(sb, "%s release '%s' of chart '%s' version '%s' from '%s' in %s\n",
+		verb, e.releaseName(), chart.Chart, chart.Version, repoURL, target)
+	if len(spec.ValuesFrom) > 0 || spec.Values != nil {
+		fmt.Fprintln(sb, "Values from:")
+		for _, ref := range spec.ValuesFrom {
+			fmt.Fprintf(sb, "  %s %s (%s)\n", ref.Kind, ref.Name, valuesKey(ref))
+		}
+		if spec.Values != nil {
+			fmt.Fprintln(sb, "  values of the executor document")
+		}
+	}
+	fmt.Fprintf(sb, "Wait: %t, timeout: %ds\n", spec.Wait, spec.Timeout)
+	return sb.String(), nil
+}
+
+func (e *HelmExecutor) releaseName() string {
+	if e.release.Spec.ReleaseName != "" {
+		return e.release.Spec.ReleaseName
+	}
+	return e.release.Name
+}
+
+// namespaceOptions adds namespace of the release to the command options
+func (e *HelmExecutor) namespaceOptions(opts ...string) []string {
+	if e.release.Spec.Namespace != "" {
+		opts = append(opts, "--namespace", e.release.Spec.Namespace)
+	}
+	return opts
+}
+
+// chartOptions returns helm options to run the command with the chart and the values of the release
+func (e *HelmExecutor) chartOptions(command ...string) (*airshipv1.HelmOptions, error) {
+	chart, repoURL, err := e.chart()
+	if err != nil {
+		return nil, err
+	}
+	values, err := e.values()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append(command, e.releaseName(), chart.Chart, "--repo", repoURL)
+	if chart.Version != "" {
+		opts = append(opts, "--version", chart.Version)
+	}
+	if len(values) > 0 {
+		opts = append(opts, "--values", helmValuesFile)
+	}
+	return &airshipv1.HelmOptions{CmdOptions: e.namespaceOptions(opts...), Values: values}, nil
+}
+
+// chart returns the chart of the release and URL of its repository from the versions catalogue
+func (e *HelmExecutor) chart() (airshipv1.ChartProperties, string, error) {
+	ref := e.release.Spec.Chart
+	doc, err := e.phaseBundle.SelectOne(document.NewSelector().
+		ByGvk(airshipv1.GroupVersion.Group, airshipv1.GroupVersion.Version, "VersionsCatalogue").
+		ByName(ref.Catalogue))
+	if err != nil {
+		return airshipv1.ChartProperties{}, "", err
+	}
+	catalogue := &airshipv1.VersionsCatalogue{}
+	if err = doc.ToObject(catalogue); err != nil {
+		return airshipv1.ChartProperties{}, "", err
+	}
+
+	chart, ok := catalogue.Spec.Charts[ref.Name]
+	if !ok {
+		return airshipv1.ChartProperties{}, "", e.errInvalid(
+			fmt.Sprintf("chart '%s' is not defined in versions catalogue '%s'", ref.Name, ref.Catalogue))
+	}
+	repoName := chart.SourceRef.Name
+	if repoName == "" {
+		repoName = ref.Name
+	}
+	repo, ok := catalogue.Spec.HelmRepositories[repoName]
+	if !ok {
+		return airshipv1.ChartProperties{}, "", e.errInvalid(
+			fmt.Sprintf("helm repository '%s' is not defined in versions catalogue '%s'", repoName, ref.Catalogue))
+	}
+	return chart, repo.URL, nil
+}
+
+// values returns values of the referenced documents merged with the values of the release in YAML format
+func (e *HelmExecutor) values() ([]byte, error) {
+	merged := map[string]interface{}{}
+	for _, ref := range e.release.Spec.ValuesFrom {
+		data, err := e.referencedValues(ref)
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]interface{}{}
+		if err = yaml.Unmarshal([]byte(data), &values); err != nil {
+			return nil, e.errInvalid(fmt.Sprintf("values of %s '%s' are invalid: %v", ref.Kind, ref.Name, err))
+		}
+		mergeValues(merged, values)
+	}
+
+	if e.release.Spec.Values != nil {
+		values := map[string]interface{}{}
+		if err := json.Unmarshal(e.release.Spec.Values.Raw, &values); err != nil {
+			return nil, e.errInvalid(fmt.Sprintf("values are invalid: %v", err))
+		}
+		mergeValues(merged, values)
+	}
+
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return yaml.Marshal(merged)
+}
+
+func (e *HelmExecutor) referencedValues(ref airshipv1.HelmValuesReference) (string, error) {
+	if ref.Kind != "ConfigMap" && ref.Kind != "Secret" {
+		return "", e.errInvalid(fmt.Sprintf("values can't be taken from %s '%s'", ref.Kind, ref.Name))
+	}
+	doc, err := e.phaseBundle.SelectOne(document.NewSelector().ByKind(ref.Kind).ByName(ref.Name))
+	if err != nil {
+		return "", err
+	}
+	if ref.Kind == "Secret" {
+		return document.GetSecretDataKey(doc, valuesKey(ref))
+	}
+
+	data, err := doc.GetStringMap("data")
+	if err != nil {
+		return "", err
+	}
+	values, ok := data[valuesKey(ref)]
+	if !ok {
+		return "", document.ErrDocumentDataKeyNotFound{DocName: ref.Name, Key: valuesKey(ref)}
+	}
+	return values, nil
+}
+
+func valuesKey(ref airshipv1.HelmValuesReference) string {
+	if ref.ValuesKey != "" {
+		return ref.ValuesKey
+	}
+	return defaultHelmValuesKey
+}
+
+// mergeValues merges src values into dst the same way helm merges values files: nested maps are merged
+// recursively, other values of src replace values of dst
+func mergeValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func (e *HelmExecutor) getKubeconfig() (string, string, func(), error) {
+	kubeConfigFile, cleanup, err := e.kubecfg.GetFile()
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	kctx, err := e.clusterMap.ClusterKubeconfigContext(e.clusterName)
+	if err != nil {
+		cleanup()
+		return "", "", nil, err
+	}
+
+	e.execObj.Spec.StorageMounts = append(e.execObj.Spec.StorageMounts, airshipv1.StorageMount{
+		MountType:     "bind",
+		Src:           kubeConfigFile,
+		DstPath:       kubeConfigFile,
+		ReadWriteMode: false,
+	})
+	return kubeConfigFile, kctx, cleanup, nil
+}
+
+func (e *HelmExecutor) run(opts *airshipv1.HelmOptions, out io.Writer) error {
+	config, err := yaml.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	e.execObj.Config = string(config)
+	return e.clientFunc("", &bytes.Buffer{}, out, e.execObj, e.targetPath).Run()
+}
+
+func (e *HelmExecutor) errInvalid(reason string) error {
+	return phaseerrors.ErrInvalidPhase{Reason: fmt.Sprintf("HelmRelease '%s': %s", e.release.Name, reason)}
+}
@@ -0,0 +1,397 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/container"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	helmReleaseTmpl = `apiVersion: airshipit.org/v1alpha1
+kind: HelmRelease
+metadata:
+  name: ingress
+spec:
+  action: %s
+  namespace: ingress
+  createNamespace: true
+  chart:
+    name: %s
+  valuesFrom:
+  - kind: ConfigMap
+    name: ingress-values
+  - kind: Secret
+    name: ingress-secret-values
+    valuesKey: secret.yaml
+  values:
+    controller:
+      replicaCount: 3
+  wait: true
+`
+	helmPhaseBundle = `---
+apiVersion: airshipit.org/v1alpha1
+kind: GenericContainer
+metadata:
+  name: helm
+  labels:
+    airshipit.org/deploy-k8s: "false"
+spec:
+  type: krm
+  image: localhost/helm:latest
+  hostNetwork: true
+---
+apiVersion: airshipit.org/v1alpha1
+kind: VersionsCatalogue
+metadata:
+  name: versions-airshipctl
+spec:
+  helm_repositories:
+    ingress-nginx:
+      url: https://kubernetes.github.io/ingress-nginx
+  charts:
+    ingress-nginx:
+      chart: ingress-nginx
+      version: 3.5.1
+    dashboard:
+      chart: kubernetes-dashboard
+      version: 4.0.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ingress-values
+data:
+  values.yaml: |
+    controller:
+      replicaCount: 2
+      kind: Deployment
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: ingress-secret-values
+stringData:
+  secret.yaml: |
+    controller:
+      admissionWebhooks:
+        enabled: false
+`
+	helmReleaseSecretTmpl = `apiVersion: v1
+kind: Secret
+metadata:
+  name: sh.helm.release.v1.ingress.v%d
+  namespace: ingress
+  labels:
+    owner: helm
+    name: ingress
+    version: "%d"
+    status: %s
+`
+	helmRenderedDocs = `apiVersion: v1
+kind: Service
+metadata:
+  name: ingress-nginx-controller
+  namespace: ingress
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ingress-nginx-controller
+  namespace: ingress
+`
+)
+
+func newHelmExecutor(t *testing.T, action, chart string, clientFunc container.ClientV1Alpha1FactoryFunc) ifc.Executor {
+	t.Helper()
+	executor, err := executors.NewHelmExecutor(ifc.ExecutorConfig{
+		ClusterName:       "target-cluster",
+		TargetPath:        "testdata",
+		PhaseConfigBundle: executorBundle(t, helmPhaseBundle),
+		ExecutorDocument:  executorDoc(t, fmt.Sprintf(helmReleaseTmpl, action, chart)),
+		ClusterMap: ClusterMapMockInterface{MockClusterKubeconfigContext: func(s string) (string, error) {
+			return s, nil
+		}},
+		KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+			return "/tmp/kubeconfig", func() {}, nil
+		}},
+		ContainerFunc: clientFunc,
+		DynamicClientFunc: testClusterClientFunc(t, map[string][]string{
+			"target-cluster": {
+				fmt.Sprintf(helmReleaseSecretTmpl, 1, 1, "superseded"),
+				fmt.Sprintf(helmReleaseSecretTmpl, 2, 2, "deployed"),
+			},
+		}),
+	})
+	require.NoError(t, err)
+	return executor
+}
+
+// helmClientFunc returns container client which records options passed to the helm function
+// and writes the output to the container writer
+func helmClientFunc(t *testing.T, opts *v1alpha1.HelmOptions, output string) container.ClientV1Alpha1FactoryFunc {
+	return func(_ string, _ io.Reader, out io.Writer, conf *v1alpha1.GenericContainer,
+		_ string) container.ClientV1Alpha1 {
+		return MockClientFuncInterface{MockRun: func() error {
+			require.NoError(t, yaml.Unmarshal([]byte(conf.Config), opts))
+			_, err := io.WriteString(out, output)
+			return err
+		}}
+	}
+}
+
+func TestNewHelmExecutor(t *testing.T) {
+	_, err := executors.NewHelmExecutor(ifc.ExecutorConfig{
+		PhaseConfigBundle: executorBundle(t, helmPhaseBundle),
+		ExecutorDocument:  executorDoc(t, fmt.Sprintf(helmReleaseTmpl, "install", "ingress-nginx")),
+	})
+	require.NoError(t, err)
+
+	_, err = executors.NewHelmExecutor(ifc.ExecutorConfig{
+		PhaseConfigBundle: executorBundle(t, krmExecDoc),
+		ExecutorDocument:  executorDoc(t, fmt.Sprintf(helmReleaseTmpl, "install", "ingress-nginx")),
+	})
+	require.Error(t, err)
+}
+
+func TestHelmExecutorRun(t *testing.T) {
+	expectedValues := map[string]interface{}{
+		"controller": map[string]interface{}{
+			"admissionWebhooks": map[string]interface{}{"enabled": false},
+			"kind":              "Deployment",
+			"replicaCount":      3,
+		},
+	}
+	tests := []struct {
+		name           string
+		action         string
+		dryRun         bool
+		expectedOpts   []string
+		expectedValues map[string]interface{}
+		expectedErr    string
+	}{
+		{
+			name:   "success install",
+			action: "install",
+			expectedOpts: []string{"upgrade", "--install", "ingress", "ingress-nginx",
+				"--repo", "https://kubernetes.github.io/ingress-nginx", "--version", "3.5.1",
+				"--values", "values.yaml", "--namespace", "ingress", "--create-namespace",
+				"--kubeconfig", "/tmp/kubeconfig", "--kube-context", "target-cluster",
+				"--timeout", "300s", "--wait"},
+			expectedValues: expectedValues,
+		},
+		{
+			name:   "success upgrade dry run",
+			action: "upgrade",
+			dryRun: true,
+			expectedOpts: []string{"upgrade", "ingress", "ingress-nginx",
+				"--repo", "https://kubernetes.github.io/ingress-nginx", "--version", "3.5.1",
+				"--values", "values.yaml", "--namespace", "ingress",
+				"--kubeconfig", "/tmp/kubeconfig", "--kube-context", "target-cluster",
+				"--timeout", "300s", "--wait", "--dry-run"},
+			expectedValues: expectedValues,
+		},
+		{
+			name:   "success uninstall",
+			action: "uninstall",
+			expectedOpts: []string{"uninstall", "ingress", "--namespace", "ingress",
+				"--kubeconfig", "/tmp/kubeconfig", "--kube-context", "target-cluster", "--timeout", "300s"},
+		},
+		{
+			name:        "error unknown action",
+			action:      "rollback",
+			expectedErr: "unknown action type 'rollback'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &v1alpha1.HelmOptions{}
+			out := &bytes.Buffer{}
+			executor := newHelmExecutor(t, tt.action, "ingress-nginx", helmClientFunc(t, opts, "release installed\n"))
+			err := executor.Run(ifc.RunOptions{DryRun: tt.dryRun, Out: out})
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOpts, opts.CmdOptions)
+			if tt.expectedValues != nil {
+				values := map[string]interface{}{}
+				require.NoError(t, yaml.Unmarshal(opts.Values, &values))
+				assert.Equal(t, tt.expectedValues, values)
+			} else {
+				assert.Empty(t, opts.Values)
+			}
+			assert.False(t, opts.Template)
+			assert.Equal(t, "release installed\n", out.String())
+		})
+	}
+}
+
+func TestHelmExecutorRender(t *testing.T) {
+	opts := &v1alpha1.HelmOptions{}
+	executor := newHelmExecutor(t, "install", "ingress-nginx", helmClientFunc(t, opts, helmRenderedDocs))
+
+	out := &bytes.Buffer{}
+	err := executor.Render(out, ifc.RenderOptions{FilterSelector: document.NewSelector().ByKind("Deployment")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"template", "ingress", "ingress-nginx",
+		"--repo", "https://kubernetes.github.io/ingress-nginx", "--version", "3.5.1",
+		"--values", "values.yaml", "--namespace", "ingress"}, opts.CmdOptions)
+	assert.True(t, opts.Template)
+	assert.Contains(t, out.String(), "kind: Deployment")
+	assert.NotContains(t, out.String(), "kind: Service")
+}
+
+func TestHelmExecutorValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		action      string
+		chart       string
+		expectedErr string
+	}{
+		{
+			name:   "success",
+			action: "install",
+			chart:  "ingress-nginx",
+		},
+		{
+			name:        "error unknown action",
+			action:      "rollback",
+			chart:       "ingress-nginx",
+			expectedErr: "HelmRelease 'ingress': unknown action 'rollback'",
+		},
+		{
+			name:        "error chart is not in catalogue",
+			action:      "install",
+			chart:       "cert-manager",
+			expectedErr: "chart 'cert-manager' is not defined in versions catalogue 'versions-airshipctl'",
+		},
+		{
+			name:        "error repository is not in catalogue",
+			action:      "upgrade",
+			chart:       "dashboard",
+			expectedErr: "helm repository 'dashboard' is not defined in versions catalogue 'versions-airshipctl'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := newHelmExecutor(t, tt.action, tt.chart, nil).Validate()
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHelmExecutorStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   string
+		expected ifc.ExecutorStatus
+	}{
+		{
+			name:   "release is deployed",
+			action: "install",
+			expected: ifc.ExecutorStatus{
+				State:   v1alpha1.StatusCurrent,
+				Message: "Release 'ingress' revision 2 is deployed",
+				Details: map[string]string{"release": "ingress", "revision": "2", "releaseStatus": "deployed"},
+			},
+		},
+		{
+			name:   "release is not uninstalled",
+			action: "uninstall",
+			expected: ifc.ExecutorStatus{
+				State:   v1alpha1.StatusInProgress,
+				Message: "Release 'ingress' revision 2 is deployed",
+				Details: map[string]string{"release": "ingress", "revision": "2", "releaseStatus": "deployed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			sts, err := newHelmExecutor(t, tt.action, "ingress-nginx", nil).Status()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sts)
+		})
+	}
+}
+
+func TestHelmExecutorStatusNotInstalled(t *testing.T) {
+	for action, state := range map[string]v1alpha1.StatusState{
+		"install":   v1alpha1.StatusNotFound,
+		"uninstall": v1alpha1.StatusCurrent,
+	} {
+		executor, err := executors.NewHelmExecutor(ifc.ExecutorConfig{
+			ClusterName:       "target-cluster",
+			PhaseConfigBundle: executorBundle(t, helmPhaseBundle),
+			ExecutorDocument:  executorDoc(t, fmt.Sprintf(helmReleaseTmpl, action, "ingress-nginx")),
+			ClusterMap: ClusterMapMockInterface{MockClusterKubeconfigContext: func(s string) (string, error) {
+				return s, nil
+			}},
+			KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+				return "/tmp/kubeconfig", func() {}, nil
+			}},
+			DynamicClientFunc: testClusterClientFunc(t, nil),
+		})
+		require.NoError(t, err)
+
+		sts, err := executor.Status()
+		require.NoError(t, err)
+		assert.Equal(t, state, sts.State, action)
+		assert.Equal(t, "Release 'ingress' is not installed", sts.Message)
+	}
+}
+
+func TestHelmExecutorDescribe(t *testing.T) {
+	description, err := newHelmExecutor(t, "install", "ingress-nginx", nil).Describe()
+	require.NoError(t, err)
+	assert.Equal(t, "Installs or upgrades release 'ingress' of chart 'ingress-nginx' version '3.5.1' "+
+		"from 'https://kubernetes.github.io/ingress-nginx' in namespace 'ingress' of cluster 'target-cluster'\n"+
+		"Values from:\n"+
+		"  ConfigMap ingress-values (values.yaml)\n"+
+		"  Secret ingress-secret-values (secret.yaml)\n"+
+		"  values of the executor document\n"+
+		"Wait: true, timeout: 300s\n", description)
+
+	description, err = newHelmExecutor(t, "uninstall", "ingress-nginx", nil).Describe()
+	require.NoError(t, err)
+	assert.Equal(t, "Uninstalls release 'ingress' from namespace 'ingress' of cluster 'target-cluster'\n"+
+		"Timeout: 300s\n", description)
+}
@@ -16,8 +16,11 @@ package executors
 
 import (
 	"bytes"
+	goerrors "errors"
+	"fmt"
 	"io"
-	"os"
+	"sort"
+	"strings"
 
 	"sigs.k8s.io/kustomize/kyaml/yaml"
 
@@ -25,10 +28,11 @@ import (
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
-	airerrors "opendev.org/airship/airshipctl/pkg/errors"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
 	"opendev.org/airship/airshipctl/pkg/log"
-	"opendev.org/airship/airshipctl/pkg/phase/errors"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 )
 
@@ -47,6 +51,8 @@ type KubeApplierExecutor struct {
 	kubeconfig  kubeconfig.Interface
 	clientFunc  container.ClientV1Alpha1FactoryFunc
 	execObj     *airshipv1.GenericContainer
+
+	dynamicClientFunc utils.DynamicClientFunc
 }
 
 // NewKubeApplierExecutor returns instance of executor
@@ -57,6 +63,11 @@ func NewKubeApplierExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		return nil, err
 	}
 	bundle, err := cfg.BundleFactory()
+	// delete action removes resources stored in the inventory, so the phase may have no document entry point
+	if err != nil && apiObj.Config.Action == airshipv1.ApplyActionDelete &&
+		goerrors.As(err, &phaseerrors.ErrDocumentEntrypointNotDefined{}) {
+		bundle, err = document.NewBundleFromBytes([]byte{})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +88,11 @@ func NewKubeApplierExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		clientFunc = cfg.ContainerFunc
 	}
 
+	dynamicClientFunc := utils.DynamicClientFromKubeConfig
+	if cfg.DynamicClientFunc != nil {
+		dynamicClientFunc = cfg.DynamicClientFunc
+	}
+
 	return &KubeApplierExecutor{
 		ExecutorBundle:   bundle,
 		BundleName:       cfg.PhaseName,
@@ -88,13 +104,20 @@ func NewKubeApplierExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		clientFunc:       clientFunc,
 		execObj:          cObj,
 		targetPath:       cfg.TargetPath,
+
+		dynamicClientFunc: dynamicClientFunc,
 	}, nil
 }
 
 // Run executor, should be performed in separate go routine
 func (e *KubeApplierExecutor) Run(runOpts ifc.RunOptions) error {
+	switch e.apiObject.Config.Action {
+	case "", airshipv1.ApplyActionApply, airshipv1.ApplyActionDelete:
+	default:
+		return errors.ErrUnknownExecutorAction{Action: string(e.apiObject.Config.Action), ExecutorName: KubernetesApply}
+	}
 	e.apiObject.Config.Debug = log.DebugEnabled()
-	e.apiObject.Config.PhaseName = e.BundleName
+	e.apiObject.Config.PhaseName = e.inventoryName()
 
 	if e.apiObject.Config.Kubeconfig == "" {
 		kcfg, ctx, cleanup, err := e.getKubeconfig()
@@ -114,7 +137,7 @@ func (e *KubeApplierExecutor) Run(runOpts ifc.RunOptions) error {
 
 	e.apiObject.Config.DryRun = runOpts.DryRun
 	if runOpts.Timeout != nil {
-		e.apiObject.Config.WaitOptions.Timeout = int(*runOpts.Timeout)
+		e.apiObject.Config.WaitOptions.Timeout = int(runOpts.Timeout.Seconds())
 	}
 
 	reader, err := e.prepareDocuments()
@@ -128,7 +151,20 @@ func (e *KubeApplierExecutor) Run(runOpts ifc.RunOptions) error {
 	}
 
 	e.execObj.Config = string(opts)
-	return e.clientFunc("", reader, os.Stdout, e.execObj, e.targetPath).Run()
+	return e.clientFunc("", reader, outputWriter(runOpts), e.execObj, e.targetPath).Run()
+}
+
+// inventoryName returns the name of the phase which owns the inventory created by the applier
+func (e *KubeApplierExecutor) inventoryName() string {
+	if e.apiObject.Config.PhaseName != "" {
+		return e.apiObject.Config.PhaseName
+	}
+	return e.BundleName
+}
+
+// deletes reports if the phase destroys resources of the inventory instead of applying them
+func (e *KubeApplierExecutor) deletes() bool {
+	return e.apiObject.Config.Action == airshipv1.ApplyActionDelete
 }
 
 func (e *KubeApplierExecutor) getKubeconfig() (string, string, func(), error) {
@@ -163,14 +199,23 @@ func (e *KubeApplierExecutor) prepareDocuments() (io.Reader, error) {
 // Validate document set
 func (e *KubeApplierExecutor) Validate() error {
 	if e.BundleName == "" {
-		return errors.ErrInvalidPhase{Reason: "k8s applier BundleName is empty"}
+		return phaseerrors.ErrInvalidPhase{Reason: "k8s applier BundleName is empty"}
+	}
+	switch e.apiObject.Config.Action {
+	case "", airshipv1.ApplyActionApply:
+	case airshipv1.ApplyActionDelete:
+		// the inventory of the phase defines the resources to delete, the bundle may be empty
+		return nil
+	default:
+		return phaseerrors.ErrInvalidPhase{Reason: fmt.Sprintf("KubernetesApply '%s': unknown action '%s'",
+			e.apiObject.GetName(), e.apiObject.Config.Action)}
 	}
 	docs, err := e.ExecutorBundle.GetAllDocuments()
 	if err != nil {
 		return err
 	}
 	if len(docs) == 0 {
-		return errors.ErrInvalidPhase{Reason: "no executor documents in the bundle"}
+		return phaseerrors.ErrInvalidPhase{Reason: "no executor documents in the bundle"}
 	}
 	// TODO: need to find if any other validation needs to be added
 	return nil
@@ -185,7 +230,50 @@ func (e *KubeApplierExecutor) Render(w io.Writer, o ifc.RenderOptions) error {
 	return bundle.Write(w)
 }
 
-// Status returns the status of the given phase
-func (e *KubeApplierExecutor) Status() (ifc.ExecutorStatus, error) {
-	return ifc.ExecutorStatus{}, airerrors.ErrNotImplemented{What: KubernetesApply}
+// Describe lists the resources applied to the cluster grouped by kind
+func (e *KubeApplierExecutor) Describe() (string, error) {
+	docs, invNamespace, invID, err := e.statusDocuments()
+	if err != nil {
+		return "", err
+	}
+
+	if e.deletes() {
+		sb := &strings.Builder{}
+		fmt.Fprintf(sb, "Deletes resources stored in inventory '%s/%s' from cluster '%s' in reverse dependency order\n",
+			invNamespace, invID, e.clusterName)
+		if e.apiObject.Config.WaitOptions.Timeout > 0 {
+			fmt.Fprintf(sb, "Wait timeout: %ds\n", e.apiObject.Config.WaitOptions.Timeout)
+		}
+		return sb.String(), nil
+	}
+
+	var kinds []string
+	byKind := map[string][]string{}
+	for _, doc := range docs {
+		kind := doc.GetKind()
+		if _, exists := byKind[kind]; !exists {
+			kinds = append(kinds, kind)
+		}
+		name := doc.GetName()
+		if doc.GetNamespace() != "" {
+			name = doc.GetNamespace() + "/" + name
+		}
+		byKind[kind] = append(byKind[kind], name)
+	}
+	sort.Strings(kinds)
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Applies %d resources to cluster '%s' using inventory '%s/%s'\n",
+		len(docs), e.clusterName, invNamespace, invID)
+	for _, kind := range kinds {
+		fmt.Fprintf(sb, "  %s (%d):\n", kind, len(byKind[kind]))
+		for _, name := range byKind[kind] {
+			fmt.Fprintf(sb, "    %s\n", name)
+		}
+	}
+	fmt.Fprintf(sb, "Prune: %t\n", e.apiObject.Config.PruneOptions.Prune)
+	if e.apiObject.Config.WaitOptions.Timeout > 0 {
+		fmt.Fprintf(sb, "Wait timeout: %ds\n", e.apiObject.Config.WaitOptions.Timeout)
+	}
+	return sb.String(), nil
 }
@@ -0,0 +1,416 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/k8s/kstatus"
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// inventoryLabel is a label of the cli-utils inventory object
+	inventoryLabel = "cli-utils.sigs.k8s.io/inventory-id"
+	// inventoryNamespacePrefix is a prefix of the namespace where the applier creates
+	// the inventory object if the bundle doesn't provide one
+	inventoryNamespacePrefix = "airshipit-"
+	// defaultNamespace is used for namespaced resources which don't have namespace defined
+	defaultNamespace = "default"
+	// driftConditionType is a type of the phase condition which shows if resources differ from the bundle
+	driftConditionType = "Drifted"
+)
+
+// objectKey identifies kubernetes resource the same way cli-utils inventory does
+type objectKey struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// parseInventoryKey parses object reference stored in the inventory in <namespace>_<name>_<group>_<kind>
+// format, colons of the name are encoded as double underscores
+func parseInventoryKey(inventory, ref string) (objectKey, error) {
+	invalidErr := errors.ErrInvalidInventoryObject{Inventory: inventory, Object: ref}
+	key := objectKey{}
+	index := strings.Index(ref, "_")
+	if index == -1 {
+		return key, invalidErr
+	}
+	key.Namespace, ref = ref[:index], ref[index+1:]
+
+	index = strings.LastIndex(ref, "_")
+	if index == -1 {
+		return key, invalidErr
+	}
+	key.Kind, ref = ref[index+1:], ref[:index]
+
+	index = strings.LastIndex(ref, "_")
+	if index == -1 {
+		return key, invalidErr
+	}
+	key.Group, key.Name = ref[index+1:], strings.ReplaceAll(ref[:index], "__", ":")
+	if key.Name == "" || key.Kind == "" {
+		return key, invalidErr
+	}
+	return key, nil
+}
+
+// Status returns status of the resources applied by the phase, the resources are taken from
+// the cli-utils inventory stored in the cluster and from the document bundle of the phase
+func (e *KubeApplierExecutor) Status() (ifc.ExecutorStatus, error) {
+	kcfg, kctx := e.apiObject.Config.Kubeconfig, e.apiObject.Config.Context
+	if kcfg == "" {
+		var cleanup func()
+		var err error
+		kcfg, kctx, cleanup, err = e.getKubeconfig()
+		if err != nil {
+			return ifc.ExecutorStatus{}, err
+		}
+		defer cleanup()
+	}
+
+	client, mapper, err := e.dynamicClientFunc(kcfg, kctx)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	bundleDocs, invNamespace, invID, err := e.statusDocuments()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	inventory, err := e.inventoryObjects(client, invNamespace, invID)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	sts := ifc.ExecutorStatus{}
+	reported := map[objectKey]bool{}
+	for _, doc := range bundleDocs {
+		key := objectKey{
+			Group:     doc.GetGroup(),
+			Kind:      doc.GetKind(),
+			Namespace: doc.GetNamespace(),
+			Name:      doc.GetName(),
+		}
+		rs := resourceStatus(client, mapper, &key, doc)
+		reported[key] = true
+		sts.Resources = append(sts.Resources, rs)
+	}
+
+	for _, key := range inventory {
+		key := key
+		if reported[key] {
+			continue
+		}
+		rs := resourceStatus(client, mapper, &key, nil)
+		// namespace of the inventory object is created by the applier itself
+		if key.Group == "" && key.Kind == "Namespace" && key.Name == invNamespace && rs.Exists {
+			rs.Drift = airshipv1.DriftInSync
+		}
+		sts.Resources = append(sts.Resources, rs)
+	}
+	if e.deletes() {
+		summarizeDeletion(&sts)
+		return sts, nil
+	}
+	summarizeStatus(&sts)
+	return sts, nil
+}
+
+// summarizeDeletion sets overall state of the delete phase, resources being absent is the desired state
+func summarizeDeletion(sts *ifc.ExecutorStatus) {
+	states := map[airshipv1.StatusState]int{}
+	for _, rs := range sts.Resources {
+		states[rs.State]++
+	}
+
+	total := len(sts.Resources)
+	deleted := states[airshipv1.StatusNotFound]
+	switch {
+	case deleted == total:
+		sts.State = airshipv1.StatusCurrent
+		sts.Message = "All resources are deleted"
+	case states[airshipv1.StatusUnknown] > 0:
+		sts.State = airshipv1.StatusUnknown
+		sts.Message = fmt.Sprintf("Status of %d of %d resources is unknown", states[airshipv1.StatusUnknown], total)
+	case states[airshipv1.StatusTerminating] > 0:
+		sts.State = airshipv1.StatusTerminating
+		sts.Message = fmt.Sprintf("%d of %d resources are deleted", deleted, total)
+	default:
+		sts.State = airshipv1.StatusInProgress
+		sts.Message = fmt.Sprintf("%d of %d resources are deleted", deleted, total)
+	}
+}
+
+// summarizeStatus sets overall state of the phase and its drift condition according to the resource statuses
+func summarizeStatus(sts *ifc.ExecutorStatus) {
+	summarizeStates(sts)
+
+	drifted := 0
+	for _, rs := range sts.Resources {
+		if rs.Drift != airshipv1.DriftInSync {
+			drifted++
+		}
+	}
+
+	total := len(sts.Resources)
+	cond := airshipv1.StatusCondition{
+		Type:    driftConditionType,
+		Status:  string(metav1.ConditionFalse),
+		Message: "All resources match the document bundle",
+	}
+	if drifted > 0 {
+		cond.Status = string(metav1.ConditionTrue)
+		cond.Message = fmt.Sprintf("%d of %d resources don't match the document bundle", drifted, total)
+	}
+	sts.Conditions = append(sts.Conditions, cond)
+}
+
+// resourceConditions returns conditions of the resource, conditions with invalid format are ignored
+func resourceConditions(live *unstructured.Unstructured) []airshipv1.StatusCondition {
+	conditions, _, err := unstructured.NestedSlice(live.Object, "status", "conditions")
+	if err != nil {
+		return nil
+	}
+	var res []airshipv1.StatusCondition
+	for _, c := range conditions {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cond := airshipv1.StatusCondition{}
+		cond.Type, _, _ = unstructured.NestedString(m, "type")
+		cond.Status, _, _ = unstructured.NestedString(m, "status")
+		cond.Reason, _, _ = unstructured.NestedString(m, "reason")
+		cond.Message, _, _ = unstructured.NestedString(m, "message")
+		if ts, _, _ := unstructured.NestedString(m, "lastTransitionTime"); ts != "" {
+			if t, parseErr := time.Parse(time.RFC3339, ts); parseErr == nil {
+				mt := metav1.NewTime(t)
+				cond.LastTransitionTime = &mt
+			}
+		}
+		if cond.Type != "" {
+			res = append(res, cond)
+		}
+	}
+	return res
+}
+
+// statusDocuments returns documents which are applied to kubernetes, along with namespace and id of the
+// inventory object, the inventory document is not returned if it's defined in the bundle
+func (e *KubeApplierExecutor) statusDocuments() ([]document.Document, string, string, error) {
+	filteredBundle, err := e.ExecutorBundle.SelectBundle(document.NewDeployToK8sSelector())
+	if err != nil {
+		return nil, "", "", err
+	}
+	allDocs, err := filteredBundle.GetAllDocuments()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	invNamespace, invID := inventoryNamespacePrefix+e.inventoryName(), e.inventoryName()
+	docs := make([]document.Document, 0, len(allDocs))
+	for _, doc := range allDocs {
+		if id, found := doc.GetLabels()[inventoryLabel]; found {
+			invNamespace, invID = doc.GetNamespace(), id
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs, invNamespace, invID, nil
+}
+
+// inventoryObjects returns references to the objects stored in the cli-utils inventory, empty list is returned
+// if the inventory doesn't exist i.e. the phase has never been applied
+func (e *KubeApplierExecutor) inventoryObjects(client dynamic.Interface, namespace, id string) ([]objectKey, error) {
+	cmResource := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	list, err := client.Resource(cmResource).Namespace(namespace).List(context.Background(),
+		metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", inventoryLabel, id)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		log.Printf("inventory '%s' is not found in namespace '%s'\n", id, namespace)
+		return nil, nil
+	}
+
+	inv := list.Items[0]
+	data, _, err := unstructured.NestedStringMap(inv.Object, "data")
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]string, 0, len(data))
+	for ref := range data {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	keys := make([]objectKey, 0, len(refs))
+	for _, ref := range refs {
+		key, parseErr := parseInventoryKey(inv.GetNamespace()+"/"+inv.GetName(), ref)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// resourceStatus returns status of the kubernetes resource, doc is the document from the bundle used to
+// detect drift and may be nil, namespace of the key is defaulted if the resource is namespaced
+func resourceStatus(client dynamic.Interface, mapper meta.RESTMapper, key *objectKey,
+	doc document.Document) airshipv1.ResourceStatus {
+	rs := airshipv1.ResourceStatus{
+		Group:     key.Group,
+		Kind:      key.Kind,
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		State:     airshipv1.StatusUnknown,
+		Drift:     airshipv1.DriftNotInBundle,
+	}
+	if doc != nil {
+		rs.Drift = airshipv1.DriftMissing
+	}
+
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: key.Group, Kind: key.Kind})
+	switch {
+	case meta.IsNoMatchError(err):
+		// kind isn't served by the cluster e.g. the CRD is deleted, so the resource can't exist
+		rs.State = airshipv1.StatusNotFound
+		rs.Message = err.Error()
+		return rs
+	case err != nil:
+		rs.Message = err.Error()
+		return rs
+	}
+
+	ri := client.Resource(mapping.Resource)
+	var live *unstructured.Unstructured
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if key.Namespace == "" {
+			key.Namespace = defaultNamespace
+			rs.Namespace = defaultNamespace
+		}
+		live, err = ri.Namespace(key.Namespace).Get(context.Background(), key.Name, metav1.GetOptions{})
+	} else {
+		live, err = ri.Get(context.Background(), key.Name, metav1.GetOptions{})
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		rs.State = airshipv1.StatusNotFound
+		rs.Message = "Resource not found"
+		return rs
+	case err != nil:
+		rs.Message = err.Error()
+		return rs
+	}
+
+	rs.Exists = true
+	res := kstatus.Compute(live)
+	rs.State, rs.Message = airshipv1.StatusState(res.Status), res.Message
+	rs.Conditions = resourceConditions(live)
+	if doc != nil {
+		rs.Drift, err = drift(doc, live)
+		if err != nil {
+			rs.Message = err.Error()
+		}
+	}
+	return rs
+}
+
+// drift checks if every field defined in the document matches the live resource, status and
+// fields which are set by the cluster only are ignored
+func drift(doc document.Document, live *unstructured.Unstructured) (airshipv1.DriftStatus, error) {
+	desired, err := normalizedObject(doc)
+	if err != nil {
+		return airshipv1.DriftDrifted, err
+	}
+	actual, err := normalizedObject(live.Object)
+	if err != nil {
+		return airshipv1.DriftDrifted, err
+	}
+
+	delete(desired, "status")
+	if md, ok := desired["metadata"].(map[string]interface{}); ok {
+		delete(md, "namespace")
+	}
+	if isSubset(desired, actual) {
+		return airshipv1.DriftInSync, nil
+	}
+	return airshipv1.DriftDrifted, nil
+}
+
+// normalizedObject converts object to the generic map through JSON, so numbers of the
+// document and of the live object are represented the same way
+func normalizedObject(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]interface{}{}
+	return res, json.Unmarshal(data, &res)
+}
+
+func isSubset(desired, actual interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return len(d) == 0 && actual == nil
+		}
+		for k, v := range d {
+			if !isSubset(v, a[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(d) {
+			return len(d) == 0 && actual == nil
+		}
+		for i := range d {
+			if !isSubset(d[i], a[i]) {
+				return false
+			}
+		}
+		return true
+	case nil:
+		return true
+	default:
+		return reflect.DeepEqual(desired, actual)
+	}
+}
@@ -0,0 +1,356 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	statusBundle = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed-map
+  namespace: test
+data:
+  key: value
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deployment
+  namespace: test
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: test
+        image: test:latest
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: missing-secret
+  namespace: test
+`
+	liveInventory = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: inventory-1234
+  namespace: airshipit-status-phase
+  labels:
+    cli-utils.sigs.k8s.io/inventory-id: status-phase
+data:
+  test_changed-map__ConfigMap: ""
+  test_test-deployment_apps_Deployment: ""
+  _airshipit-status-phase__Namespace: ""
+  test_pruned-map__ConfigMap: ""
+`
+	liveChangedMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed-map
+  namespace: test
+data:
+  key: other-value
+`
+	liveDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deployment
+  namespace: test
+  generation: 1
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: test
+        image: test:latest
+status:
+  observedGeneration: 1
+  replicas: 1
+  updatedReplicas: 1
+  readyReplicas: 1
+  availableReplicas: 1
+`
+	liveNamespace = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: airshipit-status-phase
+`
+	livePrunedMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: pruned-map
+  namespace: test
+`
+	invalidInventory = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: inventory-1234
+  namespace: airshipit-status-phase
+  labels:
+    cli-utils.sigs.k8s.io/inventory-id: status-phase
+data:
+  invalid: ""
+`
+)
+
+func testRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}, {Group: "apps", Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	return mapper
+}
+
+func testDynamicClientFunc(t *testing.T, objs ...string) utils.DynamicClientFunc {
+	runtimeObjs := make([]runtime.Object, 0, len(objs))
+	for _, obj := range objs {
+		m := map[string]interface{}{}
+		require.NoError(t, yaml.Unmarshal([]byte(obj), &m))
+		runtimeObjs = append(runtimeObjs, &unstructured.Unstructured{Object: m})
+	}
+	// inventory is looked up by listing config maps, so their list kind must be known even if there are none
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMapList"},
+		&unstructured.UnstructuredList{})
+	listKinds := map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}
+	return func(_, _ string) (dynamic.Interface, meta.RESTMapper, error) {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, runtimeObjs...),
+			testRESTMapper(), nil
+	}
+}
+
+func TestKubeApplierExecutorStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		containsErr       string
+		dynamicClientFunc utils.DynamicClientFunc
+		expectedState     v1alpha1.StatusState
+		expectedMessage   string
+		expectedResources []v1alpha1.ResourceStatus
+	}{
+		{
+			name:        "unable to create client",
+			containsErr: "client error",
+			dynamicClientFunc: func(_, _ string) (dynamic.Interface, meta.RESTMapper, error) {
+				return nil, nil, errors.New("client error")
+			},
+		},
+		{
+			name:              "invalid inventory",
+			containsErr:       "contains invalid object reference 'invalid'",
+			dynamicClientFunc: testDynamicClientFunc(t, invalidInventory),
+		},
+		{
+			name:              "inventory not found",
+			dynamicClientFunc: testDynamicClientFunc(t),
+			expectedState:     v1alpha1.StatusNotFound,
+			expectedMessage:   "Resources are not deployed",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Kind:      "ConfigMap",
+					Namespace: "test",
+					Name:      "changed-map",
+					State:     v1alpha1.StatusNotFound,
+					Message:   "Resource not found",
+					Drift:     v1alpha1.DriftMissing,
+				},
+				{
+					Group:     "apps",
+					Kind:      "Deployment",
+					Namespace: "test",
+					Name:      "test-deployment",
+					State:     v1alpha1.StatusNotFound,
+					Message:   "Resource not found",
+					Drift:     v1alpha1.DriftMissing,
+				},
+				{
+					Kind:      "Secret",
+					Namespace: "test",
+					Name:      "missing-secret",
+					State:     v1alpha1.StatusNotFound,
+					Message:   "Resource not found",
+					Drift:     v1alpha1.DriftMissing,
+				},
+			},
+		},
+		{
+			name: "inventory objects are reported",
+			dynamicClientFunc: testDynamicClientFunc(t, liveInventory, liveChangedMap, liveDeployment,
+				liveNamespace, livePrunedMap),
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "4 of 5 resources are current",
+			expectedResources: []v1alpha1.ResourceStatus{
+				{
+					Kind:      "ConfigMap",
+					Namespace: "test",
+					Name:      "changed-map",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Resource is current",
+					Drift:     v1alpha1.DriftDrifted,
+				},
+				{
+					Group:     "apps",
+					Kind:      "Deployment",
+					Namespace: "test",
+					Name:      "test-deployment",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Deployment is available. Replicas: 1",
+					Drift:     v1alpha1.DriftInSync,
+				},
+				{
+					Kind:      "Secret",
+					Namespace: "test",
+					Name:      "missing-secret",
+					State:     v1alpha1.StatusNotFound,
+					Message:   "Resource not found",
+					Drift:     v1alpha1.DriftMissing,
+				},
+				{
+					Kind:    "Namespace",
+					Name:    "airshipit-status-phase",
+					Exists:  true,
+					State:   v1alpha1.StatusCurrent,
+					Message: "Resource is current",
+					Drift:   v1alpha1.DriftInSync,
+				},
+				{
+					Kind:      "ConfigMap",
+					Namespace: "test",
+					Name:      "pruned-map",
+					Exists:    true,
+					State:     v1alpha1.StatusCurrent,
+					Message:   "Resource is current",
+					Drift:     v1alpha1.DriftNotInBundle,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			exec, err := executors.NewKubeApplierExecutor(
+				ifc.ExecutorConfig{
+					PhaseName:        "status-phase",
+					ExecutorDocument: executorDoc(t, ValidExecutorDoc),
+					BundleFactory: func() (document.Bundle, error) {
+						return document.NewBundleFromBytes([]byte(statusBundle))
+					},
+					KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+						return "kubeconfig", func() {}, nil
+					}},
+					ClusterMap:        testClusterMap(t),
+					ClusterName:       "testCluster",
+					PhaseConfigBundle: executorBundle(t, applierKRMDoc),
+					DynamicClientFunc: tt.dynamicClientFunc,
+				})
+			require.NoError(t, err)
+
+			sts, err := exec.Status()
+			if tt.containsErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.containsErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResources, sts.Resources)
+			assert.Equal(t, tt.expectedState, sts.State)
+			assert.Equal(t, tt.expectedMessage, sts.Message)
+			require.Len(t, sts.Conditions, 1)
+			assert.Equal(t, "Drifted", sts.Conditions[0].Type)
+			assert.Equal(t, "True", sts.Conditions[0].Status)
+		})
+	}
+}
+
+func TestKubeApplierExecutorDeleteStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		dynamicClientFunc utils.DynamicClientFunc
+		expectedState     v1alpha1.StatusState
+		expectedMessage   string
+	}{
+		{
+			name: "resources are being deleted",
+			dynamicClientFunc: testDynamicClientFunc(t, liveInventory, liveChangedMap, liveDeployment,
+				liveNamespace),
+			expectedState:   v1alpha1.StatusInProgress,
+			expectedMessage: "2 of 5 resources are deleted",
+		},
+		{
+			name:              "resources are deleted",
+			dynamicClientFunc: testDynamicClientFunc(t),
+			expectedState:     v1alpha1.StatusCurrent,
+			expectedMessage:   "All resources are deleted",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			exec, err := executors.NewKubeApplierExecutor(
+				ifc.ExecutorConfig{
+					PhaseName:        "delete-phase",
+					ExecutorDocument: executorDoc(t, deleteExecutorDoc),
+					BundleFactory: func() (document.Bundle, error) {
+						return document.NewBundleFromBytes([]byte(statusBundle))
+					},
+					KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+						return "kubeconfig", func() {}, nil
+					}},
+					ClusterMap:        testClusterMap(t),
+					ClusterName:       "testCluster",
+					PhaseConfigBundle: executorBundle(t, applierKRMDoc),
+					DynamicClientFunc: tt.dynamicClientFunc,
+				})
+			require.NoError(t, err)
+
+			sts, err := exec.Status()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedState, sts.State)
+			assert.Equal(t, tt.expectedMessage, sts.Message)
+			assert.Empty(t, sts.Conditions)
+
+			description, err := exec.Describe()
+			require.NoError(t, err)
+			assert.Equal(t, "Deletes resources stored in inventory 'airshipit-status-phase/status-phase' "+
+				"from cluster 'testCluster' in reverse dependency order\nWait timeout: 600s\n", description)
+		})
+	}
+}
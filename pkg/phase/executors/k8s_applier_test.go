@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +31,7 @@ import (
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/fs"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/executors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 	testdoc "opendev.org/airship/airshipctl/testutil/document"
@@ -48,6 +50,18 @@ config:
     timeout: 600
   pruneOptions:
     prune: false
+`
+	deleteExecutorDoc = `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  labels:
+    airshipit.org/deploy-k8s: "false"
+  name: kubernetes-delete
+config:
+  action: delete
+  phaseName: status-phase
+  waitOptions:
+    timeout: 600
 `
 	WrongExecutorDoc = `apiVersion: v1
 kind: ConfigMap
@@ -158,6 +172,12 @@ func testApplierBundleFactoryAllDocuments() document.BundleFactoryFunc {
 	}
 }
 
+func testApplierBundleFactoryNoDocumentEntryPoint() document.BundleFactoryFunc {
+	return func() (document.Bundle, error) {
+		return nil, phaseerrors.ErrDocumentEntrypointNotDefined{PhaseName: "status-phase"}
+	}
+}
+
 func TestNewKubeApplierExecutor(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -190,6 +210,19 @@ func TestNewKubeApplierExecutor(t *testing.T) {
 			bundleFactory: testdoc.EmptyBundleFactory,
 			phaseBundle:   executorBundle(t, applierKRMDoc),
 		},
+		{
+			name:          "delete action without document entry point",
+			execDoc:       executorDoc(t, deleteExecutorDoc),
+			bundleFactory: testApplierBundleFactoryNoDocumentEntryPoint(),
+			phaseBundle:   executorBundle(t, applierKRMDoc),
+		},
+		{
+			name:          "apply action without document entry point",
+			execDoc:       executorDoc(t, ValidExecutorDoc),
+			bundleFactory: testApplierBundleFactoryNoDocumentEntryPoint(),
+			phaseBundle:   executorBundle(t, applierKRMDoc),
+			expectedErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -301,6 +334,19 @@ func TestKubeApplierExecutorRun(t *testing.T) {
 				}}
 			},
 		},
+		{
+			name:          "unknown action",
+			containsErr:   "unknown action type 'destroy' was requested from executor 'kubernetes-apply'",
+			bundleFactory: testApplierBundleFactoryNoError(),
+			kubeconf:      testKubeconfig("kubeconfig"),
+			execDoc:       executorDoc(t, strings.Replace(deleteExecutorDoc, "action: delete", "action: destroy", 1)),
+			clusterName:   "ephemeral-cluster",
+			clusterMap: clustermap.NewClusterMap(&v1alpha1.ClusterMap{
+				Map: map[string]*v1alpha1.Cluster{
+					"ephemeral-cluster": {},
+				},
+			}),
+		},
 		{
 			name:          "successful run",
 			containsErr:   "",
@@ -387,6 +433,7 @@ func TestKubeApplierExecutor_Validate(t *testing.T) {
 		name          string
 		bundleFactory document.BundleFactoryFunc
 		bundleName    string
+		execDoc       string
 		wantErr       bool
 	}{
 		{
@@ -406,14 +453,31 @@ func TestKubeApplierExecutor_Validate(t *testing.T) {
 			bundleFactory: testApplierBundleFactoryAllDocuments(),
 			wantErr:       false,
 		},
+		{
+			name:          "Success delete without documents",
+			bundleName:    "some name",
+			bundleFactory: testApplierBundleFactoryEmptyAllDocuments(),
+			execDoc:       deleteExecutorDoc,
+			wantErr:       false,
+		},
+		{
+			name:          "Error unknown action",
+			bundleName:    "some name",
+			bundleFactory: testApplierBundleFactoryAllDocuments(),
+			execDoc:       strings.Replace(deleteExecutorDoc, "action: delete", "action: destroy", 1),
+			wantErr:       true,
+		},
 	}
 	for _, test := range tests {
 		tt := test
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.execDoc == "" {
+				tt.execDoc = ValidExecutorDoc
+			}
 			e, err := executors.NewKubeApplierExecutor(ifc.ExecutorConfig{
 				BundleFactory:     tt.bundleFactory,
 				PhaseName:         tt.bundleName,
-				ExecutorDocument:  executorDoc(t, ValidExecutorDoc),
+				ExecutorDocument:  executorDoc(t, tt.execDoc),
 				PhaseConfigBundle: executorBundle(t, applierKRMDoc),
 			})
 			require.NoError(t, err)
@@ -0,0 +1,144 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+// Registration describes an executor that can be referenced by phases
+type Registration struct {
+	// Name identifies the executor, it must be unique
+	Name string
+	// Object is an instance of the executor document API type, kind of the object identifies the executor
+	Object runtime.Object
+	// AddToScheme adds API types of the executor to the airshipctl scheme, it's required if
+	// the executor document type isn't a part of airshipctl API
+	AddToScheme func(*runtime.Scheme) error
+	// Factory creates the executor for the phase
+	Factory ifc.ExecutorFactory
+}
+
+type registration struct {
+	name    string
+	gvk     schema.GroupVersionKind
+	factory ifc.ExecutorFactory
+}
+
+var (
+	registryMu sync.RWMutex
+	// registrations are kept in the order of registration
+	registrations []registration
+)
+
+func init() {
+	for _, r := range []Registration{
+		{Name: Clusterctl, Object: &airshipv1.Clusterctl{}, Factory: NewClusterctlExecutor},
+		{Name: KubernetesApply, Object: &airshipv1.KubernetesApply{}, Factory: NewKubeApplierExecutor},
+		{Name: GenericContainer, Object: airshipv1.DefaultGenericContainer(), Factory: NewContainerExecutor},
+		{Name: Ephemeral, Object: airshipv1.DefaultBootConfiguration(), Factory: NewEphemeralExecutor},
+		{Name: BMHManager, Object: &airshipv1.BaremetalManager{}, Factory: NewBaremetalExecutor},
+		{Name: ExecPlugin, Object: &airshipv1.ExecPlugin{}, Factory: NewExecPluginExecutor},
+		{Name: Helm, Object: airshipv1.DefaultHelmRelease(), Factory: NewHelmExecutor},
+		{Name: Wait, Object: airshipv1.DefaultWait(), Factory: NewWaitExecutor},
+	} {
+		MustRegister(r)
+	}
+}
+
+// Register makes the executor available to phases. Executors implemented out of the airshipctl tree
+// are usually registered from init function of their package, for example
+//
+//	func init() {
+//		executors.MustRegister(executors.Registration{
+//			Name:        "site-dns",
+//			Object:      &sitev1.DNSExecutor{},
+//			AddToScheme: sitev1.AddToScheme,
+//			Factory:     NewDNSExecutor,
+//		})
+//	}
+func Register(r Registration) error {
+	if r.Name == "" || r.Object == nil || r.Factory == nil {
+		return errors.ErrExecutorRegistration{
+			ExecutorName: r.Name,
+			Err:          fmt.Errorf("name, object and factory of the executor must be set"),
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if r.AddToScheme != nil {
+		if err := r.AddToScheme(airshipv1.Scheme); err != nil {
+			return errors.ErrExecutorRegistration{ExecutorName: r.Name, Err: err}
+		}
+	}
+	gvks, _, err := airshipv1.Scheme.ObjectKinds(r.Object)
+	if err != nil {
+		return errors.ErrExecutorRegistration{ExecutorName: r.Name, Err: err}
+	}
+
+	for _, existing := range registrations {
+		if existing.name == r.Name || existing.gvk == gvks[0] {
+			return errors.ErrExecutorRegistration{
+				ExecutorName: r.Name,
+				Err:          errors.ErrExecutorAlreadyRegistered{ExecutorName: existing.name, GVK: existing.gvk},
+			}
+		}
+	}
+	registrations = append(registrations, registration{name: r.Name, gvk: gvks[0], factory: r.Factory})
+	return nil
+}
+
+// MustRegister registers the executor and panics if registration fails
+func MustRegister(r Registration) {
+	if err := Register(r); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterExecutor adds executor registered with the given name to phase executor registry
+func RegisterExecutor(executorName string, registry map[schema.GroupVersionKind]ifc.ExecutorFactory) error {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, r := range registrations {
+		if r.name == executorName {
+			registry[r.gvk] = r.factory
+			return nil
+		}
+	}
+	return errors.ErrUnknownExecutorName{ExecutorName: executorName}
+}
+
+// Registry returns factories of all registered executors by kind of the executor document
+func Registry() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	registry := make(map[schema.GroupVersionKind]ifc.ExecutorFactory, len(registrations))
+	for _, r := range registrations {
+		registry[r.gvk] = r.factory
+	}
+	return registry
+}
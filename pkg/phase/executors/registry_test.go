@@ -0,0 +1,149 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+var siteDNSGVK = schema.GroupVersionKind{Group: "site.example.com", Version: "v1", Kind: "SiteDNS"}
+
+// siteDNS is an executor document of an out-of-tree executor
+type siteDNS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+func (d *siteDNS) DeepCopyObject() runtime.Object {
+	out := *d
+	d.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+// unregisteredDoc is an executor document which kind is unknown to the scheme
+type unregisteredDoc struct {
+	siteDNS
+}
+
+func addSiteDNSToScheme(s *runtime.Scheme) error {
+	s.AddKnownTypeWithName(siteDNSGVK, &siteDNS{})
+	return nil
+}
+
+func fakeFactory(_ ifc.ExecutorConfig) (ifc.Executor, error) {
+	return nil, nil
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name         string
+		registration executors.Registration
+		expectedErr  string
+	}{
+		{
+			name:         "error factory is not set",
+			registration: executors.Registration{Name: "no-factory", Object: &unregisteredDoc{}},
+			expectedErr:  "name, object and factory of the executor must be set",
+		},
+		{
+			name: "error kind is unknown",
+			registration: executors.Registration{
+				Name:    "unknown-kind",
+				Object:  &unregisteredDoc{},
+				Factory: fakeFactory,
+			},
+			expectedErr: "failed to register executor unknown-kind",
+		},
+		{
+			name: "error add to scheme",
+			registration: executors.Registration{
+				Name:        "broken-scheme",
+				Object:      &unregisteredDoc{},
+				AddToScheme: func(_ *runtime.Scheme) error { return fmt.Errorf("scheme error") },
+				Factory:     fakeFactory,
+			},
+			expectedErr: "scheme error",
+		},
+		{
+			name: "error name is taken",
+			registration: executors.Registration{
+				Name:    executors.Clusterctl,
+				Object:  &v1alpha1.Phase{},
+				Factory: fakeFactory,
+			},
+			expectedErr: "executor 'clusterctl' is already registered for 'airshipit.org/v1alpha1, Kind=Clusterctl'",
+		},
+		{
+			name: "error kind is taken",
+			registration: executors.Registration{
+				Name:    "another-container",
+				Object:  v1alpha1.DefaultGenericContainer(),
+				Factory: fakeFactory,
+			},
+			expectedErr: "executor 'generic-container' is already registered for " +
+				"'airshipit.org/v1alpha1, Kind=GenericContainer'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := executors.Register(tt.registration)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestRegisterOutOfTreeExecutor(t *testing.T) {
+	executors.MustRegister(executors.Registration{
+		Name:        "site-dns",
+		Object:      &siteDNS{},
+		AddToScheme: addSiteDNSToScheme,
+		Factory:     fakeFactory,
+	})
+
+	_, found := executors.Registry()[siteDNSGVK]
+	assert.True(t, found)
+
+	registry := make(map[schema.GroupVersionKind]ifc.ExecutorFactory)
+	require.NoError(t, executors.RegisterExecutor("site-dns", registry))
+	_, found = registry[siteDNSGVK]
+	assert.True(t, found)
+
+	assert.Panics(t, func() {
+		executors.MustRegister(executors.Registration{Name: "site-dns", Object: &siteDNS{}, Factory: fakeFactory})
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	registry := executors.Registry()
+	for _, kind := range []string{"Clusterctl", "KubernetesApply", "GenericContainer", "BootConfiguration",
+		"BaremetalManager", "ExecPlugin", "HelmRelease", "Wait"} {
+		_, found := registry[v1alpha1.GroupVersion.WithKind(kind)]
+		assert.True(t, found, kind)
+	}
+}
@@ -0,0 +1,362 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
+	"opendev.org/airship/airshipctl/pkg/k8s/kstatus"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
+	"opendev.org/airship/airshipctl/pkg/log"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+var _ ifc.Executor = &WaitExecutor{}
+
+// WaitExecutor waits for kubernetes resources of the phase cluster to reach the desired state
+type WaitExecutor struct {
+	wait        *airshipv1.Wait
+	clusterName string
+	clusterMap  clustermap.ClusterMap
+	kubecfg     kubeconfig.Interface
+
+	dynamicClientFunc utils.DynamicClientFunc
+}
+
+// NewWaitExecutor creates instance of wait executor
+func NewWaitExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+	wait := airshipv1.DefaultWait()
+	if err := cfg.ExecutorDocument.ToAPIObject(wait, airshipv1.Scheme); err != nil {
+		return nil, err
+	}
+
+	dynamicClientFunc := utils.DynamicClientFromKubeConfig
+	if cfg.DynamicClientFunc != nil {
+		dynamicClientFunc = cfg.DynamicClientFunc
+	}
+
+	return &WaitExecutor{
+		wait:        wait,
+		clusterName: cfg.ClusterName,
+		clusterMap:  cfg.ClusterMap,
+		kubecfg:     cfg.KubeConfig,
+
+		dynamicClientFunc: dynamicClientFunc,
+	}, nil
+}
+
+// Run polls the selected resources until all of them reach the desired state or the timeout expires,
+// changes of the resource states are written to the output
+func (e *WaitExecutor) Run(opts ifc.RunOptions) error {
+	log.Printf("starting wait executor %s", e.wait.Name)
+	if opts.DryRun {
+		log.Printf("dry run, skipping wait for %d resource selectors", len(e.wait.Spec.Resources))
+		return nil
+	}
+
+	timeout := time.Duration(e.wait.Spec.Timeout) * time.Second
+	if opts.Timeout != nil {
+		timeout = *opts.Timeout
+	}
+	interval := time.Duration(e.wait.Spec.PollInterval) * time.Second
+
+	client, mapper, cleanup, err := e.clusterClient()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	out := outputWriter(opts)
+	deadline := time.Now().Add(timeout)
+	reported := map[string]airshipv1.StatusState{}
+	for {
+		sts := e.check(client, mapper)
+		if err = reportProgress(out, sts, reported); err != nil {
+			return err
+		}
+		if sts.State == airshipv1.StatusCurrent {
+			log.Printf("wait %s completed: %s", e.wait.Name, sts.Message)
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return errors.ErrWaitTimeout{Name: e.wait.Name, Timeout: timeout, Message: sts.Message}
+		}
+		if remaining < interval {
+			time.Sleep(remaining)
+		} else {
+			time.Sleep(interval)
+		}
+	}
+}
+
+// Render doesn't render anything, the executor has no documents
+func (e *WaitExecutor) Render(w io.Writer, _ ifc.RenderOptions) error {
+	_, err := w.Write([]byte{})
+	return err
+}
+
+// Validate checks that resource selectors, jsonpath conditions and intervals are valid
+func (e *WaitExecutor) Validate() error {
+	spec := e.wait.Spec
+	switch {
+	case len(spec.Resources) == 0:
+		return e.errInvalid("no resources to wait for")
+	case spec.Timeout <= 0:
+		return e.errInvalid("timeout must be positive")
+	case spec.PollInterval <= 0:
+		return e.errInvalid("poll interval must be positive")
+	}
+
+	for _, res := range spec.Resources {
+		if res.APIVersion == "" || res.Kind == "" {
+			return e.errInvalid("apiVersion and kind of the resource must be set")
+		}
+		if res.Name != "" && res.LabelSelector != "" {
+			return e.errInvalid(fmt.Sprintf("%s '%s' has both name and label selector", res.Kind, res.Name))
+		}
+		if _, err := labels.Parse(res.LabelSelector); err != nil {
+			return e.errInvalid(fmt.Sprintf("invalid label selector '%s': %v", res.LabelSelector, err))
+		}
+		for _, c := range res.Conditions {
+			if err := jsonpath.New("validate").Parse(c.JSONPath); err != nil {
+				return e.errInvalid(fmt.Sprintf("invalid jsonpath '%s': %v", c.JSONPath, err))
+			}
+		}
+	}
+	return nil
+}
+
+// Status checks the selected resources once and reports their states
+func (e *WaitExecutor) Status() (ifc.ExecutorStatus, error) {
+	client, mapper, cleanup, err := e.clusterClient()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	defer cleanup()
+	return e.check(client, mapper), nil
+}
+
+// Describe returns the resources to wait for along with their conditions
+func (e *WaitExecutor) Describe() (string, error) {
+	spec := e.wait.Spec
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Waits up to %ds for resources of cluster '%s' checking every %ds:\n",
+		spec.Timeout, e.clusterName, spec.PollInterval)
+	for _, res := range spec.Resources {
+		fmt.Fprintf(sb, "  %s is current", describeWaitResource(res))
+		for _, c := range res.Conditions {
+			if c.Value == "" {
+				fmt.Fprintf(sb, " and %s exists", c.JSONPath)
+				continue
+			}
+			fmt.Fprintf(sb, " and %s is '%s'", c.JSONPath, c.Value)
+		}
+		fmt.Fprintln(sb)
+	}
+	return sb.String(), nil
+}
+
+func describeWaitResource(res airshipv1.WaitResource) string {
+	name := res.Name
+	if res.Namespace != "" && name != "" {
+		name = res.Namespace + "/" + name
+	}
+	switch {
+	case name != "":
+		return fmt.Sprintf("%s %s", res.Kind, name)
+	case res.LabelSelector != "":
+		return fmt.Sprintf("every %s with labels '%s'", res.Kind, res.LabelSelector)
+	default:
+		return fmt.Sprintf("every %s", res.Kind)
+	}
+}
+
+// check returns states of all selected resources
+func (e *WaitExecutor) check(client dynamic.Interface, mapper meta.RESTMapper) ifc.ExecutorStatus {
+	sts := ifc.ExecutorStatus{}
+	for _, res := range e.wait.Spec.Resources {
+		sts.Resources = append(sts.Resources, waitResourceStatuses(client, mapper, res)...)
+	}
+	summarizeStates(&sts)
+	return sts
+}
+
+// waitResourceStatuses returns states of the resources matching the selector, selector which doesn't match any
+// resource is reported as a single resource which is not found
+func waitResourceStatuses(client dynamic.Interface, mapper meta.RESTMapper,
+	res airshipv1.WaitResource) []airshipv1.ResourceStatus {
+	gvk := res.GroupVersionKind()
+	selector := airshipv1.ResourceStatus{
+		Group:     gvk.Group,
+		Kind:      gvk.Kind,
+		Namespace: res.Namespace,
+		Name:      res.Name,
+	}
+
+	objs, err := selectWaitObjects(client, mapper, res)
+	switch {
+	case err != nil:
+		selector.State, selector.Message = airshipv1.StatusUnknown, err.Error()
+		return []airshipv1.ResourceStatus{selector}
+	case len(objs) == 0 && res.Name != "":
+		selector.State, selector.Message = airshipv1.StatusNotFound, "Resource not found"
+		return []airshipv1.ResourceStatus{selector}
+	case len(objs) == 0:
+		selector.State, selector.Message = airshipv1.StatusNotFound, "No resources found"
+		if res.LabelSelector != "" {
+			selector.Message = fmt.Sprintf("No resources found with labels '%s'", res.LabelSelector)
+		}
+		return []airshipv1.ResourceStatus{selector}
+	}
+
+	statuses := make([]airshipv1.ResourceStatus, 0, len(objs))
+	for i := range objs {
+		rs := airshipv1.ResourceStatus{
+			Group:      gvk.Group,
+			Kind:       gvk.Kind,
+			Namespace:  objs[i].GetNamespace(),
+			Name:       objs[i].GetName(),
+			Exists:     true,
+			Conditions: resourceConditions(&objs[i]),
+		}
+		rs.State, rs.Message = waitObjectState(&objs[i], res.Conditions)
+		statuses = append(statuses, rs)
+	}
+	return statuses
+}
+
+// selectWaitObjects returns the resource by its name or resources matching the label selector, nothing is
+// returned if the kind isn't served by the cluster yet
+func selectWaitObjects(client dynamic.Interface, mapper meta.RESTMapper,
+	res airshipv1.WaitResource) ([]unstructured.Unstructured, error) {
+	gvk := res.GroupVersionKind()
+	if res.Name == "" {
+		return utils.ListObjects(client, mapper, gvk.GroupKind(), res.Namespace, res.LabelSelector)
+	}
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ri dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := res.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		ri = client.Resource(mapping.Resource).Namespace(namespace)
+	}
+	obj, err := ri.Get(context.Background(), res.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []unstructured.Unstructured{*obj}, nil
+}
+
+// waitObjectState computes kstatus of the resource, conditions are checked once the resource is current
+func waitObjectState(obj *unstructured.Unstructured,
+	conditions []airshipv1.WaitCondition) (airshipv1.StatusState, string) {
+	res := kstatus.Compute(obj)
+	if res.Status != kstatus.CurrentStatus {
+		return airshipv1.StatusState(res.Status), res.Message
+	}
+
+	for _, c := range conditions {
+		expr := kstatus.Expression{Condition: c.JSONPath, Value: c.Value}
+		matched, err := expr.Match(obj.UnstructuredContent())
+		if err != nil {
+			return airshipv1.StatusUnknown, fmt.Sprintf("Unable to parse jsonpath '%s' in resource: %v", c.JSONPath, err)
+		}
+		if !matched {
+			return airshipv1.StatusInProgress,
+				fmt.Sprintf("Resource has not reached state '%s' at jsonpath '%s' yet", c.Value, c.JSONPath)
+		}
+	}
+	return airshipv1.StatusCurrent, res.Message
+}
+
+// reportProgress writes states of the resources which changed since the previous check
+func reportProgress(w io.Writer, sts ifc.ExecutorStatus, reported map[string]airshipv1.StatusState) error {
+	for _, rs := range sts.Resources {
+		resource := rs.Kind
+		switch {
+		case rs.Name != "" && rs.Namespace != "":
+			resource = fmt.Sprintf("%s %s/%s", rs.Kind, rs.Namespace, rs.Name)
+		case rs.Name != "":
+			resource = fmt.Sprintf("%s %s", rs.Kind, rs.Name)
+		case rs.Namespace != "":
+			resource = fmt.Sprintf("%s in namespace %s", rs.Kind, rs.Namespace)
+		}
+		key := rs.Group + "/" + resource
+		if state, found := reported[key]; found && state == rs.State {
+			continue
+		}
+		reported[key] = rs.State
+		if _, err := fmt.Fprintf(w, "%s is %s: %s\n", resource, rs.State, rs.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *WaitExecutor) clusterClient() (dynamic.Interface, meta.RESTMapper, func(), error) {
+	kubeConfigFile, cleanup, err := e.kubecfg.GetFile()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	kctx, err := e.clusterMap.ClusterKubeconfigContext(e.clusterName)
+	if err != nil {
+		cleanup()
+		return nil, nil, nil, err
+	}
+
+	client, mapper, err := e.dynamicClientFunc(kubeConfigFile, kctx)
+	if err != nil {
+		cleanup()
+		return nil, nil, nil, err
+	}
+	return client, mapper, cleanup, nil
+}
+
+func (e *WaitExecutor) errInvalid(reason string) error {
+	return phaseerrors.ErrInvalidPhase{Reason: fmt.Sprintf("Wait '%s': %s", e.wait.Name, reason)}
+}
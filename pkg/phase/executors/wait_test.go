@@ -0,0 +1,269 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	waitDoc = `apiVersion: airshipit.org/v1alpha1
+kind: Wait
+metadata:
+  name: wait-cluster
+spec:
+  timeout: 60
+  pollInterval: 1
+  resources:
+  - apiVersion: apps/v1
+    kind: Deployment
+    namespace: capi-system
+    labelSelector: cluster.x-k8s.io/provider=cluster-api
+  - apiVersion: cluster.x-k8s.io/v1alpha3
+    kind: Cluster
+    namespace: target-infra
+    name: target-cluster
+    conditions:
+    - jsonPath: "{.status.controlPlaneReady}"
+      value: "true"
+`
+	waitClusterTmpl = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Cluster
+metadata:
+  name: target-cluster
+  namespace: target-infra
+status:
+  controlPlaneReady: %t
+`
+)
+
+func newWaitExecutor(t *testing.T, doc string, objs ...string) ifc.Executor {
+	t.Helper()
+	executor, err := executors.NewWaitExecutor(ifc.ExecutorConfig{
+		ClusterName:      "target-cluster",
+		ExecutorDocument: executorDoc(t, doc),
+		ClusterMap: ClusterMapMockInterface{MockClusterKubeconfigContext: func(s string) (string, error) {
+			return s, nil
+		}},
+		KubeConfig: fakeKubeConfig{getFile: func() (string, kubeconfig.Cleanup, error) {
+			return "/tmp/kubeconfig", func() {}, nil
+		}},
+		DynamicClientFunc: testClusterClientFunc(t, map[string][]string{"target-cluster": objs}),
+	})
+	require.NoError(t, err)
+	return executor
+}
+
+func TestWaitExecutorRun(t *testing.T) {
+	timeout := 10 * time.Millisecond
+	tests := []struct {
+		name           string
+		objs           []string
+		dryRun         bool
+		expectedOutput string
+		expectedErr    error
+	}{
+		{
+			name: "success",
+			objs: []string{capiDeployment, fmt.Sprintf(waitClusterTmpl, true)},
+			expectedOutput: "Deployment capi-system/capi-controller-manager is Current: " +
+				"Deployment is available. Replicas: 1\n" +
+				"Cluster target-infra/target-cluster is Current: Resource is current\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+		},
+		{
+			name: "error condition is not met",
+			objs: []string{capiDeployment, fmt.Sprintf(waitClusterTmpl, false)},
+			expectedOutput: "Deployment capi-system/capi-controller-manager is Current: " +
+				"Deployment is available. Replicas: 1\n" +
+				"Cluster target-infra/target-cluster is InProgress: " +
+				"Resource has not reached state 'true' at jsonpath '{.status.controlPlaneReady}' yet\n",
+			expectedErr: errors.ErrWaitTimeout{
+				Name:    "wait-cluster",
+				Timeout: timeout,
+				Message: "1 of 2 resources are current",
+			},
+		},
+		{
+			name: "error resources are not found",
+			expectedOutput: "Deployment in namespace capi-system is NotFound: " +
+				"No resources found with labels 'cluster.x-k8s.io/provider=cluster-api'\n" +
+				"Cluster target-infra/target-cluster is NotFound: Resource not found\n",
+			expectedErr: errors.ErrWaitTimeout{
+				Name:    "wait-cluster",
+				Timeout: timeout,
+				Message: "Resources are not deployed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := newWaitExecutor(t, waitDoc, tt.objs...).Run(ifc.RunOptions{
+				DryRun:  tt.dryRun,
+				Timeout: &timeout,
+				Out:     out,
+			})
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestWaitExecutorStatus(t *testing.T) {
+	sts, err := newWaitExecutor(t, waitDoc, fmt.Sprintf(waitClusterTmpl, false)).Status()
+	require.NoError(t, err)
+	assert.Equal(t, ifc.ExecutorStatus{
+		State:   v1alpha1.StatusInProgress,
+		Message: "0 of 2 resources are current",
+		Resources: []v1alpha1.ResourceStatus{
+			{
+				Group:     "apps",
+				Kind:      "Deployment",
+				Namespace: "capi-system",
+				State:     v1alpha1.StatusNotFound,
+				Message:   "No resources found with labels 'cluster.x-k8s.io/provider=cluster-api'",
+			},
+			{
+				Group:     "cluster.x-k8s.io",
+				Kind:      "Cluster",
+				Namespace: "target-infra",
+				Name:      "target-cluster",
+				Exists:    true,
+				State:     v1alpha1.StatusInProgress,
+				Message:   "Resource has not reached state 'true' at jsonpath '{.status.controlPlaneReady}' yet",
+			},
+		},
+	}, sts)
+}
+
+func TestWaitExecutorValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		doc         string
+		expectedErr string
+	}{
+		{
+			name: "success",
+			doc:  waitDoc,
+		},
+		{
+			name: "error no resources",
+			doc: `apiVersion: airshipit.org/v1alpha1
+kind: Wait
+metadata:
+  name: wait-cluster
+spec:
+  resources: []
+`,
+			expectedErr: "Wait 'wait-cluster': no resources to wait for",
+		},
+		{
+			name: "error kind is not set",
+			doc: `apiVersion: airshipit.org/v1alpha1
+kind: Wait
+metadata:
+  name: wait-cluster
+spec:
+  resources:
+  - name: target-cluster
+`,
+			expectedErr: "apiVersion and kind of the resource must be set",
+		},
+		{
+			name: "error name and label selector",
+			doc: `apiVersion: airshipit.org/v1alpha1
+kind: Wait
+metadata:
+  name: wait-cluster
+spec:
+  resources:
+  - apiVersion: v1
+    kind: Node
+    name: node01
+    labelSelector: node-role.kubernetes.io/master
+`,
+			expectedErr: "Node 'node01' has both name and label selector",
+		},
+		{
+			name: "error invalid jsonpath",
+			doc: `apiVersion: airshipit.org/v1alpha1
+kind: Wait
+metadata:
+  name: wait-cluster
+spec:
+  resources:
+  - apiVersion: v1
+    kind: Node
+    conditions:
+    - jsonPath: "{.status["
+`,
+			expectedErr: "invalid jsonpath '{.status['",
+		},
+		{
+			name: "error invalid poll interval",
+			doc: `apiVersion: airshipit.org/v1alpha1
+kind: Wait
+metadata:
+  name: wait-cluster
+spec:
+  pollInterval: -1
+  resources:
+  - apiVersion: v1
+    kind: Node
+`,
+			expectedErr: "poll interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := newWaitExecutor(t, tt.doc).Validate()
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWaitExecutorDescribe(t *testing.T) {
+	description, err := newWaitExecutor(t, waitDoc).Describe()
+	require.NoError(t, err)
+	assert.Equal(t, "Waits up to 60s for resources of cluster 'target-cluster' checking every 1s:\n"+
+		"  every Deployment with labels 'cluster.x-k8s.io/provider=cluster-api' is current\n"+
+		"  Cluster target-infra/target-cluster is current and {.status.controlPlaneReady} is 'true'\n",
+		description)
+}
@@ -59,6 +59,7 @@ func TestHelperPhase(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "capi_init",
 				},
+				Description: "Initializes Cluster API providers",
 				Config: airshipv1.PhaseConfig{
 					ExecutorRef: &corev1.ObjectReference{
 						Kind:       "Clusterctl",
@@ -284,7 +285,7 @@ func TestHelperListPlans(t *testing.T) {
 	}{
 		{
 			name:        "Success plan list",
-			expectedLen: 5,
+			expectedLen: 12,
 			config:      testConfig,
 		},
 		{
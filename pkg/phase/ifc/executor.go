@@ -18,11 +18,13 @@ import (
 	"io"
 	"time"
 
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
 	inventoryifc "opendev.org/airship/airshipctl/pkg/inventory/ifc"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/utils"
 )
 
 // Executor interface should be implemented by each runner
@@ -31,21 +33,35 @@ type Executor interface {
 	Render(io.Writer, RenderOptions) error
 	Validate() error
 	Status() (ExecutorStatus, error)
+	// Describe returns human readable description of what the executor does when the phase is run
+	Describe() (string, error)
 }
 
-// ExecutorStatus is a struct which defines the status
-type ExecutorStatus struct{}
+// ExecutorStatus is a struct which defines the status reported by the executor
+type ExecutorStatus = v1alpha1.ExecutorStatus
 
 // RunOptions holds options for run method
 type RunOptions struct {
 	DryRun  bool
 	Timeout *time.Duration
+	// Out is a writer for the output produced by the executor, stdout is used if not set
+	Out io.Writer
+	// ImageRegistry if set makes the phase pull container images from this registry, e.g. a private
+	// mirror, the same way 'airshipctl phase render --image-registry' rewrites the image references
+	ImageRegistry string
 }
 
 // PlanRunOptions holds options for plan run method
 type PlanRunOptions struct {
 	RunOptions
 	ResumeFromPhase string
+	// Resume restarts plan execution from the first phase that didn't succeed during the last run
+	Resume bool
+	// Force allows to resume plan execution even if the plan or documents of completed phases
+	// have changed since the last run
+	Force bool
+	// MaxParallel limits the number of independent phases executed concurrently, 0 means no limit
+	MaxParallel int
 }
 
 // RenderOptions holds options for render method
@@ -70,4 +86,5 @@ type ExecutorConfig struct {
 	PhaseConfigBundle document.Bundle
 	Inventory         inventoryifc.Inventory
 	ContainerFunc     container.ClientV1Alpha1FactoryFunc
+	DynamicClientFunc utils.DynamicClientFunc
 }
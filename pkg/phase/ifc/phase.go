@@ -33,9 +33,7 @@ type Phase interface {
 }
 
 // PhaseStatus is a struct which defines status of phase
-type PhaseStatus struct {
-	ExecutorStatus ExecutorStatus
-}
+type PhaseStatus = v1alpha1.PhaseStatus
 
 // Plan provides a way to interact with phase plans
 type Plan interface {
@@ -48,7 +46,11 @@ type Plan interface {
 type StatusOptions struct{}
 
 // PlanStatus is a struct which defines status of PLAN
-type PlanStatus struct{}
+type PlanStatus struct {
+	// LastRun contains execution state recorded during the last plan run,
+	// it is nil if the plan has never been executed
+	LastRun *v1alpha1.PhasePlanRun
+}
 
 // ID uniquely identifies the phase
 type ID struct {
@@ -0,0 +1,191 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/document/images"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+// containerExecutorSelectors maps kinds of the executors running their tools in containers to the selectors
+// of the GenericContainer documents defining those containers
+var containerExecutorSelectors = map[string]func() document.Selector{
+	"Clusterctl":      document.NewClusterctlContainerExecutorSelector,
+	"KubernetesApply": document.NewApplierContainerExecutorSelector,
+	"HelmRelease":     document.NewHelmContainerExecutorSelector,
+}
+
+// ImagesFlags options for phase images command
+type ImagesFlags struct {
+	PhaseID ifc.ID
+	PlanID  ifc.ID
+	// ImageRegistry if set makes the command print the reference of the image in this registry
+	// next to every image reference copied to the registry
+	ImageRegistry string
+}
+
+// ImagesCommand phase images command
+type ImagesCommand struct {
+	Options ImagesFlags
+	Factory config.Factory
+	Writer  io.Writer
+}
+
+// RunE prints container images used by the phase or by all phases of the plan. Phases are rendered
+// from their document entry points without cluster access, executor documents are inspected as well
+func (c *ImagesCommand) RunE() error {
+	if (c.Options.PhaseID.Name == "") == (c.Options.PlanID.Name == "") {
+		return phaseerrors.ErrPhaseOrPlanNotSpecified{}
+	}
+
+	// images don't depend on secrets, so encrypted documents are rendered as is
+	os.Setenv("TOLERATE_DECRYPTION_FAILURES", "true")
+
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	phaseIDs := []ifc.ID{c.Options.PhaseID}
+	if c.Options.PlanID.Name != "" {
+		if phaseIDs, err = planPhaseIDs(helper, c.Options.PlanID); err != nil {
+			return err
+		}
+	}
+
+	client := NewClient(helper)
+	var docs []document.Document
+	for _, id := range phaseIDs {
+		var phaseDocs []document.Document
+		if phaseDocs, err = phaseDocuments(helper, client, id); err != nil {
+			return err
+		}
+		docs = append(docs, phaseDocs...)
+	}
+
+	refs, err := images.Collect(docs)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		if err = c.writeImage(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeImage prints the image reference followed by its reference in the image registry if it is set,
+// images that aren't copied to the registry are skipped in that case
+func (c *ImagesCommand) writeImage(ref string) error {
+	if c.Options.ImageRegistry == "" {
+		_, err := fmt.Fprintln(c.Writer, ref)
+		return err
+	}
+	mirrored := images.MirrorReference(ref, c.Options.ImageRegistry)
+	if mirrored == ref {
+		return nil
+	}
+	_, err := fmt.Fprintf(c.Writer, "%s %s\n", ref, mirrored)
+	return err
+}
+
+// planPhaseIDs returns phases of the plan along with its hook phases
+func planPhaseIDs(helper ifc.Helper, planID ifc.ID) ([]ifc.ID, error) {
+	planObj, err := helper.Plan(planID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []ifc.ID
+	for _, step := range planObj.Phases {
+		ids = append(ids, ifc.ID{Name: step.Name})
+	}
+	for _, name := range hookPhases(planObj) {
+		ids = append(ids, ifc.ID{Name: name})
+	}
+	return ids, nil
+}
+
+// phaseDocuments returns executor document of the phase and the document of the container the executor
+// runs, if any, along with the documents rendered from the phase document entry point, if the phase has one
+func phaseDocuments(helper ifc.Helper, client ifc.Client, id ifc.ID) ([]document.Document, error) {
+	phaseObj, err := helper.Phase(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []document.Document
+	if phaseObj.Config.ExecutorRef != nil {
+		var executorDoc document.Document
+		if executorDoc, err = helper.ExecutorDoc(id); err != nil {
+			return nil, err
+		}
+		docs = append(docs, executorDoc)
+
+		var containerDoc document.Document
+		if containerDoc, err = executorContainerDoc(helper, executorDoc); err != nil {
+			return nil, err
+		}
+		if containerDoc != nil {
+			docs = append(docs, containerDoc)
+		}
+	}
+
+	if phaseObj.Config.DocumentEntryPoint == "" {
+		return docs, nil
+	}
+
+	p, err := client.PhaseByAPIObj(phaseObj)
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.DocumentRoot()
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := document.NewBundleByPath(root)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := bundle.GetAllDocuments()
+	if err != nil {
+		return nil, err
+	}
+	return append(docs, rendered...), nil
+}
+
+// executorContainerDoc returns GenericContainer document from the phase config bundle that defines the container
+// used by the executor, nil is returned for executors that don't run containers
+func executorContainerDoc(helper ifc.Helper, executorDoc document.Document) (document.Document, error) {
+	selector, ok := containerExecutorSelectors[executorDoc.GetKind()]
+	if !ok || executorDoc.GetGroup() != v1alpha1.GroupVersion.Group {
+		return nil, nil
+	}
+	return helper.PhaseConfigBundle().SelectOne(selector())
+}
@@ -0,0 +1,137 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+)
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+// planGraph represents phase plan as a graph of its steps, steps are identified by their index within the plan
+type planGraph struct {
+	// deps contains indexes of the steps each step depends on
+	deps [][]int
+	// order contains indexes of the steps sorted so that every step goes after its dependencies
+	order []int
+	// concurrent is set when the plan defines dependencies, so independent steps may run concurrently,
+	// otherwise every step depends on the previous one
+	concurrent bool
+}
+
+// newPlanGraph builds graph of the phase plan steps and makes sure that phase dependencies are
+// defined properly and have no cycles
+func newPlanGraph(planObj *v1alpha1.PhasePlan) (*planGraph, error) {
+	g := &planGraph{deps: make([][]int, len(planObj.Phases))}
+	for _, step := range planObj.Phases {
+		if len(step.DependsOn) != 0 {
+			g.concurrent = true
+			break
+		}
+	}
+
+	if !g.concurrent {
+		for i := range planObj.Phases {
+			g.order = append(g.order, i)
+			if i > 0 {
+				g.deps[i] = []int{i - 1}
+			}
+		}
+		return g, nil
+	}
+
+	index := make(map[string]int, len(planObj.Phases))
+	for i, step := range planObj.Phases {
+		if _, exists := index[step.Name]; exists {
+			return nil, phaseerrors.ErrDuplicatePhaseInPlan{PlanName: planObj.Name, PhaseName: step.Name}
+		}
+		index[step.Name] = i
+	}
+	for i, step := range planObj.Phases {
+		for _, dep := range step.DependsOn {
+			j, exists := index[dep]
+			if !exists {
+				return nil, phaseerrors.ErrUnknownPhaseDependency{
+					PlanName:   planObj.Name,
+					PhaseName:  step.Name,
+					Dependency: dep,
+				}
+			}
+			g.deps[i] = append(g.deps[i], j)
+		}
+	}
+	return g, g.sort(planObj)
+}
+
+// sort orders steps so that every step goes after its dependencies using depth-first search,
+// error is returned if dependencies form a cycle
+func (g *planGraph) sort(planObj *v1alpha1.PhasePlan) error {
+	state := make([]int, len(g.deps))
+	var path []int
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			var cycle []string
+			for k := len(path) - 1; k >= 0; k-- {
+				if path[k] == i {
+					for _, j := range path[k:] {
+						cycle = append(cycle, planObj.Phases[j].Name)
+					}
+					break
+				}
+			}
+			cycle = append(cycle, planObj.Phases[i].Name)
+			return phaseerrors.ErrPhaseDependencyCycle{PlanName: planObj.Name, Cycle: cycle}
+		}
+
+		state[i] = visiting
+		path = append(path, i)
+		for _, dep := range g.deps[i] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		g.order = append(g.order, i)
+		return nil
+	}
+
+	for i := range g.deps {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ready returns true if all dependencies of the step are done
+func (g *planGraph) ready(i int, done []bool) bool {
+	for _, dep := range g.deps[i] {
+		if !done[dep] {
+			return false
+		}
+	}
+	return true
+}
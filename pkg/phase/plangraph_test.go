@@ -0,0 +1,218 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+// phaseTracker records execution of the phases by trackingExecutor
+type phaseTracker struct {
+	mu         sync.Mutex
+	executed   []string
+	running    int
+	maxRunning int
+	timeouts   map[string]*time.Duration
+	// failures is a number of failing attempts of the phase
+	failures map[string]int
+}
+
+func (t *phaseTracker) registry() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+	gvk := schema.GroupVersionKind{
+		Group:   "airshipit.org",
+		Version: "v1alpha1",
+		Kind:    "Clusterctl",
+	}
+	return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+		gvk: func(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+			return trackingExecutor{tracker: t, phaseName: cfg.PhaseName}, nil
+		},
+	}
+}
+
+type trackingExecutor struct {
+	fakeExecutor
+	tracker   *phaseTracker
+	phaseName string
+}
+
+func (e trackingExecutor) Run(ro ifc.RunOptions) error {
+	t := e.tracker
+	t.mu.Lock()
+	if t.timeouts == nil {
+		t.timeouts = map[string]*time.Duration{}
+	}
+	t.timeouts[e.phaseName] = ro.Timeout
+	t.running++
+	if t.running > t.maxRunning {
+		t.maxRunning = t.running
+	}
+	t.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.running--
+	t.executed = append(t.executed, e.phaseName)
+	if t.failures[e.phaseName] > 0 {
+		t.failures[e.phaseName]--
+		return fmt.Errorf("phase %s failed", e.phaseName)
+	}
+	return nil
+}
+
+// dagPlanDependencies duplicates dependencies of dag_plan defined in testdata
+var dagPlanDependencies = map[string][]string{
+	"isogen":       {"capi_init"},
+	"remotedirect": {"capi_init"},
+	"initinfra":    {"isogen", "remotedirect"},
+}
+
+func TestPlanDependenciesRun(t *testing.T) {
+	testCases := []struct {
+		name       string
+		runOptions ifc.PlanRunOptions
+		failures   map[string]int
+		// lastRun adjusts successful run of the plan recorded before the test one
+		lastRun            func(run *v1alpha1.PhasePlanRun)
+		errContains        string
+		expectedExecuted   []string
+		expectedMaxRunning int
+		expectedResults    []v1alpha1.PhaseRunResult
+	}{
+		{
+			name:               "Independent phases run concurrently",
+			expectedExecuted:   []string{"capi_init", "isogen", "remotedirect", "initinfra"},
+			expectedMaxRunning: 2,
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+			},
+		},
+		{
+			name:               "Max parallel limits concurrent phases",
+			runOptions:         ifc.PlanRunOptions{MaxParallel: 1},
+			expectedExecuted:   []string{"capi_init", "isogen", "remotedirect", "initinfra"},
+			expectedMaxRunning: 1,
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+			},
+		},
+		{
+			name:               "Dependent phases are not started after failure",
+			failures:           map[string]int{"isogen": 1},
+			errContains:        "phase isogen failed",
+			expectedExecuted:   []string{"capi_init", "isogen", "remotedirect"},
+			expectedMaxRunning: 2,
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunFailed,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunPending,
+			},
+		},
+		{
+			name: "Resume executes failed phase and its dependents",
+			lastRun: func(run *v1alpha1.PhasePlanRun) {
+				run.Phases[1].Result = v1alpha1.PhaseRunFailed
+				run.Phases[3].Result = v1alpha1.PhaseRunPending
+			},
+			runOptions:         ifc.PlanRunOptions{Resume: true},
+			expectedExecuted:   []string{"isogen", "initinfra"},
+			expectedMaxRunning: 1,
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			workDir, cleanup := testutil.TempDir(t, "airship-plan-run")
+			defer cleanup(t)
+			helper := newWorkDirHelper(t, workDir)
+			planID := ifc.ID{Name: "dag_plan"}
+
+			if tt.lastRun != nil {
+				p, err := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry)).PlanByID(planID)
+				require.NoError(t, err)
+				require.NoError(t, p.Run(ifc.PlanRunOptions{}))
+				path := phase.PlanRunPath(workDir, planID)
+				run, err := phase.ReadPlanRun(path)
+				require.NoError(t, err)
+				tt.lastRun(run)
+				require.NoError(t, phase.WritePlanRun(path, run))
+			}
+
+			tracker := &phaseTracker{failures: tt.failures}
+			p, err := phase.NewClient(helper, phase.InjectRegistry(tracker.registry)).PlanByID(planID)
+			require.NoError(t, err)
+
+			err = p.Run(tt.runOptions)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.ElementsMatch(t, tt.expectedExecuted, tracker.executed)
+			assert.Equal(t, tt.expectedMaxRunning, tracker.maxRunning)
+			// phases must finish after their dependencies
+			finished := map[string]int{}
+			for i, name := range tracker.executed {
+				finished[name] = i
+			}
+			for name, deps := range dagPlanDependencies {
+				for _, dep := range deps {
+					if i, executed := finished[name]; executed {
+						if j, depExecuted := finished[dep]; depExecuted {
+							assert.Less(t, j, i, "%s executed before %s", name, dep)
+						}
+					}
+				}
+			}
+
+			status, err := p.Status(ifc.StatusOptions{})
+			require.NoError(t, err)
+			require.NotNil(t, status.LastRun)
+			require.Len(t, status.LastRun.Phases, len(tt.expectedResults))
+			for i, phaseRun := range status.LastRun.Phases {
+				assert.Equal(t, tt.expectedResults[i], phaseRun.Result, phaseRun.Name)
+			}
+		})
+	}
+}
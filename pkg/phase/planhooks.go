@@ -0,0 +1,105 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/log"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	preHook  = "pre"
+	postHook = "post"
+)
+
+// runStepPhases executes pre hook phases, the step phase itself according to the step policy and
+// post hook phases, it returns the number of the step phase attempts
+func (p *plan) runStepPhases(step v1alpha1.PhaseStep, policy stepPolicy, phaseRunner ifc.Phase,
+	ro ifc.RunOptions) (int, error) {
+	if err := p.runHooks(step.Name, preHook, step.Pre, ro); err != nil {
+		return 0, err
+	}
+	attempts, err := policy.run(step.Name, phaseRunner, ro)
+	if err != nil {
+		return attempts, err
+	}
+	return attempts, p.runHooks(step.Name, postHook, step.Post, ro)
+}
+
+// runHooks executes hook phases of the plan step one by one and stops on the first failure
+func (p *plan) runHooks(phaseName, hookType string, hooks []string, ro ifc.RunOptions) error {
+	for _, hook := range hooks {
+		log.Printf("executing %s hook phase %s of the phase %s\n", hookType, hook, phaseName)
+		hookRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: hook})
+		if err == nil {
+			err = hookRunner.Run(ro)
+		}
+		if err != nil {
+			return phaseerrors.ErrPhaseHookFailed{
+				PhaseName:     phaseName,
+				HookType:      hookType,
+				HookPhaseName: hook,
+				Err:           err,
+			}
+		}
+	}
+	return nil
+}
+
+// runOnFailure executes phases defined to run after the plan failure, all of them are executed
+// regardless of their results since the plan has already failed
+func (p *plan) runOnFailure(ro ifc.RunOptions, recorder *planRunRecorder) {
+	for _, name := range p.apiObj.OnFailure {
+		log.Printf("executing on failure phase: %s\n", name)
+		index, err := recorder.onFailureStarted(name)
+		if err != nil {
+			log.Printf("failed to record execution state of phase %s: %v\n", name, err)
+		}
+
+		phaseRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: name})
+		if err == nil {
+			err = phaseRunner.Run(ro)
+		}
+		if err != nil {
+			log.Printf("on failure phase %s failed: %v\n", name, err)
+		}
+
+		if recErr := recorder.onFailureFinished(index, err); recErr != nil {
+			log.Printf("failed to record execution state of phase %s: %v\n", name, recErr)
+		}
+	}
+}
+
+// hookPhases returns names of all hook and on failure phases of the plan, each name is listed once
+func hookPhases(planObj *v1alpha1.PhasePlan) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(phases []string) {
+		for _, name := range phases {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	for _, step := range planObj.Phases {
+		add(step.Pre)
+		add(step.Post)
+	}
+	add(planObj.OnFailure)
+	return names
+}
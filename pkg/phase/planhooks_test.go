@@ -0,0 +1,102 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+func TestPlanHooks(t *testing.T) {
+	testCases := []struct {
+		name              string
+		failures          map[string]int
+		errContains       string
+		expectedExecuted  []string
+		expectedResults   []v1alpha1.PhaseRunResult
+		expectedOnFailure []v1alpha1.PhaseRunResult
+	}{
+		{
+			name:             "Hooks are executed around the phase",
+			expectedExecuted: []string{"isogen", "capi_init", "remotedirect", "initinfra"},
+			expectedResults:  []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunSucceeded, v1alpha1.PhaseRunSucceeded},
+		},
+		{
+			name:              "On failure phases are executed when phase fails",
+			failures:          map[string]int{"capi_init": 1},
+			errContains:       "phase capi_init failed",
+			expectedExecuted:  []string{"isogen", "capi_init", "remotedirect"},
+			expectedResults:   []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunFailed, v1alpha1.PhaseRunPending},
+			expectedOnFailure: []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunSucceeded},
+		},
+		{
+			name:              "Phase is not executed when pre hook fails",
+			failures:          map[string]int{"isogen": 1},
+			errContains:       "pre hook phase 'isogen' of the phase 'capi_init' failed: phase isogen failed",
+			expectedExecuted:  []string{"isogen", "remotedirect"},
+			expectedResults:   []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunFailed, v1alpha1.PhaseRunPending},
+			expectedOnFailure: []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunSucceeded},
+		},
+		{
+			name:              "Phase fails when post hook fails",
+			failures:          map[string]int{"remotedirect": 2},
+			errContains:       "post hook phase 'remotedirect' of the phase 'capi_init' failed",
+			expectedExecuted:  []string{"isogen", "capi_init", "remotedirect", "remotedirect"},
+			expectedResults:   []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunFailed, v1alpha1.PhaseRunPending},
+			expectedOnFailure: []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunFailed},
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			workDir, cleanup := testutil.TempDir(t, "airship-plan-run")
+			defer cleanup(t)
+
+			tracker := &phaseTracker{failures: tt.failures}
+			client := phase.NewClient(newWorkDirHelper(t, workDir), phase.InjectRegistry(tracker.registry))
+			p, err := client.PlanByID(ifc.ID{Name: "hooks_plan"})
+			require.NoError(t, err)
+
+			err = p.Run(ifc.PlanRunOptions{})
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedExecuted, tracker.executed)
+
+			status, err := p.Status(ifc.StatusOptions{})
+			require.NoError(t, err)
+			require.NotNil(t, status.LastRun)
+			require.Len(t, status.LastRun.Phases, len(tt.expectedResults))
+			for i, phaseRun := range status.LastRun.Phases {
+				assert.Equal(t, tt.expectedResults[i], phaseRun.Result, phaseRun.Name)
+			}
+			require.Len(t, status.LastRun.OnFailure, len(tt.expectedOnFailure))
+			for i, phaseRun := range status.LastRun.OnFailure {
+				assert.Equal(t, "remotedirect", phaseRun.Name)
+				assert.Equal(t, tt.expectedOnFailure[i], phaseRun.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	goerrors "errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/log"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// PlanRunDir is a directory inside airshipctl work directory where plan execution state is stored
+	PlanRunDir = "plans"
+)
+
+// PlanRunPath returns path to the file with execution state of the plan
+func PlanRunPath(workDir string, planID ifc.ID) string {
+	return filepath.Join(workDir, PlanRunDir, planID.Namespace, planID.Name+".yaml")
+}
+
+// ReadPlanRun reads execution state of the plan, nil is returned if the plan has never been executed
+func ReadPlanRun(path string) (*v1alpha1.PhasePlanRun, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	run := &v1alpha1.PhasePlanRun{}
+	if err = yaml.Unmarshal(data, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// WritePlanRun stores execution state of the plan
+func WritePlanRun(path string, run *v1alpha1.PhasePlanRun) error {
+	data, err := yaml.Marshal(run)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// planRunRecorder keeps track of phase plan execution and persists it after every change, phases are
+// identified by their index within the plan since the same phase may be listed more than once,
+// nil recorder is valid and does nothing, it's used for dry runs
+type planRunRecorder struct {
+	path string
+	run  *v1alpha1.PhasePlanRun
+	mu   sync.Mutex
+}
+
+func newPlanRunRecorder(path string, planObj *v1alpha1.PhasePlan) (*planRunRecorder, error) {
+	hash, err := planHash(planObj)
+	if err != nil {
+		return nil, err
+	}
+	r := &planRunRecorder{
+		path: path,
+		run:  v1alpha1.DefaultPhasePlanRun(planObj),
+	}
+	r.run.PlanHash = hash
+	r.run.StartTime = now()
+	return r, r.write()
+}
+
+// phaseSkipped records phase which is not going to be executed, if the phase has succeeded during
+// the last run its record is preserved
+func (r *planRunRecorder) phaseSkipped(index int, lastRun *v1alpha1.PhasePlanRun) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	phaseRun := &r.run.Phases[index]
+	if lastPhaseRun := findPhaseRun(lastRun, index, phaseRun.Name); lastPhaseRun != nil &&
+		lastPhaseRun.Result == v1alpha1.PhaseRunSucceeded {
+		*phaseRun = *lastPhaseRun
+	} else {
+		phaseRun.Result = v1alpha1.PhaseRunSkipped
+	}
+	return r.write()
+}
+
+func (r *planRunRecorder) phaseStarted(index int, bundleHash string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	phaseRun := &r.run.Phases[index]
+	phaseRun.Result = v1alpha1.PhaseRunRunning
+	phaseRun.StartTime = now()
+	phaseRun.BundleHash = bundleHash
+	return r.write()
+}
+
+func (r *planRunRecorder) phaseFinished(index int, attempts int, runErr error) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	phaseRun := &r.run.Phases[index]
+	phaseRun.EndTime = now()
+	phaseRun.Attempts = attempts
+	phaseRun.Result = v1alpha1.PhaseRunSucceeded
+	if runErr != nil {
+		phaseRun.Result = v1alpha1.PhaseRunFailed
+		phaseRun.Error = runErr.Error()
+	}
+	return r.write()
+}
+
+// onFailureStarted records start of the phase executed after the plan failure and returns index of its record
+func (r *planRunRecorder) onFailureStarted(name string) (int, error) {
+	if r == nil {
+		return 0, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.OnFailure = append(r.run.OnFailure, v1alpha1.PhaseRun{
+		Name:      name,
+		Result:    v1alpha1.PhaseRunRunning,
+		StartTime: now(),
+	})
+	return len(r.run.OnFailure) - 1, r.write()
+}
+
+func (r *planRunRecorder) onFailureFinished(index int, runErr error) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	phaseRun := &r.run.OnFailure[index]
+	phaseRun.EndTime = now()
+	phaseRun.Result = v1alpha1.PhaseRunSucceeded
+	if runErr != nil {
+		phaseRun.Result = v1alpha1.PhaseRunFailed
+		phaseRun.Error = runErr.Error()
+	}
+	return r.write()
+}
+
+func (r *planRunRecorder) finished() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.EndTime = now()
+	return r.write()
+}
+
+func (r *planRunRecorder) write() error {
+	return WritePlanRun(r.path, r.run)
+}
+
+// skippedSteps returns plan steps which must not be executed along with the last recorded plan run
+func (p *plan) skippedSteps(g *planGraph, ro ifc.PlanRunOptions) ([]bool, *v1alpha1.PhasePlanRun, error) {
+	if ro.Resume && ro.ResumeFromPhase != "" {
+		return nil, nil, phaseerrors.ErrConflictingResumeOptions{}
+	}
+
+	lastRun, err := ReadPlanRun(PlanRunPath(p.helper.WorkDir(), p.id()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	skipped := make([]bool, len(p.apiObj.Phases))
+	switch {
+	case ro.ResumeFromPhase != "":
+		for i, step := range p.apiObj.Phases {
+			if step.Name == ro.ResumeFromPhase {
+				return skipped, lastRun, nil
+			}
+			skipped[i] = true
+		}
+		return nil, nil, phaseerrors.ErrPhaseNotInPlan{PlanName: p.apiObj.Name, PhaseName: ro.ResumeFromPhase}
+	case ro.Resume:
+		skipped, err = p.resumeSkipped(g, lastRun, ro.Force)
+		return skipped, lastRun, err
+	default:
+		return skipped, lastRun, nil
+	}
+}
+
+// resumeSkipped returns plan steps which succeeded during the last run or whose condition is not met
+// and which don't depend on the steps that must be executed again, it makes sure that neither plan
+// nor documents of the skipped phases have changed since then unless force is set
+func (p *plan) resumeSkipped(g *planGraph, lastRun *v1alpha1.PhasePlanRun, force bool) ([]bool, error) {
+	if lastRun == nil {
+		return nil, phaseerrors.ErrPlanRunNotFound{PlanName: p.apiObj.Name}
+	}
+
+	hash, err := planHash(p.apiObj)
+	if err != nil {
+		return nil, err
+	}
+	if hash != lastRun.PlanHash {
+		if !force {
+			return nil, phaseerrors.ErrPlanChanged{PlanName: p.apiObj.Name}
+		}
+		log.Printf("plan %s has changed since the last run, resuming anyway\n", p.apiObj.Name)
+	}
+
+	// phase is executed again if it didn't succeed and its condition is met or any of its
+	// dependencies is executed again
+	rerun := make([]bool, len(p.apiObj.Phases))
+	for _, i := range g.order {
+		step := p.apiObj.Phases[i]
+		if phaseRun := findPhaseRun(lastRun, i, step.Name); phaseRun == nil ||
+			phaseRun.Result != v1alpha1.PhaseRunSucceeded {
+			if rerun[i], err = p.conditionMet(step); err != nil {
+				return nil, err
+			}
+		}
+		for _, dep := range g.deps[i] {
+			rerun[i] = rerun[i] || rerun[dep]
+		}
+	}
+
+	skipped := make([]bool, len(p.apiObj.Phases))
+	for i, step := range p.apiObj.Phases {
+		if rerun[i] {
+			continue
+		}
+		skipped[i] = true
+		if phaseRun := findPhaseRun(lastRun, i, step.Name); phaseRun != nil &&
+			phaseRun.Result == v1alpha1.PhaseRunSucceeded {
+			if err = p.checkBundleHash(step.Name, phaseRun.BundleHash, force); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return skipped, nil
+}
+
+// checkBundleHash makes sure that documents of the completed phase haven't changed since the last run
+// unless force is set
+func (p *plan) checkBundleHash(phaseName, lastHash string, force bool) error {
+	phaseRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: phaseName})
+	if err != nil {
+		return err
+	}
+	hash, err := bundleHash(phaseRunner)
+	if err != nil {
+		return err
+	}
+	if hash != lastHash {
+		if !force {
+			return phaseerrors.ErrPhaseBundleChanged{PlanName: p.apiObj.Name, PhaseName: phaseName}
+		}
+		log.Printf("documents of the completed phase %s have changed since the last run, "+
+			"resuming anyway\n", phaseName)
+	}
+	return nil
+}
+
+// findPhaseRun returns record of the phase with given index and name, nil is returned if the plan
+// run has no such record e.g. the plan has changed since then
+func findPhaseRun(run *v1alpha1.PhasePlanRun, index int, name string) *v1alpha1.PhaseRun {
+	if run == nil || index >= len(run.Phases) || run.Phases[index].Name != name {
+		return nil
+	}
+	return &run.Phases[index]
+}
+
+// planHash returns sha256 sum of the phase plan definition
+func planHash(planObj *v1alpha1.PhasePlan) (string, error) {
+	data, err := yaml.Marshal(planObj)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// bundleHash returns sha256 sum of the rendered phase document bundle, empty string is
+// returned for phases without document entry point
+func bundleHash(p ifc.Phase) (string, error) {
+	buf := &bytes.Buffer{}
+	err := p.Render(buf, false, ifc.RenderOptions{FilterSelector: document.NewSelector()})
+	if goerrors.As(err, &phaseerrors.ErrDocumentEntrypointNotDefined{}) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(buf.Bytes())), nil
+}
+
+func now() *metav1.Time {
+	t := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	return &t
+}
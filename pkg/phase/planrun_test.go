@@ -0,0 +1,309 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+// workDirHelper overrides work directory of the phase helper
+type workDirHelper struct {
+	ifc.Helper
+	workDir string
+}
+
+func (h workDirHelper) WorkDir() string {
+	return h.workDir
+}
+
+func newWorkDirHelper(t *testing.T, workDir string) ifc.Helper {
+	t.Helper()
+	helper, err := phase.NewHelper(testConfig(t))
+	require.NoError(t, err)
+	return workDirHelper{Helper: helper, workDir: workDir}
+}
+
+func TestPlanRunPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("/work", phase.PlanRunDir, "plan.yaml"),
+		phase.PlanRunPath("/work", ifc.ID{Name: "plan"}))
+	assert.Equal(t, filepath.Join("/work", phase.PlanRunDir, "ns", "plan.yaml"),
+		phase.PlanRunPath("/work", ifc.ID{Name: "plan", Namespace: "ns"}))
+}
+
+func TestReadWritePlanRun(t *testing.T) {
+	workDir, cleanup := testutil.TempDir(t, "airship-plan-run")
+	defer cleanup(t)
+
+	path := phase.PlanRunPath(workDir, ifc.ID{Name: "plan"})
+	run, err := phase.ReadPlanRun(path)
+	require.NoError(t, err)
+	assert.Nil(t, run)
+
+	expected := v1alpha1.DefaultPhasePlanRun(&v1alpha1.PhasePlan{
+		Phases: []v1alpha1.PhaseStep{{Name: "phase1"}, {Name: "phase2"}},
+	})
+	expected.Name = "plan"
+	expected.Phases[0].Result = v1alpha1.PhaseRunFailed
+	expected.Phases[0].Error = "some error"
+	require.NoError(t, phase.WritePlanRun(path, expected))
+
+	run, err = phase.ReadPlanRun(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, run)
+}
+
+func TestPlanRunRecord(t *testing.T) {
+	testCases := []struct {
+		name            string
+		planID          ifc.ID
+		runOptions      ifc.PlanRunOptions
+		registryFunc    phase.ExecutorRegistry
+		errContains     string
+		expectedResults []v1alpha1.PhaseRunResult
+		expectedErrors  []string
+		expectHash      bool
+	}{
+		{
+			name:            "Success run is recorded",
+			planID:          ifc.ID{Name: "init"},
+			registryFunc:    fakeRegistry,
+			expectedResults: []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunSucceeded},
+			expectedErrors:  []string{""},
+			expectHash:      true,
+		},
+		{
+			name:   "Executor error is recorded",
+			planID: ifc.ID{Name: "init"},
+			registryFunc: func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+				gvk := schema.GroupVersionKind{
+					Group:   "airshipit.org",
+					Version: "v1alpha1",
+					Kind:    "Clusterctl",
+				}
+				return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+					gvk: func(_ ifc.ExecutorConfig) (ifc.Executor, error) {
+						return fakeExecutor{run: fmt.Errorf("run error")}, nil
+					},
+				}
+			},
+			errContains:     "run error",
+			expectedResults: []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunFailed},
+			expectedErrors:  []string{"run error"},
+			expectHash:      true,
+		},
+		{
+			name:            "Phase with no documents is recorded as failed without hash",
+			planID:          ifc.ID{Name: "some_plan"},
+			registryFunc:    fakeRegistry,
+			errContains:     "found no documents",
+			expectedResults: []v1alpha1.PhaseRunResult{v1alpha1.PhaseRunFailed},
+		},
+		{
+			name:         "Dry run is not recorded",
+			planID:       ifc.ID{Name: "init"},
+			runOptions:   ifc.PlanRunOptions{RunOptions: ifc.RunOptions{DryRun: true}},
+			registryFunc: fakeRegistry,
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			workDir, cleanup := testutil.TempDir(t, "airship-plan-run")
+			defer cleanup(t)
+
+			client := phase.NewClient(newWorkDirHelper(t, workDir), phase.InjectRegistry(tt.registryFunc))
+			p, err := client.PlanByID(tt.planID)
+			require.NoError(t, err)
+
+			err = p.Run(tt.runOptions)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+
+			status, err := p.Status(ifc.StatusOptions{})
+			require.NoError(t, err)
+			if tt.expectedResults == nil {
+				assert.Nil(t, status.LastRun)
+				return
+			}
+			require.NotNil(t, status.LastRun)
+			assert.Equal(t, tt.planID.Name, status.LastRun.Name)
+			assert.NotNil(t, status.LastRun.StartTime)
+			require.Len(t, status.LastRun.Phases, len(tt.expectedResults))
+			for i, phaseRun := range status.LastRun.Phases {
+				assert.Equal(t, tt.expectedResults[i], phaseRun.Result)
+				assert.NotNil(t, phaseRun.StartTime)
+				assert.NotNil(t, phaseRun.EndTime)
+				assert.Equal(t, tt.expectHash, phaseRun.BundleHash != "")
+				if tt.expectedErrors != nil {
+					assert.Equal(t, tt.expectedErrors[i], phaseRun.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanRunResume(t *testing.T) {
+	runErrRegistry := func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+		gvk := schema.GroupVersionKind{
+			Group:   "airshipit.org",
+			Version: "v1alpha1",
+			Kind:    "Clusterctl",
+		}
+		return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+			gvk: func(_ ifc.ExecutorConfig) (ifc.Executor, error) {
+				return fakeExecutor{run: fmt.Errorf("run error")}, nil
+			},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		lastRun      func(run *v1alpha1.PhasePlanRun)
+		runOptions   ifc.PlanRunOptions
+		registryFunc phase.ExecutorRegistry
+		errContains  string
+		// expectedResult is a result of the capi_init phase after resume
+		expectedResult v1alpha1.PhaseRunResult
+	}{
+		{
+			name:         "Error conflicting options",
+			runOptions:   ifc.PlanRunOptions{Resume: true, ResumeFromPhase: "capi_init"},
+			registryFunc: fakeRegistry,
+			errContains:  "can not be used together",
+		},
+		{
+			name:         "Error unknown resume from phase",
+			runOptions:   ifc.PlanRunOptions{ResumeFromPhase: "unknown"},
+			registryFunc: fakeRegistry,
+			errContains:  "phase 'unknown' is not a part of the plan 'init'",
+		},
+		{
+			name:         "Error no recorded runs",
+			runOptions:   ifc.PlanRunOptions{Resume: true},
+			registryFunc: fakeRegistry,
+			errContains:  "plan 'init' has no recorded runs",
+		},
+		{
+			name: "Resume failed phase",
+			lastRun: func(run *v1alpha1.PhasePlanRun) {
+				run.Phases[0].Result = v1alpha1.PhaseRunFailed
+			},
+			runOptions:     ifc.PlanRunOptions{Resume: true},
+			registryFunc:   fakeRegistry,
+			expectedResult: v1alpha1.PhaseRunSucceeded,
+		},
+		{
+			name:           "Nothing to resume",
+			lastRun:        func(run *v1alpha1.PhasePlanRun) {},
+			runOptions:     ifc.PlanRunOptions{Resume: true},
+			registryFunc:   runErrRegistry,
+			expectedResult: v1alpha1.PhaseRunSucceeded,
+		},
+		{
+			name: "Error plan changed",
+			lastRun: func(run *v1alpha1.PhasePlanRun) {
+				run.PlanHash = "changed"
+				run.Phases[0].Result = v1alpha1.PhaseRunFailed
+			},
+			runOptions:     ifc.PlanRunOptions{Resume: true},
+			registryFunc:   fakeRegistry,
+			errContains:    "plan 'init' has changed since the last run",
+			expectedResult: v1alpha1.PhaseRunFailed,
+		},
+		{
+			name: "Force resume changed plan",
+			lastRun: func(run *v1alpha1.PhasePlanRun) {
+				run.PlanHash = "changed"
+				run.Phases[0].Result = v1alpha1.PhaseRunFailed
+			},
+			runOptions:     ifc.PlanRunOptions{Resume: true, Force: true},
+			registryFunc:   fakeRegistry,
+			expectedResult: v1alpha1.PhaseRunSucceeded,
+		},
+		{
+			name: "Error completed phase documents changed",
+			lastRun: func(run *v1alpha1.PhasePlanRun) {
+				run.Phases[0].BundleHash = "changed"
+			},
+			runOptions:     ifc.PlanRunOptions{Resume: true},
+			registryFunc:   fakeRegistry,
+			errContains:    "documents of the completed phase 'capi_init' of the plan 'init' have changed",
+			expectedResult: v1alpha1.PhaseRunSucceeded,
+		},
+		{
+			name: "Force resume changed phase documents",
+			lastRun: func(run *v1alpha1.PhasePlanRun) {
+				run.Phases[0].BundleHash = "changed"
+			},
+			runOptions:     ifc.PlanRunOptions{Resume: true, Force: true},
+			registryFunc:   runErrRegistry,
+			expectedResult: v1alpha1.PhaseRunSucceeded,
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			workDir, cleanup := testutil.TempDir(t, "airship-plan-run")
+			defer cleanup(t)
+			helper := newWorkDirHelper(t, workDir)
+			planID := ifc.ID{Name: "init"}
+
+			if tt.lastRun != nil {
+				// record successful run and adjust it
+				p, err := phase.NewClient(helper, phase.InjectRegistry(fakeRegistry)).PlanByID(planID)
+				require.NoError(t, err)
+				require.NoError(t, p.Run(ifc.PlanRunOptions{}))
+				path := phase.PlanRunPath(workDir, planID)
+				run, err := phase.ReadPlanRun(path)
+				require.NoError(t, err)
+				tt.lastRun(run)
+				require.NoError(t, phase.WritePlanRun(path, run))
+			}
+
+			p, err := phase.NewClient(helper, phase.InjectRegistry(tt.registryFunc)).PlanByID(planID)
+			require.NoError(t, err)
+			err = p.Run(tt.runOptions)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.expectedResult != "" {
+				status, err := p.Status(ifc.StatusOptions{})
+				require.NoError(t, err)
+				require.NotNil(t, status.LastRun)
+				require.Len(t, status.LastRun.Phases, 1)
+				assert.Equal(t, tt.expectedResult, status.LastRun.Phases[0].Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/log"
+	phaseerrors "opendev.org/airship/airshipctl/pkg/phase/errors"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+// stepPolicy holds parsed execution options of the plan step
+type stepPolicy struct {
+	attempts int
+	backoff  time.Duration
+	timeout  *time.Duration
+}
+
+// newStepPolicies parses and validates execution options of all plan steps
+func newStepPolicies(planObj *v1alpha1.PhasePlan) ([]stepPolicy, error) {
+	policies := make([]stepPolicy, len(planObj.Phases))
+	for i, step := range planObj.Phases {
+		policy, err := newStepPolicy(step)
+		if err != nil {
+			return nil, phaseerrors.ErrInvalidPhaseStep{
+				PlanName:  planObj.Name,
+				PhaseName: step.Name,
+				Reason:    err.Error(),
+			}
+		}
+		policies[i] = policy
+	}
+	return policies, nil
+}
+
+func newStepPolicy(step v1alpha1.PhaseStep) (stepPolicy, error) {
+	policy := stepPolicy{attempts: 1}
+	if step.Retry != nil {
+		if step.Retry.Attempts < 1 {
+			return policy, fmt.Errorf("retry attempts must be greater than zero")
+		}
+		policy.attempts = step.Retry.Attempts
+		if step.Retry.Backoff != "" {
+			backoff, err := parsePositiveDuration("retry backoff", step.Retry.Backoff)
+			if err != nil {
+				return policy, err
+			}
+			policy.backoff = backoff
+		}
+	}
+
+	if step.Timeout != "" {
+		timeout, err := parsePositiveDuration("timeout", step.Timeout)
+		if err != nil {
+			return policy, err
+		}
+		policy.timeout = &timeout
+	}
+
+	if step.When != nil {
+		if step.When.Cluster == "" && step.When.EnvVar == nil {
+			return policy, fmt.Errorf("condition must define either cluster or environment variable")
+		}
+		if step.When.EnvVar != nil && step.When.EnvVar.Name == "" {
+			return policy, fmt.Errorf("condition environment variable name must not be empty")
+		}
+	}
+	return policy, nil
+}
+
+func parsePositiveDuration(field, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s '%s' must be a positive duration, e.g. 10m", field, value)
+	}
+	return d, nil
+}
+
+// run executes the phase until it succeeds or all attempts are made, it returns the number of
+// attempts along with the error of the last one
+func (sp stepPolicy) run(name string, phaseRunner ifc.Phase, ro ifc.RunOptions) (int, error) {
+	if sp.timeout != nil {
+		ro.Timeout = sp.timeout
+	}
+
+	backoff := sp.backoff
+	for attempt := 1; ; attempt++ {
+		err := phaseRunner.Run(ro)
+		if err == nil || attempt >= sp.attempts {
+			return attempt, err
+		}
+		log.Printf("phase %s failed, attempt %d of %d, retrying in %s: %v\n",
+			name, attempt, sp.attempts, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// conditionMet returns true if the phase has no condition or the condition is met
+func (p *plan) conditionMet(step v1alpha1.PhaseStep) (bool, error) {
+	if step.When == nil {
+		return true, nil
+	}
+
+	if step.When.EnvVar != nil {
+		value := os.Getenv(step.When.EnvVar.Name)
+		if value == "" || (step.When.EnvVar.Value != "" && value != step.When.EnvVar.Value) {
+			return false, nil
+		}
+	}
+
+	if step.When.Cluster != "" {
+		cMap, err := p.helper.ClusterMap()
+		if err != nil {
+			return false, err
+		}
+		for _, cluster := range cMap.AllClusters() {
+			if cluster == step.When.Cluster {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
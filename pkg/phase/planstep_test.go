@@ -0,0 +1,147 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+const conditionEnvVar = "AIRSHIPCTL_TEST_PHASE_CONDITION"
+
+func TestPlanStepOptions(t *testing.T) {
+	testCases := []struct {
+		name             string
+		failures         map[string]int
+		conditionValue   string
+		errContains      string
+		expectedExecuted []string
+		expectedResults  []v1alpha1.PhaseRunResult
+		expectedAttempts []int
+	}{
+		{
+			name:             "Phase is retried until it succeeds",
+			failures:         map[string]int{"capi_init": 2},
+			expectedExecuted: []string{"capi_init", "capi_init", "capi_init", "isogen", "initinfra"},
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSkipped,
+				v1alpha1.PhaseRunSucceeded,
+			},
+			expectedAttempts: []int{3, 1, 0, 1},
+		},
+		{
+			name:             "Phase fails when all attempts fail",
+			failures:         map[string]int{"capi_init": 3},
+			errContains:      "phase capi_init failed",
+			expectedExecuted: []string{"capi_init", "capi_init", "capi_init"},
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunFailed,
+				v1alpha1.PhaseRunPending,
+				v1alpha1.PhaseRunPending,
+				v1alpha1.PhaseRunPending,
+			},
+			expectedAttempts: []int{3, 0, 0, 0},
+		},
+		{
+			name:             "Plan continues when phase allowed to fail",
+			failures:         map[string]int{"isogen": 1},
+			expectedExecuted: []string{"capi_init", "isogen", "initinfra"},
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunFailed,
+				v1alpha1.PhaseRunSkipped,
+				v1alpha1.PhaseRunSucceeded,
+			},
+			expectedAttempts: []int{1, 1, 0, 1},
+		},
+		{
+			name:             "Phase is executed when condition is met",
+			conditionValue:   "enabled",
+			expectedExecuted: []string{"capi_init", "isogen", "remotedirect", "initinfra"},
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+			},
+			expectedAttempts: []int{1, 1, 1, 1},
+		},
+		{
+			name:             "Phase is skipped when condition value doesn't match",
+			conditionValue:   "disabled",
+			expectedExecuted: []string{"capi_init", "isogen", "initinfra"},
+			expectedResults: []v1alpha1.PhaseRunResult{
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSucceeded,
+				v1alpha1.PhaseRunSkipped,
+				v1alpha1.PhaseRunSucceeded,
+			},
+			expectedAttempts: []int{1, 1, 0, 1},
+		},
+	}
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			workDir, cleanup := testutil.TempDir(t, "airship-plan-run")
+			defer cleanup(t)
+			if tt.conditionValue != "" {
+				require.NoError(t, os.Setenv(conditionEnvVar, tt.conditionValue))
+				defer os.Unsetenv(conditionEnvVar)
+			}
+
+			tracker := &phaseTracker{failures: tt.failures}
+			client := phase.NewClient(newWorkDirHelper(t, workDir), phase.InjectRegistry(tracker.registry))
+			p, err := client.PlanByID(ifc.ID{Name: "step_options_plan"})
+			require.NoError(t, err)
+
+			err = p.Run(ifc.PlanRunOptions{})
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedExecuted, tracker.executed)
+
+			// phase timeout overrides the one given in run options
+			expectedTimeout := 10 * time.Minute
+			require.NotNil(t, tracker.timeouts["capi_init"])
+			assert.Equal(t, expectedTimeout, *tracker.timeouts["capi_init"])
+			if timeout, executed := tracker.timeouts["isogen"]; executed {
+				assert.Nil(t, timeout)
+			}
+
+			status, err := p.Status(ifc.StatusOptions{})
+			require.NoError(t, err)
+			require.NotNil(t, status.LastRun)
+			require.Len(t, status.LastRun.Phases, len(tt.expectedResults))
+			for i, phaseRun := range status.LastRun.Phases {
+				assert.Equal(t, tt.expectedResults[i], phaseRun.Result, phaseRun.Name)
+				assert.Equal(t, tt.expectedAttempts[i], phaseRun.Attempts, phaseRun.Name)
+			}
+		})
+	}
+}
@@ -15,12 +15,14 @@
 package phase
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"strings"
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/document/images"
 	"opendev.org/airship/airshipctl/pkg/phase/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 )
@@ -55,7 +57,10 @@ type RenderCommand struct {
 	// FailOnDecryptionError makes sure that encrypted documents are getting decrypted by avoiding setting
 	// env variable TOLERATE_DECRYPTION_FAILURES=true
 	FailOnDecryptionError bool
-	PhaseID               ifc.ID
+	// ImageRegistry if set makes all container image references of the rendered documents point
+	// to this registry, e.g. a private mirror of the images
+	ImageRegistry string
+	PhaseID       ifc.ID
 }
 
 // RunE prints out filtered documents
@@ -68,6 +73,26 @@ func (fo *RenderCommand) RunE(cfgFactory config.Factory, out io.Writer) error {
 		os.Setenv("TOLERATE_DECRYPTION_FAILURES", "true")
 	}
 
+	if fo.ImageRegistry == "" {
+		return fo.render(cfgFactory, out)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := fo.render(cfgFactory, buf); err != nil {
+		return err
+	}
+	bundle, err := document.NewBundleFromBytes(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	mirrored, err := images.Mirror(bundle, fo.ImageRegistry)
+	if err != nil {
+		return err
+	}
+	return mirrored.Write(out)
+}
+
+func (fo *RenderCommand) render(cfgFactory config.Factory, out io.Writer) error {
 	cfg, err := cfgFactory()
 	if err != nil {
 		return err
@@ -157,3 +157,29 @@ func TestRenderConfigBundle(t *testing.T) {
 	assert.Contains(t, buf.String(), "kind: Phase")
 	assert.Contains(t, buf.String(), "kind: ClusterMap")
 }
+
+func TestRenderImageRegistry(t *testing.T) {
+	rs := testutil.DummyConfig()
+	dummyManifest := rs.Manifests["dummy_manifest"]
+	dummyManifest.TargetPath = "testdata"
+	dummyManifest.PhaseRepositoryName = config.DefaultTestPhaseRepo
+	dummyManifest.Repositories = map[string]*config.Repository{
+		config.DefaultTestPhaseRepo: {},
+	}
+	dummyManifest.MetadataPath = "images_site/metadata.yaml"
+	buf := bytes.NewBuffer([]byte{})
+	settings := &phase.RenderCommand{
+		Source:        phase.RenderSourcePhase,
+		ImageRegistry: "registry.local:5000",
+		PhaseID:       ifc.ID{Name: "initinfra"},
+	}
+	err := settings.RunE(func() (*config.Config, error) {
+		return rs, nil
+	}, buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "image: registry.local:5000/metal3-io/ironic:capm3-v0.5.0")
+	assert.Contains(t, buf.String(), "image: registry.local:5000/library/busybox:1.33")
+	assert.Contains(t, buf.String(), "image: registry.local:5000/calico/kube-controllers:v3.15.1")
+	assert.Contains(t, buf.String(), "repository: registry.local:5000/metal3-io")
+	assert.NotContains(t, buf.String(), "quay.io")
+}
@@ -0,0 +1,118 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hardware
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BIOSSettings are BIOS attributes and persistent boot order of a baremetal host
+type BIOSSettings struct {
+	// Attributes maps vendor specific names of BIOS attributes to their values
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// BootOrder lists boot options in the order they are tried by BIOS
+	BootOrder []string `json:"bootOrder,omitempty"`
+}
+
+// BIOSAttributeChange is a BIOS attribute which value differs from the desired one
+type BIOSAttributeChange struct {
+	Name    string      `json:"name"`
+	Current interface{} `json:"current"`
+	Desired interface{} `json:"desired"`
+}
+
+// BIOSSettingsDiff lists the changes required to bring BIOS settings of a host to the desired ones
+type BIOSSettingsDiff struct {
+	// Attributes are sorted by name
+	Attributes []BIOSAttributeChange `json:"attributes,omitempty"`
+	// CurrentBootOrder and BootOrder are set only if the boot order has to be changed
+	CurrentBootOrder []string `json:"currentBootOrder,omitempty"`
+	BootOrder        []string `json:"bootOrder,omitempty"`
+}
+
+// DiffBIOSSettings compares the current BIOS settings of a host with the desired ones. Attribute values are
+// compared by their text representation, since BMCs aren't consistent in typing of numeric and boolean values.
+// An error is returned if some of the desired attributes aren't known to BIOS of the host.
+func DiffBIOSSettings(current, desired BIOSSettings) (BIOSSettingsDiff, error) {
+	var diff BIOSSettingsDiff
+	var unknown []string
+	for name, value := range desired.Attributes {
+		currentValue, ok := current.Attributes[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if fmt.Sprint(currentValue) != fmt.Sprint(value) {
+			diff.Attributes = append(diff.Attributes, BIOSAttributeChange{
+				Name:    name,
+				Current: currentValue,
+				Desired: value,
+			})
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return BIOSSettingsDiff{}, ErrUnknownBIOSAttributes{Attributes: unknown}
+	}
+	sort.Slice(diff.Attributes, func(i, j int) bool { return diff.Attributes[i].Name < diff.Attributes[j].Name })
+
+	if len(desired.BootOrder) != 0 && !equalStrings(current.BootOrder, desired.BootOrder) {
+		diff.CurrentBootOrder = current.BootOrder
+		diff.BootOrder = desired.BootOrder
+	}
+	return diff, nil
+}
+
+// Empty returns true if BIOS settings of the host are already the desired ones
+func (d BIOSSettingsDiff) Empty() bool {
+	return len(d.Attributes) == 0 && len(d.BootOrder) == 0
+}
+
+// Changes returns only the settings that have to be changed, so that they can be applied to BIOS of the host
+func (d BIOSSettingsDiff) Changes() BIOSSettings {
+	changes := BIOSSettings{BootOrder: d.BootOrder}
+	if len(d.Attributes) != 0 {
+		changes.Attributes = make(map[string]interface{}, len(d.Attributes))
+		for _, change := range d.Attributes {
+			changes.Attributes[change.Name] = change.Desired
+		}
+	}
+	return changes
+}
+
+// String returns a line per changed setting in the form of 'name: current -> desired'
+func (d BIOSSettingsDiff) String() string {
+	var s string
+	for _, change := range d.Attributes {
+		s += fmt.Sprintf("%s: %v -> %v\n", change.Name, change.Current, change.Desired)
+	}
+	if len(d.BootOrder) != 0 {
+		s += fmt.Sprintf("BootOrder: %v -> %v\n", d.CurrentBootOrder, d.BootOrder)
+	}
+	return s
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
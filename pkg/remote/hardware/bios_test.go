@@ -0,0 +1,111 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hardware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffBIOSSettings(t *testing.T) {
+	current := BIOSSettings{
+		Attributes: map[string]interface{}{
+			"BootMode":           "Uefi",
+			"ProcVirtualization": "Disabled",
+			"SriovGlobalEnable":  false,
+			"NumLock":            "On",
+			"MemFrequency":       float64(2933),
+		},
+		BootOrder: []string{"Boot0001", "Boot0002"},
+	}
+
+	tests := []struct {
+		name     string
+		desired  BIOSSettings
+		expected BIOSSettingsDiff
+		empty    bool
+	}{
+		{
+			name:  "nothing desired",
+			empty: true,
+		},
+		{
+			name: "settings match",
+			desired: BIOSSettings{
+				Attributes: map[string]interface{}{"BootMode": "Uefi", "SriovGlobalEnable": "false"},
+				BootOrder:  []string{"Boot0001", "Boot0002"},
+			},
+			empty: true,
+		},
+		{
+			name: "settings differ",
+			desired: BIOSSettings{
+				Attributes: map[string]interface{}{
+					"SriovGlobalEnable":  true,
+					"ProcVirtualization": "Enabled",
+					"MemFrequency":       2933,
+				},
+				BootOrder: []string{"Boot0002", "Boot0001"},
+			},
+			expected: BIOSSettingsDiff{
+				Attributes: []BIOSAttributeChange{
+					{Name: "ProcVirtualization", Current: "Disabled", Desired: "Enabled"},
+					{Name: "SriovGlobalEnable", Current: false, Desired: true},
+				},
+				CurrentBootOrder: []string{"Boot0001", "Boot0002"},
+				BootOrder:        []string{"Boot0002", "Boot0001"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := DiffBIOSSettings(current, tt.desired)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, diff)
+			assert.Equal(t, tt.empty, diff.Empty())
+		})
+	}
+}
+
+func TestDiffBIOSSettingsUnknownAttributes(t *testing.T) {
+	_, err := DiffBIOSSettings(
+		BIOSSettings{Attributes: map[string]interface{}{"BootMode": "Uefi"}},
+		BIOSSettings{Attributes: map[string]interface{}{"NumLock": "On", "BootMode": "Bios", "AcPwrRcvry": "On"}})
+	assert.Equal(t, ErrUnknownBIOSAttributes{Attributes: []string{"AcPwrRcvry", "NumLock"}}, err)
+	assert.Equal(t, "BIOS doesn't have attributes 'AcPwrRcvry', 'NumLock'", err.Error())
+}
+
+func TestBIOSSettingsDiffChanges(t *testing.T) {
+	diff := BIOSSettingsDiff{
+		Attributes: []BIOSAttributeChange{
+			{Name: "BootMode", Current: "Bios", Desired: "Uefi"},
+			{Name: "NumLock", Current: "Off", Desired: "On"},
+		},
+		CurrentBootOrder: []string{"Boot0001"},
+		BootOrder:        []string{"Boot0002"},
+	}
+	assert.Equal(t, BIOSSettings{
+		Attributes: map[string]interface{}{"BootMode": "Uefi", "NumLock": "On"},
+		BootOrder:  []string{"Boot0002"},
+	}, diff.Changes())
+	assert.Equal(t, "BootMode: Bios -> Uefi\nNumLock: Off -> On\nBootOrder: [Boot0001] -> [Boot0002]\n", diff.String())
+
+	assert.Equal(t, BIOSSettings{}, BIOSSettingsDiff{}.Changes())
+	assert.Empty(t, BIOSSettingsDiff{}.String())
+}
@@ -0,0 +1,66 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hardware
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrInventoryNotSupported is returned when the management client of a host can't retrieve its hardware inventory
+type ErrInventoryNotSupported struct {
+	NodeName string
+}
+
+func (e ErrInventoryNotSupported) Error() string {
+	return fmt.Sprintf("hardware inventory of node '%s' is not supported by its management type", e.NodeName)
+}
+
+// ErrBIOSNotSupported is returned when the management client of a host can't read or change its BIOS settings
+type ErrBIOSNotSupported struct {
+	NodeName string
+}
+
+func (e ErrBIOSNotSupported) Error() string {
+	return fmt.Sprintf("BIOS configuration of node '%s' is not supported by its management type", e.NodeName)
+}
+
+// ErrUnknownBIOSAttributes is returned when the desired BIOS settings contain attributes not known to BIOS of a host
+type ErrUnknownBIOSAttributes struct {
+	Attributes []string
+}
+
+func (e ErrUnknownBIOSAttributes) Error() string {
+	return fmt.Sprintf("BIOS doesn't have attributes '%s'", strings.Join(e.Attributes, "', '"))
+}
+
+// ErrFirmwareUpdateNotSupported is returned when the management client of a host can't update its firmware
+type ErrFirmwareUpdateNotSupported struct {
+	NodeName string
+}
+
+func (e ErrFirmwareUpdateNotSupported) Error() string {
+	return fmt.Sprintf("firmware update of node '%s' is not supported by its management type", e.NodeName)
+}
+
+// ErrUnknownFirmwareComponents is returned when the desired firmware versions are declared for components a host
+// doesn't have
+type ErrUnknownFirmwareComponents struct {
+	Components []string
+}
+
+func (e ErrUnknownFirmwareComponents) Error() string {
+	return fmt.Sprintf("host doesn't have firmware components '%s'", strings.Join(e.Components, "', '"))
+}
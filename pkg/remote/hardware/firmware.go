@@ -0,0 +1,90 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hardware
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Firmware is a firmware component of a baremetal host as reported by its BMC
+type Firmware struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// FirmwareTarget is the desired version of a firmware component and the image providing it
+type FirmwareTarget struct {
+	// Component is either ID or name of the firmware component as reported by the BMC
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	// ImageURL is the URL the BMC downloads the firmware image from
+	ImageURL string `json:"imageURL"`
+}
+
+// FirmwareUpdate is a firmware component which version differs from the desired one
+type FirmwareUpdate struct {
+	FirmwareTarget
+	// Current lists the distinct versions of the matching components that differ from the desired one
+	Current []string `json:"current"`
+}
+
+// String returns the update in the form of 'component: current -> desired'
+func (u FirmwareUpdate) String() string {
+	return fmt.Sprintf("%s: %s -> %s", u.Component, strings.Join(u.Current, ", "), u.Version)
+}
+
+// PlanFirmwareUpdates compares versions of the firmware components of a host with the desired ones and returns
+// the updates to perform in the order of the targets. A target matches every component with the same ID or
+// name, it's updated unless all of them have the desired version. An error is returned if some of the targets
+// don't match any component of the host.
+func PlanFirmwareUpdates(components []Firmware, targets []FirmwareTarget) ([]FirmwareUpdate, error) {
+	var updates []FirmwareUpdate
+	var unknown []string
+	for _, target := range targets {
+		found := false
+		update := FirmwareUpdate{FirmwareTarget: target}
+		for _, component := range components {
+			if component.ID != target.Component && component.Name != target.Component {
+				continue
+			}
+			found = true
+			if component.Version != target.Version && !containsString(update.Current, component.Version) {
+				update.Current = append(update.Current, component.Version)
+			}
+		}
+
+		switch {
+		case !found:
+			unknown = append(unknown, target.Component)
+		case len(update.Current) != 0:
+			updates = append(updates, update)
+		}
+	}
+	if len(unknown) != 0 {
+		return nil, ErrUnknownFirmwareComponents{Components: unknown}
+	}
+	return updates, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
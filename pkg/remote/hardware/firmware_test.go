@@ -0,0 +1,101 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hardware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanFirmwareUpdates(t *testing.T) {
+	components := []Firmware{
+		{ID: "Installed-25227-4.40.00.00", Name: "Integrated Dell Remote Access Controller", Version: "4.40.00.00"},
+		{ID: "Installed-159-2.10.2", Name: "BIOS", Version: "2.10.2"},
+		{ID: "Installed-107649-21.60.16", Name: "Broadcom Gigabit Ethernet BCM5720", Version: "21.60.16"},
+		{ID: "Installed-107649-21.60.16-2", Name: "Broadcom Gigabit Ethernet BCM5720", Version: "21.60.16"},
+	}
+	bmc := FirmwareTarget{
+		Component: "Integrated Dell Remote Access Controller",
+		Version:   "5.00.00.00",
+		ImageURL:  "http://localhost:8099/idrac-5.00.00.00.exe",
+	}
+	bios := FirmwareTarget{Component: "BIOS", Version: "2.10.2", ImageURL: "http://localhost:8099/bios-2.10.2.exe"}
+	nic := FirmwareTarget{
+		Component: "Broadcom Gigabit Ethernet BCM5720",
+		Version:   "21.80.8",
+		ImageURL:  "http://localhost:8099/bcm5720-21.80.8.exe",
+	}
+
+	tests := []struct {
+		name        string
+		targets     []FirmwareTarget
+		expected    []FirmwareUpdate
+		expectedErr error
+	}{
+		{
+			name:    "up to date",
+			targets: []FirmwareTarget{bios},
+		},
+		{
+			name:    "components to update",
+			targets: []FirmwareTarget{nic, bios, bmc},
+			expected: []FirmwareUpdate{
+				{FirmwareTarget: nic, Current: []string{"21.60.16"}},
+				{FirmwareTarget: bmc, Current: []string{"4.40.00.00"}},
+			},
+		},
+		{
+			name:    "component by ID",
+			targets: []FirmwareTarget{{Component: "Installed-159-2.10.2", Version: "2.12.2"}},
+			expected: []FirmwareUpdate{{
+				FirmwareTarget: FirmwareTarget{Component: "Installed-159-2.10.2", Version: "2.12.2"},
+				Current:        []string{"2.10.2"},
+			}},
+		},
+		{
+			name:        "unknown components",
+			targets:     []FirmwareTarget{bmc, {Component: "CPLD"}, {Component: "PERC H740P"}},
+			expectedErr: ErrUnknownFirmwareComponents{Components: []string{"CPLD", "PERC H740P"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := PlanFirmwareUpdates(components, tt.targets)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, updates)
+		})
+	}
+}
+
+func TestFirmwareUpdateString(t *testing.T) {
+	update := FirmwareUpdate{
+		FirmwareTarget: FirmwareTarget{Component: "BIOS", Version: "2.12.2"},
+		Current:        []string{"2.10.2", "2.11.0"},
+	}
+	assert.Equal(t, "BIOS: 2.10.2, 2.11.0 -> 2.12.2", update.String())
+}
+
+func TestErrUnknownFirmwareComponents(t *testing.T) {
+	err := ErrUnknownFirmwareComponents{Components: []string{"CPLD", "BIOS"}}
+	assert.Equal(t, "host doesn't have firmware components 'CPLD', 'BIOS'", err.Error())
+}
@@ -0,0 +1,101 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package hardware describes hardware inventory, BIOS settings and firmware of baremetal hosts independently of the
+// management clients.
+package hardware
+
+import "strings"
+
+const (
+	// HealthOK indicates that a component of a baremetal host is healthy.
+	HealthOK = "OK"
+	// HealthWarning indicates that a component of a baremetal host requires attention.
+	HealthWarning = "Warning"
+	// HealthCritical indicates that a component of a baremetal host requires immediate attention.
+	HealthCritical = "Critical"
+)
+
+var healthSeverity = map[string]int{
+	HealthOK:       1,
+	HealthWarning:  2,
+	HealthCritical: 3,
+}
+
+// Inventory is the hardware inventory of a baremetal host
+type Inventory struct {
+	Manufacturer       string     `json:"manufacturer,omitempty"`
+	Model              string     `json:"model,omitempty"`
+	SerialNumber       string     `json:"serialNumber,omitempty"`
+	BIOSVersion        string     `json:"biosVersion,omitempty"`
+	BMCFirmwareVersion string     `json:"bmcFirmwareVersion,omitempty"`
+	Processors         Processors `json:"processors"`
+	MemoryGiB          float64    `json:"memoryGiB"`
+	NICs               []NIC      `json:"nics,omitempty"`
+	Disks              []Disk     `json:"disks,omitempty"`
+	// Health is the worst health state reported for the system, its chassis and its BMC
+	Health string `json:"health,omitempty"`
+}
+
+// Processors summarizes the processors of a baremetal host
+type Processors struct {
+	Count int    `json:"count"`
+	Model string `json:"model,omitempty"`
+}
+
+// NIC is a network interface of a baremetal host
+type NIC struct {
+	Name       string `json:"name"`
+	MACAddress string `json:"macAddress,omitempty"`
+	LinkStatus string `json:"linkStatus,omitempty"`
+}
+
+// Disk is a drive of a baremetal host
+type Disk struct {
+	Name          string `json:"name"`
+	Model         string `json:"model,omitempty"`
+	CapacityBytes int64  `json:"capacityBytes,omitempty"`
+	MediaType     string `json:"mediaType,omitempty"`
+	Health        string `json:"health,omitempty"`
+}
+
+// HasMACAddress reports whether any network interface of the host has the MAC address, the addresses are
+// compared ignoring case and separators.
+func (i Inventory) HasMACAddress(mac string) bool {
+	mac = normalizeMACAddress(mac)
+	if mac == "" {
+		return false
+	}
+	for _, nic := range i.NICs {
+		if normalizeMACAddress(nic.MACAddress) == mac {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeMACAddress(mac string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.TrimSpace(mac)))
+}
+
+// WorstHealth returns the most severe of the health states, unknown and empty states are ignored.
+func WorstHealth(states ...string) string {
+	var worst string
+	for _, state := range states {
+		if healthSeverity[state] > healthSeverity[worst] {
+			worst = state
+		}
+	}
+	return worst
+}
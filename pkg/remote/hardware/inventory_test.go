@@ -0,0 +1,68 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hardware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasMACAddress(t *testing.T) {
+	inventory := Inventory{NICs: []NIC{
+		{Name: "NIC.1", MACAddress: "00:3B:8B:0C:EC:8B"},
+		{Name: "NIC.2"},
+	}}
+
+	tests := []struct {
+		name     string
+		mac      string
+		expected bool
+	}{
+		{name: "same case", mac: "00:3B:8B:0C:EC:8B", expected: true},
+		{name: "lower case", mac: "00:3b:8b:0c:ec:8b", expected: true},
+		{name: "dash separated", mac: "00-3b-8b-0c-ec-8b", expected: true},
+		{name: "other address", mac: "00:3b:8b:0c:ec:8c", expected: false},
+		{name: "empty address", mac: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, inventory.HasMACAddress(tt.mac))
+		})
+	}
+}
+
+func TestWorstHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		states   []string
+		expected string
+	}{
+		{name: "no states", expected: ""},
+		{name: "healthy", states: []string{HealthOK, "", HealthOK}, expected: HealthOK},
+		{name: "warning", states: []string{HealthOK, HealthWarning}, expected: HealthWarning},
+		{name: "critical", states: []string{HealthCritical, HealthWarning, HealthOK}, expected: HealthCritical},
+		{name: "unknown state ignored", states: []string{"Unknown", HealthWarning}, expected: HealthWarning},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, WorstHealth(tt.states...))
+		})
+	}
+}
@@ -17,6 +17,7 @@ package ifc
 import (
 	"context"
 
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
 	"opendev.org/airship/airshipctl/pkg/remote/power"
 )
 
@@ -31,6 +32,7 @@ type Client interface {
 	SystemPowerOff(context.Context) error
 	SystemPowerOn(context.Context) error
 	SystemPowerStatus(context.Context) (power.Status, error)
+	VirtualMediaInserted(context.Context) (bool, error)
 	RemoteDirect(context.Context, string) error
 
 	// TODO(drewwalters96): This function is tightly coupled to Redfish. It should be combined with the
@@ -38,6 +40,25 @@ type Client interface {
 	SetVirtualMedia(context.Context, string) error
 }
 
+// HardwareInventoryClient is implemented by the clients able to report hardware inventory of the host.
+type HardwareInventoryClient interface {
+	HardwareInventory(context.Context) (hardware.Inventory, error)
+}
+
+// BIOSClient is implemented by the clients able to read and change BIOS settings of the host. The changed
+// settings are applied by BIOS on the next reboot of the host.
+type BIOSClient interface {
+	BIOSSettings(context.Context) (hardware.BIOSSettings, error)
+	SetBIOSSettings(context.Context, hardware.BIOSSettings) error
+}
+
+// FirmwareClient is implemented by the clients able to report and update firmware of the host. UpdateFirmware
+// returns once the BMC completes the update from the image, some components apply it on the next reboot only.
+type FirmwareClient interface {
+	FirmwareInventory(context.Context) ([]hardware.Firmware, error)
+	UpdateFirmware(ctx context.Context, imageURL string) error
+}
+
 // ClientFactory is a function to be used
 type ClientFactory func(name string,
 	redfishURL string,
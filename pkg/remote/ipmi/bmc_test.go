@@ -0,0 +1,268 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ipmi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	completionInvalidCommand = 0xc1
+	completionInvalidData    = 0xcc
+)
+
+// fakeBMC is an in-process stand-in of the BMC serving IPMI v2.0 sessions over UDP on the loopback interface
+type fakeBMC struct {
+	conn     net.PacketConn
+	username string
+	password string
+
+	mu sync.Mutex
+	// stuck makes the BMC ignore chassis control commands
+	stuck          bool
+	powerOn        bool
+	bootDevice     BootDevice
+	sessions       map[uint32]*fakeSession
+	lastSessionID  uint32
+	closedSessions int
+}
+
+type fakeSession struct {
+	rakpExchange
+	keys *sessionKeys
+}
+
+func newFakeBMC(t *testing.T, username, password string) *fakeBMC {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	bmc := &fakeBMC{
+		conn:     conn,
+		username: username,
+		password: password,
+		sessions: make(map[uint32]*fakeSession),
+	}
+	t.Cleanup(func() { conn.Close() })
+	go bmc.serve()
+	return bmc
+}
+
+func (b *fakeBMC) address() string {
+	return "ipmi://" + b.conn.LocalAddr().String()
+}
+
+func (b *fakeBMC) state() (bool, BootDevice, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.powerOn, b.bootDevice, b.closedSessions
+}
+
+func (b *fakeBMC) serve() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := b.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if resp := b.handle(append([]byte{}, buf[:n]...)); resp != nil {
+			b.conn.WriteTo(resp, addr) //nolint:errcheck
+		}
+	}
+}
+
+func (b *fakeBMC) handle(in []byte) []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(in) > rmcpHeaderSize && in[rmcpHeaderSize] == authTypeNone {
+		p, err := decodePacket(in, nil)
+		if err != nil {
+			return nil
+		}
+		m, err := decodeMessage(p.payload)
+		if err != nil || m.netFn != netFnApp || m.cmd != cmdGetChannelAuthCapabilities.code {
+			return nil
+		}
+		return encodeV15Packet(response(m, 0x01, 0x80|0x04, 0x00, 0x02, 0, 0, 0, 0))
+	}
+
+	if len(in) < rmcpHeaderSize+sessionHeaderSize {
+		return nil
+	}
+	sessionID := binary.LittleEndian.Uint32(in[6:10])
+	if sessionID != 0 {
+		s, ok := b.sessions[sessionID]
+		if !ok || s.keys == nil {
+			return nil
+		}
+		return b.handleCommand(s, in)
+	}
+
+	p, err := decodePacket(in, nil)
+	if err != nil {
+		return nil
+	}
+	var resp packet
+	switch p.payloadType {
+	case payloadOpenSessionRequest:
+		resp = b.openSession(p.payload)
+	case payloadRAKP1:
+		resp = b.rakp2(p.payload)
+	case payloadRAKP3:
+		resp = b.rakp4(p.payload)
+	default:
+		return nil
+	}
+	out, err := encodePacket(resp, nil)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func (b *fakeBMC) openSession(req []byte) packet {
+	b.lastSessionID++
+	s := &fakeSession{rakpExchange: rakpExchange{
+		consoleID: binary.LittleEndian.Uint32(req[4:8]),
+		bmcID:     b.lastSessionID,
+	}}
+	b.sessions[s.bmcID] = s
+
+	resp := []byte{req[0], 0, privilegeAdministrator, 0}
+	resp = appendUint32(resp, s.consoleID)
+	resp = appendUint32(resp, s.bmcID)
+	resp = append(resp, req[8:32]...)
+	return packet{payloadType: payloadOpenSessionResponse, payload: resp}
+}
+
+func (b *fakeBMC) rakp2(req []byte) packet {
+	s, ok := b.sessions[binary.LittleEndian.Uint32(req[4:8])]
+	if !ok {
+		return packet{payloadType: payloadRAKP2, payload: []byte{req[0], 0x02, 0, 0, 0, 0, 0, 0}}
+	}
+	s.consoleRandom = req[8:24]
+	s.role = []byte{req[24], req[27]}
+	s.username = req[28 : 28+int(req[27])]
+	s.bmcRandom = randomBytes(randomNumberSize)
+	s.guid = randomBytes(guidSize)
+
+	resp := []byte{req[0], 0, 0, 0}
+	resp = appendUint32(resp, s.consoleID)
+	if !bytes.Equal(s.username, []byte(b.username)) {
+		resp[1] = 0x0d
+		return packet{payloadType: payloadRAKP2, payload: resp}
+	}
+	resp = append(resp, s.bmcRandom...)
+	resp = append(resp, s.guid...)
+	resp = append(resp, s.bmcAuthCode([]byte(b.password))...)
+	return packet{payloadType: payloadRAKP2, payload: resp}
+}
+
+func (b *fakeBMC) rakp4(req []byte) packet {
+	s, ok := b.sessions[binary.LittleEndian.Uint32(req[4:8])]
+	if !ok {
+		return packet{payloadType: payloadRAKP4, payload: []byte{req[0], 0x02, 0, 0, 0, 0, 0, 0}}
+	}
+	resp := []byte{req[0], 0, 0, 0}
+	resp = appendUint32(resp, s.consoleID)
+
+	if !bytes.Equal(s.consoleAuthCode([]byte(b.password)), req[8:]) {
+		resp[1] = 0x0f
+		return packet{payloadType: payloadRAKP4, payload: resp}
+	}
+
+	sik := s.sessionIntegrityKey([]byte(b.password))
+	s.keys = newSessionKeys(sik)
+	resp = append(resp, s.integrityCheck(sik)...)
+	return packet{payloadType: payloadRAKP4, payload: resp}
+}
+
+func (b *fakeBMC) handleCommand(s *fakeSession, in []byte) []byte {
+	p, err := decodePacket(in, s.keys)
+	if err != nil {
+		return nil
+	}
+	m, err := decodeMessage(p.payload)
+	if err != nil {
+		return nil
+	}
+
+	var msg []byte
+	switch {
+	case m.netFn == netFnApp && m.cmd == cmdSetSessionPrivilegeLevel.code:
+		msg = response(m, m.data[0])
+	case m.netFn == netFnApp && m.cmd == cmdCloseSession.code:
+		delete(b.sessions, s.bmcID)
+		b.closedSessions++
+		msg = response(m)
+	case m.netFn == netFnChassis && m.cmd == cmdGetChassisStatus.code:
+		var state byte
+		if b.powerOn {
+			state = chassisPowerOnMask
+		}
+		msg = response(m, state, 0, 0)
+	case m.netFn == netFnChassis && m.cmd == cmdChassisControl.code:
+		if !b.stuck {
+			b.powerOn = m.data[0] == chassisPowerUp
+		}
+		msg = response(m)
+	case m.netFn == netFnChassis && m.cmd == cmdSetSystemBootOptions.code:
+		device := BootDevice(m.data[2] >> 2)
+		if _, ok := bootDeviceNames[device]; !ok {
+			msg = failure(m, completionInvalidData)
+			break
+		}
+		b.bootDevice = device
+		msg = response(m)
+	default:
+		msg = failure(m, completionInvalidCommand)
+	}
+
+	out, err := encodePacket(packet{payloadType: payloadIPMI, sessionID: s.consoleID, seq: p.seq, payload: msg}, s.keys)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// failure encodes response to the request with the given completion code
+func failure(req message, code byte) []byte {
+	return encodeMessage(remoteSWID, bmcSlaveAddr, message{netFn: req.netFn | 1, cmd: req.cmd, seq: req.seq,
+		data: []byte{code}})
+}
+
+// response encodes successful response to the request with the given data
+func response(req message, data ...byte) []byte {
+	return encodeMessage(remoteSWID, bmcSlaveAddr, message{
+		netFn: req.netFn | 1,
+		cmd:   req.cmd,
+		seq:   req.seq,
+		data:  append([]byte{completionOK}, data...),
+	})
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b) //nolint:errcheck
+	return b
+}
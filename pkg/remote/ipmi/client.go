@@ -0,0 +1,303 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package ipmi implements out-of-band power management of the hosts over IPMI v2.0 (lanplus) for the BMCs
+// that don't provide Redfish API.
+package ipmi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+)
+
+const (
+	// ClientType is used by other packages as the identifier of the IPMI client.
+	ClientType = "ipmi"
+
+	addressScheme  = "ipmi"
+	defaultPort    = "623"
+	defaultTimeout = 2 * time.Second
+	// defaultAttempts is the number of times a request is sent before the BMC is considered unreachable
+	defaultAttempts = 3
+)
+
+// chassis control actions and status
+const (
+	chassisPowerDown   = 0x00
+	chassisPowerUp     = 0x01
+	chassisPowerOnMask = 0x01
+)
+
+// system boot options
+const (
+	bootParamBootFlags = 0x05
+	bootFlagsValid     = 0x80
+)
+
+// BootDevice is the device the host boots from on the next boot, see "Set System Boot Options" command
+// of IPMI specification
+type BootDevice byte
+
+const (
+	// BootDevicePXE forces PXE boot
+	BootDevicePXE BootDevice = 0x01
+	// BootDeviceDisk forces boot from default hard drive
+	BootDeviceDisk BootDevice = 0x02
+	// BootDeviceCDROM forces boot from default CD/DVD
+	BootDeviceCDROM BootDevice = 0x05
+	// BootDeviceBIOS forces boot into BIOS setup
+	BootDeviceBIOS BootDevice = 0x06
+)
+
+var bootDeviceNames = map[BootDevice]string{
+	BootDevicePXE:   "PXE",
+	BootDeviceDisk:  "Disk",
+	BootDeviceCDROM: "CD/DVD",
+	BootDeviceBIOS:  "BIOS setup",
+}
+
+// String provides a human-readable name of the boot device.
+func (d BootDevice) String() string {
+	if name, ok := bootDeviceNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02x", byte(d))
+}
+
+// Client holds details about an IPMI out-of-band system required for out-of-band management.
+type Client struct {
+	nodeID              string
+	nodeName            string
+	address             string
+	username            string
+	password            string
+	systemActionRetries int
+	systemRebootDelay   int
+
+	// timeout is the time to wait for the response before the request is sent again
+	timeout time.Duration
+	// attempts is the number of times the request is sent before the BMC is considered unreachable
+	attempts int
+
+	// Sleep is meant to be mocked out for tests
+	Sleep func(d time.Duration)
+}
+
+// NodeID retrieves the ephemeral node ID, i.e. the address of its BMC.
+func (c *Client) NodeID() string {
+	return c.nodeID
+}
+
+// NodeName retrieves the ephemeral node name.
+func (c *Client) NodeName() string {
+	return c.nodeName
+}
+
+// EjectVirtualMedia does nothing since IPMI doesn't provide virtual media.
+func (c *Client) EjectVirtualMedia(ctx context.Context) error {
+	return nil
+}
+
+// VirtualMediaInserted always reports that no media is inserted since IPMI doesn't provide virtual media.
+func (c *Client) VirtualMediaInserted(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+// SetVirtualMedia isn't supported by IPMI.
+func (c *Client) SetVirtualMedia(ctx context.Context, isoPath string) error {
+	return ErrOperationNotSupported{Operation: "set virtual media"}
+}
+
+// RemoteDirect isn't supported by IPMI since it requires virtual media.
+func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
+	return ErrOperationNotSupported{Operation: "remote direct"}
+}
+
+// SetBootSourceByType sets the boot source of the host to the CD/DVD for the next boot.
+func (c *Client) SetBootSourceByType(ctx context.Context) error {
+	return c.SetBootDevice(ctx, BootDeviceCDROM)
+}
+
+// SetBootDevice overrides the boot device of the host for the next boot.
+func (c *Client) SetBootDevice(ctx context.Context, device BootDevice) error {
+	log.Debugf("Setting boot device of node '%s' to '%s'.", c.nodeID, device)
+	return c.withSession(ctx, func(s *session) error {
+		_, err := s.command(ctx, cmdSetSystemBootOptions,
+			[]byte{bootParamBootFlags, bootFlagsValid, byte(device) << 2, 0, 0, 0})
+		return err
+	})
+}
+
+// SystemPowerOff shuts down a host.
+func (c *Client) SystemPowerOff(ctx context.Context) error {
+	if err := c.chassisControl(ctx, chassisPowerDown); err != nil {
+		return err
+	}
+	return c.waitForPowerState(ctx, power.StatusOff)
+}
+
+// SystemPowerOn powers on a host.
+func (c *Client) SystemPowerOn(ctx context.Context) error {
+	if err := c.chassisControl(ctx, chassisPowerUp); err != nil {
+		return err
+	}
+	return c.waitForPowerState(ctx, power.StatusOn)
+}
+
+// RebootSystem power cycles a host by sending a power down command followed by a power up command.
+func (c *Client) RebootSystem(ctx context.Context) error {
+	log.Debugf("Rebooting node '%s': powering off.", c.nodeID)
+	if err := c.SystemPowerOff(ctx); err != nil {
+		log.Debugf("Failed to reboot node '%s': shutdown failure.", c.nodeID)
+		return err
+	}
+
+	log.Debugf("Rebooting node '%s': powering on.", c.nodeID)
+	if err := c.SystemPowerOn(ctx); err != nil {
+		log.Debugf("Failed to reboot node '%s': startup failure.", c.nodeID)
+		return err
+	}
+	return nil
+}
+
+// SystemPowerStatus retrieves the power status of a host as a human-readable string.
+func (c *Client) SystemPowerStatus(ctx context.Context) (power.Status, error) {
+	status := power.StatusUnknown
+	err := c.withSession(ctx, func(s *session) error {
+		data, err := s.command(ctx, cmdGetChassisStatus, nil)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return ErrInvalidPacket{Message: "empty chassis status"}
+		}
+
+		status = power.StatusOff
+		if data[0]&chassisPowerOnMask != 0 {
+			status = power.StatusOn
+		}
+		return nil
+	})
+	return status, err
+}
+
+func (c *Client) chassisControl(ctx context.Context, action byte) error {
+	return c.withSession(ctx, func(s *session) error {
+		_, err := s.command(ctx, cmdChassisControl, []byte{action})
+		return err
+	})
+}
+
+// withSession runs the function within the session with the BMC, the session is closed afterwards
+func (c *Client) withSession(ctx context.Context, fn func(*session) error) error {
+	s, err := openSession(ctx, c.address, c.username, c.password, c.timeout, c.attempts)
+	if err != nil {
+		return err
+	}
+	defer s.close(ctx)
+	return fn(s)
+}
+
+func (c *Client) waitForPowerState(ctx context.Context, desiredState power.Status) error {
+	log.Debugf("Waiting for node '%s' to reach power state '%s'.", c.nodeID, desiredState)
+
+	for retry := 0; retry <= c.systemActionRetries; retry++ {
+		state, err := c.SystemPowerStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		if state == desiredState {
+			log.Debugf("Node '%s' reached power state '%s'.", c.nodeID, desiredState)
+			return nil
+		}
+
+		c.Sleep(time.Duration(c.systemRebootDelay) * time.Second)
+	}
+
+	return ErrOperationRetriesExceeded{
+		What:    fmt.Sprintf("reach desired power state %s", desiredState),
+		Retries: c.systemActionRetries,
+	}
+}
+
+// parseAddress returns UDP address of the BMC given as ipmi://<host>[:<port>] or <host>[:<port>]
+func parseAddress(bmcAddress string) (string, error) {
+	address := bmcAddress
+	if strings.Contains(bmcAddress, "://") {
+		u, err := url.Parse(bmcAddress)
+		if err != nil || u.Scheme != addressScheme || (u.Path != "" && u.Path != "/") {
+			return "", ErrInvalidBMCAddress{Address: bmcAddress}
+		}
+		address = u.Host
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host, port = strings.Trim(address, "[]"), defaultPort
+	}
+	if host == "" || (strings.ContainsAny(host, "/:@") && net.ParseIP(host) == nil) {
+		return "", ErrInvalidBMCAddress{Address: bmcAddress}
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// NewClient returns a client with the capability to manage the host over IPMI v2.0.
+func NewClient(nodeName string, bmcAddress string,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (*Client, error) {
+	address, err := parseAddress(bmcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		nodeID:              address,
+		nodeName:            nodeName,
+		address:             address,
+		username:            username,
+		password:            password,
+		systemActionRetries: systemActionRetries,
+		systemRebootDelay:   systemRebootDelay,
+		timeout:             defaultTimeout,
+		attempts:            defaultAttempts,
+
+		Sleep: func(d time.Duration) {
+			time.Sleep(d)
+		},
+	}
+
+	return c, nil
+}
+
+// ClientFactory is a constructor for IPMI ifc.Client implementation, TLS and proxy settings don't apply to IPMI
+var ClientFactory ifc.ClientFactory = func(nodeName string, bmcAddress string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (ifc.Client, error) {
+	return NewClient(nodeName, bmcAddress, username, password, systemActionRetries, systemRebootDelay)
+}
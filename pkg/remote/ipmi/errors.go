@@ -0,0 +1,87 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ErrInvalidBMCAddress is returned when BMC address can't be used to reach the host over IPMI
+type ErrInvalidBMCAddress struct {
+	Address string
+}
+
+func (e ErrInvalidBMCAddress) Error() string {
+	return fmt.Sprintf("invalid IPMI BMC address '%s', expected format is ipmi://<host>[:<port>]", e.Address)
+}
+
+// ErrInvalidPacket describes a malformed packet received from the BMC
+type ErrInvalidPacket struct {
+	Message string
+}
+
+func (e ErrInvalidPacket) Error() string {
+	return fmt.Sprintf("invalid IPMI packet: %s", e.Message)
+}
+
+// ErrNoResponse is returned when the BMC doesn't respond to the request
+type ErrNoResponse struct {
+	Address  string
+	Request  string
+	Attempts int
+}
+
+func (e ErrNoResponse) Error() string {
+	return fmt.Sprintf("no response from IPMI BMC at '%s' to '%s' after %d attempts", e.Address, e.Request, e.Attempts)
+}
+
+// ErrSessionFailed is returned when IPMI v2.0 session can't be established with the BMC
+type ErrSessionFailed struct {
+	Address string
+	Message string
+}
+
+func (e ErrSessionFailed) Error() string {
+	return fmt.Sprintf("unable to establish IPMI session with BMC at '%s': %s", e.Address, e.Message)
+}
+
+// ErrCompletionCode is returned when IPMI command completes with an error
+type ErrCompletionCode struct {
+	Command string
+	Code    byte
+}
+
+func (e ErrCompletionCode) Error() string {
+	return fmt.Sprintf("IPMI command '%s' failed with completion code 0x%02x", e.Command, e.Code)
+}
+
+// ErrOperationNotSupported is returned for the operations IPMI doesn't provide, e.g. virtual media
+type ErrOperationNotSupported struct {
+	Operation string
+}
+
+func (e ErrOperationNotSupported) Error() string {
+	return fmt.Sprintf("operation '%s' is not supported by IPMI management", e.Operation)
+}
+
+// ErrOperationRetriesExceeded is returned when the host doesn't reach the desired state in time
+type ErrOperationRetriesExceeded struct {
+	What    string
+	Retries int
+}
+
+func (e ErrOperationRetriesExceeded) Error() string {
+	return fmt.Sprintf("Unable to %s. Maximum retries (%d) exceeded.", e.What, e.Retries)
+}
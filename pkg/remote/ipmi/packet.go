@@ -0,0 +1,332 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ipmi
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	// IPMI v2.0 RAKP-HMAC-SHA1 authentication and HMAC-SHA1-96 integrity algorithms are defined on top of SHA1
+	"crypto/sha1" //nolint:gosec
+	"encoding/binary"
+	"io"
+)
+
+// RMCP and IPMI v2.0 session header fields
+const (
+	rmcpVersion   = 0x06
+	rmcpSeqNoAck  = 0xff
+	rmcpClassIPMI = 0x07
+
+	authTypeNone     = 0x00
+	authTypeRMCPPlus = 0x06
+
+	payloadIPMI                = 0x00
+	payloadOpenSessionRequest  = 0x10
+	payloadOpenSessionResponse = 0x11
+	payloadRAKP1               = 0x12
+	payloadRAKP2               = 0x13
+	payloadRAKP3               = 0x14
+	payloadRAKP4               = 0x15
+
+	payloadEncrypted     = 0x80
+	payloadAuthenticated = 0x40
+	payloadTypeMask      = 0x3f
+
+	rmcpHeaderSize     = 4
+	sessionHeaderSize  = 12
+	v15HeaderSize      = 10
+	authCodeSize       = 12
+	maxPacketSize      = 1024
+	minIPMIMessageSize = 7
+)
+
+// IPMI message fields
+const (
+	bmcSlaveAddr = 0x20
+	remoteSWID   = 0x81
+
+	netFnChassis = 0x00
+	netFnApp     = 0x06
+
+	completionOK = 0x00
+)
+
+// packet is the payload of RMCP packet along with the session header fields
+type packet struct {
+	payloadType byte
+	sessionID   uint32
+	seq         uint32
+	payload     []byte
+}
+
+// message is IPMI request or response, data of the response starts with the completion code
+type message struct {
+	netFn byte
+	cmd   byte
+	seq   byte
+	data  []byte
+}
+
+// rakpExchange holds the values authenticated during RAKP exchange, role is the requested privilege
+// level followed by the length of the user name
+type rakpExchange struct {
+	consoleID     uint32
+	bmcID         uint32
+	consoleRandom []byte
+	bmcRandom     []byte
+	guid          []byte
+	role          []byte
+	username      []byte
+}
+
+// bmcAuthCode returns the key exchange authentication code of RAKP message 2 which proves that the BMC
+// knows the password of the user
+func (r rakpExchange) bmcAuthCode(password []byte) []byte {
+	return hmacSHA1(password, uint32Bytes(r.consoleID), uint32Bytes(r.bmcID), r.consoleRandom, r.bmcRandom,
+		r.guid, r.role, r.username)
+}
+
+// consoleAuthCode returns the key exchange authentication code of RAKP message 3 which proves that the
+// remote console knows the password of the user
+func (r rakpExchange) consoleAuthCode(password []byte) []byte {
+	return hmacSHA1(password, r.bmcRandom, uint32Bytes(r.consoleID), r.role, r.username)
+}
+
+// sessionIntegrityKey returns the session integrity key (SIK) both sides derive from the password of the user
+func (r rakpExchange) sessionIntegrityKey(password []byte) []byte {
+	return hmacSHA1(password, r.consoleRandom, r.bmcRandom, r.role, r.username)
+}
+
+// integrityCheck returns the integrity check value of RAKP message 4 which proves that the BMC has derived
+// the same session integrity key
+func (r rakpExchange) integrityCheck(sik []byte) []byte {
+	return hmacSHA1(sik, r.consoleRandom, uint32Bytes(r.bmcID), r.guid)[:authCodeSize]
+}
+
+// sessionKeys are the keys derived from the session integrity key, K1 authenticates the packets
+// and the first 16 bytes of K2 encrypt their payloads
+type sessionKeys struct {
+	k1     []byte
+	aesKey []byte
+	// random is the source of the initialization vectors
+	random io.Reader
+}
+
+func newSessionKeys(sik []byte) *sessionKeys {
+	const keySize = 20
+	return &sessionKeys{
+		k1:     hmacSHA1(sik, bytesOf(0x01, keySize)),
+		aesKey: hmacSHA1(sik, bytesOf(0x02, keySize))[:aes.BlockSize],
+		random: rand.Reader,
+	}
+}
+
+// authCode returns HMAC-SHA1-96 of the data
+func (k *sessionKeys) authCode(data []byte) []byte {
+	return hmacSHA1(k.k1, data)[:authCodeSize]
+}
+
+// encrypt returns the data encrypted with AES-CBC-128 prefixed with the initialization vector
+func (k *sessionKeys) encrypt(data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(k.aesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	padSize := (aes.BlockSize - (len(data)+1)%aes.BlockSize) % aes.BlockSize
+	plain := make([]byte, 0, len(data)+padSize+1)
+	plain = append(plain, data...)
+	for i := 1; i <= padSize; i++ {
+		plain = append(plain, byte(i))
+	}
+	plain = append(plain, byte(padSize))
+
+	out := make([]byte, aes.BlockSize+len(plain))
+	if _, err = io.ReadFull(k.random, out[:aes.BlockSize]); err != nil {
+		return nil, err
+	}
+	cipher.NewCBCEncrypter(block, out[:aes.BlockSize]).CryptBlocks(out[aes.BlockSize:], plain)
+	return out, nil
+}
+
+// decrypt returns the data encrypted by encrypt
+func (k *sessionKeys) decrypt(data []byte) ([]byte, error) {
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil, ErrInvalidPacket{Message: "encrypted payload is not aligned to the cipher block size"}
+	}
+	block, err := aes.NewCipher(k.aesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, data[:aes.BlockSize]).CryptBlocks(plain, data[aes.BlockSize:])
+	padSize := int(plain[len(plain)-1])
+	if padSize >= aes.BlockSize {
+		return nil, ErrInvalidPacket{Message: "invalid confidentiality pad"}
+	}
+	return plain[:len(plain)-padSize-1], nil
+}
+
+// encodePacket encodes IPMI v2.0 packet, the payload is encrypted and the packet is authenticated if
+// session keys are given
+func encodePacket(p packet, keys *sessionKeys) ([]byte, error) {
+	payloadType, payload := p.payloadType, p.payload
+	if keys != nil {
+		payloadType |= payloadEncrypted | payloadAuthenticated
+		var err error
+		if payload, err = keys.encrypt(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	b := []byte{rmcpVersion, 0, rmcpSeqNoAck, rmcpClassIPMI, authTypeRMCPPlus, payloadType}
+	b = appendUint32(b, p.sessionID)
+	b = appendUint32(b, p.seq)
+	b = append(b, byte(len(payload)), byte(len(payload)>>8))
+	b = append(b, payload...)
+	if keys == nil {
+		return b, nil
+	}
+
+	// integrity pad makes the authenticated part of the packet, from the auth type through the next
+	// header, a multiple of 4 bytes
+	padSize := (4 - (len(b)-rmcpHeaderSize+2)%4) % 4
+	b = append(b, bytesOf(0xff, padSize)...)
+	b = append(b, byte(padSize), rmcpClassIPMI)
+	return append(b, keys.authCode(b[rmcpHeaderSize:])...), nil
+}
+
+// encodeV15Packet encodes IPMI v1.5 packet sent outside of a session
+func encodeV15Packet(msg []byte) []byte {
+	b := []byte{rmcpVersion, 0, rmcpSeqNoAck, rmcpClassIPMI, authTypeNone}
+	b = append(b, make([]byte, 8)...)
+	b = append(b, byte(len(msg)))
+	return append(b, msg...)
+}
+
+// decodePacket decodes IPMI v2.0 packet or IPMI v1.5 packet sent outside of a session, integrity of
+// the authenticated packets is verified and encrypted payloads are decrypted using the session keys
+func decodePacket(b []byte, keys *sessionKeys) (packet, error) {
+	if len(b) < rmcpHeaderSize+v15HeaderSize || b[0] != rmcpVersion || b[3] != rmcpClassIPMI {
+		return packet{}, ErrInvalidPacket{Message: "not an RMCP packet of IPMI class"}
+	}
+
+	if b[4] == authTypeNone {
+		size := int(b[rmcpHeaderSize+v15HeaderSize-1])
+		payload := b[rmcpHeaderSize+v15HeaderSize:]
+		if len(payload) < size {
+			return packet{}, ErrInvalidPacket{Message: "truncated payload"}
+		}
+		return packet{payloadType: payloadIPMI, payload: payload[:size]}, nil
+	}
+
+	headerEnd := rmcpHeaderSize + sessionHeaderSize
+	if b[4] != authTypeRMCPPlus || len(b) < headerEnd {
+		return packet{}, ErrInvalidPacket{Message: "unsupported session header"}
+	}
+	p := packet{
+		payloadType: b[5] & payloadTypeMask,
+		sessionID:   binary.LittleEndian.Uint32(b[6:10]),
+		seq:         binary.LittleEndian.Uint32(b[10:14]),
+	}
+	size := int(binary.LittleEndian.Uint16(b[14:16]))
+	if len(b) < headerEnd+size {
+		return packet{}, ErrInvalidPacket{Message: "truncated payload"}
+	}
+	p.payload = b[headerEnd : headerEnd+size]
+
+	if b[5]&(payloadAuthenticated|payloadEncrypted) != 0 && keys == nil {
+		return packet{}, ErrInvalidPacket{Message: "secured packet received outside of a session"}
+	}
+	if b[5]&payloadAuthenticated != 0 {
+		if len(b) < headerEnd+size+2+authCodeSize {
+			return packet{}, ErrInvalidPacket{Message: "truncated session trailer"}
+		}
+		end := len(b) - authCodeSize
+		if !hmac.Equal(keys.authCode(b[rmcpHeaderSize:end]), b[end:]) {
+			return packet{}, ErrInvalidPacket{Message: "integrity check failed"}
+		}
+	}
+	if b[5]&payloadEncrypted != 0 {
+		var err error
+		if p.payload, err = keys.decrypt(p.payload); err != nil {
+			return packet{}, err
+		}
+	}
+	return p, nil
+}
+
+// encodeMessage encodes IPMI message, addresses of the requester and the responder are swapped in
+// the responses
+func encodeMessage(dstAddr, srcAddr byte, m message) []byte {
+	b := []byte{dstAddr, m.netFn << 2}
+	b = append(b, checksum(b))
+	body := append([]byte{srcAddr, m.seq << 2, m.cmd}, m.data...)
+	b = append(b, body...)
+	return append(b, checksum(body))
+}
+
+// decodeMessage decodes IPMI message and verifies its checksums
+func decodeMessage(b []byte) (message, error) {
+	if len(b) < minIPMIMessageSize {
+		return message{}, ErrInvalidPacket{Message: "IPMI message is too short"}
+	}
+	if checksum(b[:3]) != 0 || checksum(b[3:]) != 0 {
+		return message{}, ErrInvalidPacket{Message: "IPMI message checksum mismatch"}
+	}
+	return message{
+		netFn: b[1] >> 2,
+		seq:   b[4] >> 2,
+		cmd:   b[5],
+		data:  b[6 : len(b)-1],
+	}, nil
+}
+
+// checksum returns two's complement of the sum of the bytes, so that the sum of the bytes
+// along with the checksum is zero
+func checksum(b []byte) byte {
+	var sum byte
+	for _, v := range b {
+		sum += v
+	}
+	return -sum
+}
+
+func hmacSHA1(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(sha1.New, key)
+	for _, d := range data {
+		mac.Write(d) //nolint:errcheck
+	}
+	return mac.Sum(nil)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func uint32Bytes(v uint32) []byte {
+	return appendUint32(nil, v)
+}
+
+func bytesOf(v byte, n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = v
+	}
+	return b
+}
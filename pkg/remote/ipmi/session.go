@@ -0,0 +1,363 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ipmi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// RMCP+ session establishment fields
+const (
+	privilegeAdministrator = 0x04
+	// privilegeNameOnlyLookup makes the BMC look the user up by name only, not by name and privilege
+	privilegeNameOnlyLookup = 0x10
+
+	authAlgRAKPHMACSHA1           = 0x01
+	integrityAlgHMACSHA196        = 0x01
+	confidentialityAlgAESCBC128   = 0x01
+	channelCurrent                = 0x0e
+	channelExtendedCapabilities   = 0x80
+	channelIPMIv20Supported       = 0x02
+	randomNumberSize              = 16
+	guidSize                      = 16
+	rakpAuthCodeSize              = 20
+	openSessionResponseSize       = 36
+	rakp2Size                     = 8 + 2*randomNumberSize + rakpAuthCodeSize
+	rakp4Size                     = 8 + authCodeSize
+	channelAuthCapabilitiesSize   = 8
+	algorithmPayloadAlgorithmByte = 4
+	// requestSeqMask limits the request sequence number of IPMI message to 6 bits
+	requestSeqMask = 0x3f
+)
+
+// command identifies IPMI command by its network function and code
+type command struct {
+	name  string
+	netFn byte
+	code  byte
+}
+
+var (
+	cmdGetChassisStatus           = command{"get chassis status", netFnChassis, 0x01}
+	cmdChassisControl             = command{"chassis control", netFnChassis, 0x02}
+	cmdSetSystemBootOptions       = command{"set system boot options", netFnChassis, 0x08}
+	cmdGetChannelAuthCapabilities = command{"get channel authentication capabilities", netFnApp, 0x38}
+	cmdSetSessionPrivilegeLevel   = command{"set session privilege level", netFnApp, 0x3b}
+	cmdCloseSession               = command{"close session", netFnApp, 0x3c}
+)
+
+// rakpStatusMessages describe the most common status codes of RMCP+ session establishment responses
+var rakpStatusMessages = map[byte]string{
+	0x01: "insufficient resources to create a session",
+	0x02: "invalid session ID",
+	0x0d: "unauthorized name",
+	0x0e: "unauthorized role or privilege level",
+	0x12: "invalid integrity check value",
+}
+
+// session is IPMI v2.0 (RMCP+) session with the BMC, every request is authenticated with HMAC-SHA1-96
+// and its payload is encrypted with AES-CBC-128 once the session is established
+type session struct {
+	conn     net.Conn
+	address  string
+	username []byte
+	password []byte
+	timeout  time.Duration
+	attempts int
+
+	consoleID uint32
+	bmcID     uint32
+	seq       uint32
+	rqSeq     byte
+	tag       byte
+	keys      *sessionKeys
+}
+
+// openSession establishes authenticated session with the BMC at the address
+func openSession(ctx context.Context, address, username, password string,
+	timeout time.Duration, attempts int) (*session, error) {
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &session{
+		conn:     conn,
+		address:  address,
+		username: []byte(username),
+		password: []byte(password),
+		timeout:  timeout,
+		attempts: attempts,
+	}
+	if err = s.establish(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// close closes the session with the BMC
+func (s *session) close(ctx context.Context) {
+	if _, err := s.command(ctx, cmdCloseSession, uint32Bytes(s.bmcID)); err != nil {
+		log.Debugf("Failed to close IPMI session with BMC at '%s': %v", s.address, err)
+	}
+	if err := s.conn.Close(); err != nil {
+		log.Debugf("Failed to close connection to BMC at '%s': %v", s.address, err)
+	}
+}
+
+func (s *session) establish(ctx context.Context) error {
+	if err := s.checkChannel(ctx); err != nil {
+		return err
+	}
+	if err := s.openSession(ctx); err != nil {
+		return err
+	}
+	if err := s.authenticate(ctx); err != nil {
+		return err
+	}
+	_, err := s.command(ctx, cmdSetSessionPrivilegeLevel, []byte{privilegeAdministrator})
+	return err
+}
+
+// checkChannel makes sure the BMC supports IPMI v2.0 sessions
+func (s *session) checkChannel(ctx context.Context) error {
+	data, err := s.command(ctx, cmdGetChannelAuthCapabilities,
+		[]byte{channelExtendedCapabilities | channelCurrent, privilegeAdministrator})
+	if err != nil {
+		return err
+	}
+	if len(data) < channelAuthCapabilitiesSize || data[1]&channelExtendedCapabilities == 0 ||
+		data[3]&channelIPMIv20Supported == 0 {
+		return ErrSessionFailed{Address: s.address, Message: "BMC doesn't support IPMI v2.0 (lanplus) sessions"}
+	}
+	return nil
+}
+
+// openSession negotiates the algorithms and session IDs
+func (s *session) openSession(ctx context.Context) error {
+	var err error
+	if s.consoleID, err = randomSessionID(); err != nil {
+		return err
+	}
+
+	s.tag++
+	req := []byte{s.tag, privilegeAdministrator, 0, 0}
+	req = appendUint32(req, s.consoleID)
+	req = append(req, algorithmPayload(0x00, authAlgRAKPHMACSHA1)...)
+	req = append(req, algorithmPayload(0x01, integrityAlgHMACSHA196)...)
+	req = append(req, algorithmPayload(0x02, confidentialityAlgAESCBC128)...)
+
+	resp, err := s.exchangeRAKP(ctx, "open session", payloadOpenSessionRequest, payloadOpenSessionResponse,
+		req, openSessionResponseSize)
+	if err != nil {
+		return err
+	}
+	if resp[12+algorithmPayloadAlgorithmByte] != authAlgRAKPHMACSHA1 ||
+		resp[20+algorithmPayloadAlgorithmByte] != integrityAlgHMACSHA196 ||
+		resp[28+algorithmPayloadAlgorithmByte] != confidentialityAlgAESCBC128 {
+		return ErrSessionFailed{Address: s.address, Message: "BMC doesn't support HMAC-SHA1 and AES-CBC-128 algorithms"}
+	}
+	s.bmcID = binary.LittleEndian.Uint32(resp[8:12])
+	return nil
+}
+
+// authenticate performs RAKP exchange to authenticate the user and derive the session keys
+func (s *session) authenticate(ctx context.Context) error {
+	consoleRandom := make([]byte, randomNumberSize)
+	if _, err := rand.Read(consoleRandom); err != nil {
+		return err
+	}
+	role := []byte{privilegeAdministrator | privilegeNameOnlyLookup, byte(len(s.username))}
+
+	s.tag++
+	rakp1 := []byte{s.tag, 0, 0, 0}
+	rakp1 = appendUint32(rakp1, s.bmcID)
+	rakp1 = append(rakp1, consoleRandom...)
+	rakp1 = append(rakp1, role[0], 0, 0, role[1])
+	rakp1 = append(rakp1, s.username...)
+	rakp2, err := s.exchangeRAKP(ctx, "RAKP 1", payloadRAKP1, payloadRAKP2, rakp1, rakp2Size)
+	if err != nil {
+		return err
+	}
+
+	exchange := rakpExchange{
+		consoleID:     s.consoleID,
+		bmcID:         s.bmcID,
+		consoleRandom: consoleRandom,
+		bmcRandom:     rakp2[8 : 8+randomNumberSize],
+		guid:          rakp2[8+randomNumberSize : 8+randomNumberSize+guidSize],
+		role:          role,
+		username:      s.username,
+	}
+	if !hmac.Equal(exchange.bmcAuthCode(s.password), rakp2[8+randomNumberSize+guidSize:rakp2Size]) {
+		return ErrSessionFailed{Address: s.address, Message: "invalid user name or password"}
+	}
+	sik := exchange.sessionIntegrityKey(s.password)
+
+	s.tag++
+	rakp3 := []byte{s.tag, 0, 0, 0}
+	rakp3 = appendUint32(rakp3, s.bmcID)
+	rakp3 = append(rakp3, exchange.consoleAuthCode(s.password)...)
+	rakp4, err := s.exchangeRAKP(ctx, "RAKP 3", payloadRAKP3, payloadRAKP4, rakp3, rakp4Size)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(exchange.integrityCheck(sik), rakp4[8:rakp4Size]) {
+		return ErrSessionFailed{Address: s.address, Message: "BMC failed to prove the session integrity key"}
+	}
+	s.keys = newSessionKeys(sik)
+	return nil
+}
+
+// exchangeRAKP sends session establishment request and returns the response with the same message tag
+// after checking its status and the remote console session ID
+func (s *session) exchangeRAKP(ctx context.Context, name string, reqType, respType byte, req []byte,
+	respSize int) ([]byte, error) {
+	out, err := encodePacket(packet{payloadType: reqType, payload: req}, nil)
+	if err != nil {
+		return nil, err
+	}
+	tag := s.tag
+	p, err := s.exchange(ctx, name, out, func(p packet) bool {
+		return p.payloadType == respType && len(p.payload) >= 2 && p.payload[0] == tag
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := p.payload
+	if status := resp[1]; status != 0 {
+		msg, ok := rakpStatusMessages[status]
+		if !ok {
+			msg = fmt.Sprintf("status code 0x%02x", status)
+		}
+		return nil, ErrSessionFailed{Address: s.address, Message: fmt.Sprintf("%s rejected: %s", name, msg)}
+	}
+	if len(resp) < respSize || binary.LittleEndian.Uint32(resp[4:8]) != s.consoleID {
+		return nil, ErrSessionFailed{Address: s.address, Message: fmt.Sprintf("malformed response to %s", name)}
+	}
+	return resp, nil
+}
+
+// command sends IPMI request and returns the response data following the completion code
+func (s *session) command(ctx context.Context, cmd command, data []byte) ([]byte, error) {
+	s.rqSeq = (s.rqSeq + 1) & requestSeqMask
+	req := message{netFn: cmd.netFn, cmd: cmd.code, seq: s.rqSeq, data: data}
+	msg := encodeMessage(bmcSlaveAddr, remoteSWID, req)
+
+	out := encodeV15Packet(msg)
+	if s.keys != nil {
+		s.seq++
+		var err error
+		out, err = encodePacket(packet{payloadType: payloadIPMI, sessionID: s.bmcID, seq: s.seq, payload: msg}, s.keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var resp message
+	_, err := s.exchange(ctx, cmd.name, out, func(p packet) bool {
+		if p.payloadType != payloadIPMI || (s.keys != nil && p.sessionID != s.consoleID) {
+			return false
+		}
+		m, decodeErr := decodeMessage(p.payload)
+		if decodeErr != nil || m.netFn != cmd.netFn|1 || m.cmd != cmd.code || m.seq != req.seq || len(m.data) == 0 {
+			return false
+		}
+		resp = m
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.data[0] != completionOK {
+		return nil, ErrCompletionCode{Command: cmd.name, Code: resp.data[0]}
+	}
+	return resp.data[1:], nil
+}
+
+// exchange sends the packet and waits for the response accepted by match, the packet is sent again
+// if there is no response in time
+func (s *session) exchange(ctx context.Context, name string, out []byte, match func(packet) bool) (packet, error) {
+	buf := make([]byte, maxPacketSize)
+	for attempt := 0; attempt < s.attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return packet{}, err
+		}
+		if _, err := s.conn.Write(out); err != nil {
+			return packet{}, err
+		}
+
+		deadline := time.Now().Add(s.timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if err := s.conn.SetReadDeadline(deadline); err != nil {
+			return packet{}, err
+		}
+
+		for {
+			n, err := s.conn.Read(buf)
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				log.Debugf("No response from BMC at '%s' to '%s', attempt %d", s.address, name, attempt+1)
+				break
+			}
+			if err != nil {
+				return packet{}, err
+			}
+
+			p, err := decodePacket(buf[:n], s.keys)
+			if err != nil {
+				log.Debugf("Dropping packet from BMC at '%s': %v", s.address, err)
+				continue
+			}
+			if match(p) {
+				return p, nil
+			}
+		}
+	}
+	return packet{}, ErrNoResponse{Address: s.address, Request: name, Attempts: s.attempts}
+}
+
+// algorithmPayload encodes authentication, integrity or confidentiality algorithm of open session request
+func algorithmPayload(payloadType, algorithm byte) []byte {
+	return []byte{payloadType, 0, 0, 8, algorithm, 0, 0, 0}
+}
+
+// randomSessionID returns non-zero session ID, zero is reserved for the messages sent outside of a session
+func randomSessionID() (uint32, error) {
+	b := make([]byte, 4)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			return 0, err
+		}
+		if id := binary.LittleEndian.Uint32(b); id != 0 {
+			return id, nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+)
+
+// biosSystemResource is a subset of Redfish ComputerSystem resource referencing its BIOS and boot order
+type biosSystemResource struct {
+	Bios ODataID `json:"Bios"`
+	Boot struct {
+		BootOrder []string `json:"BootOrder,omitempty"`
+	} `json:"Boot"`
+}
+
+// biosResource is a subset of Redfish Bios resource
+type biosResource struct {
+	Attributes map[string]interface{} `json:"Attributes,omitempty"`
+	Settings   struct {
+		SettingsObject ODataID `json:"SettingsObject"`
+	} `json:"@Redfish.Settings"`
+}
+
+// settingsPath returns path of the resource accepting pending BIOS settings, BMCs which don't reference it
+// are expected to follow the path convention of the Redfish specification
+func (b biosResource) settingsPath(biosPath string) string {
+	if b.Settings.SettingsObject.ID != "" {
+		return b.Settings.SettingsObject.ID
+	}
+	return biosPath + "/Settings"
+}
+
+func (c *Client) biosSystem(ctx context.Context) (biosSystemResource, error) {
+	var system biosSystemResource
+	if err := c.Request(ctx, http.MethodGet, c.SystemPath(), nil, &system); err != nil {
+		return biosSystemResource{}, err
+	}
+	if system.Bios.ID == "" {
+		return biosSystemResource{}, ErrRedfishClient{Message: "system doesn't reference its BIOS"}
+	}
+	return system, nil
+}
+
+// BIOSSettings retrieves the current BIOS attributes from the Bios resource and the persistent boot order
+// from the ComputerSystem resource.
+func (c *Client) BIOSSettings(ctx context.Context) (hardware.BIOSSettings, error) {
+	system, err := c.biosSystem(ctx)
+	if err != nil {
+		return hardware.BIOSSettings{}, err
+	}
+
+	var bios biosResource
+	if err = c.Request(ctx, http.MethodGet, system.Bios.ID, nil, &bios); err != nil {
+		return hardware.BIOSSettings{}, err
+	}
+	return hardware.BIOSSettings{Attributes: bios.Attributes, BootOrder: system.Boot.BootOrder}, nil
+}
+
+// SetBIOSSettings submits the BIOS attributes to the settings resource of the Bios resource and the boot order
+// to the ComputerSystem resource. Only the given settings are submitted, BIOS applies them on the next reboot.
+func (c *Client) SetBIOSSettings(ctx context.Context, settings hardware.BIOSSettings) error {
+	system, err := c.biosSystem(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(settings.Attributes) != 0 {
+		var bios biosResource
+		if err = c.Request(ctx, http.MethodGet, system.Bios.ID, nil, &bios); err != nil {
+			return err
+		}
+
+		log.Debugf("Setting %d BIOS attributes of node '%s'.", len(settings.Attributes), c.nodeName)
+		body := map[string]interface{}{"Attributes": settings.Attributes}
+		if err = c.Request(ctx, http.MethodPatch, bios.settingsPath(system.Bios.ID), body, nil); err != nil {
+			return err
+		}
+	}
+
+	if len(settings.BootOrder) != 0 {
+		log.Debugf("Setting boot order of node '%s' to %v.", c.nodeName, settings.BootOrder)
+		body := map[string]interface{}{
+			"Boot": map[string]interface{}{"BootOrder": settings.BootOrder},
+		}
+		if err = c.Request(ctx, http.MethodPatch, c.SystemPath(), body, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
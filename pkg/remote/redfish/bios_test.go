@@ -0,0 +1,148 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+func biosResources(settingsObject bool) map[string]interface{} {
+	resources := testutil.SystemResources("1", "Contoso")
+	resources["/redfish/v1/Systems/1"] = map[string]interface{}{
+		"Id":   "1",
+		"Bios": map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/Bios"},
+		"Boot": map[string]interface{}{"BootOrder": []string{"Boot0001", "Boot0002"}},
+	}
+	bios := map[string]interface{}{
+		"Attributes": map[string]interface{}{"BootMode": "Uefi", "NumCores": 8, "SriovGlobalEnable": false},
+	}
+	if settingsObject {
+		bios["@Redfish.Settings"] = map[string]interface{}{
+			"SettingsObject": map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/Bios/Pending"},
+		}
+	}
+	resources["/redfish/v1/Systems/1/Bios"] = bios
+	return resources
+}
+
+func TestBIOSSettings(t *testing.T) {
+	server := testutil.NewServer(t, biosResources(false))
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	settings, err := client.BIOSSettings(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, hardware.BIOSSettings{
+		Attributes: map[string]interface{}{"BootMode": "Uefi", "NumCores": float64(8), "SriovGlobalEnable": false},
+		BootOrder:  []string{"Boot0001", "Boot0002"},
+	}, settings)
+}
+
+func TestBIOSSettingsNoBios(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources("1", "Contoso"))
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	_, err = client.BIOSSettings(context.Background())
+	assert.Equal(t, ErrRedfishClient{Message: "system doesn't reference its BIOS"}, err)
+}
+
+func TestSetBIOSSettings(t *testing.T) {
+	tests := []struct {
+		name             string
+		settingsObject   bool
+		settings         hardware.BIOSSettings
+		expectedRequests []testutil.Request
+	}{
+		{
+			name:           "attributes and boot order",
+			settingsObject: true,
+			settings: hardware.BIOSSettings{
+				Attributes: map[string]interface{}{"SriovGlobalEnable": true},
+				BootOrder:  []string{"Boot0002", "Boot0001"},
+			},
+			expectedRequests: []testutil.Request{
+				{
+					Method: http.MethodPatch,
+					Path:   "/redfish/v1/Systems/1/Bios/Pending",
+					Body: map[string]interface{}{
+						"Attributes": map[string]interface{}{"SriovGlobalEnable": true},
+					},
+				},
+				{
+					Method: http.MethodPatch,
+					Path:   "/redfish/v1/Systems/1",
+					Body: map[string]interface{}{
+						"Boot": map[string]interface{}{"BootOrder": []interface{}{"Boot0002", "Boot0001"}},
+					},
+				},
+			},
+		},
+		{
+			name: "attributes with default settings path",
+			settings: hardware.BIOSSettings{
+				Attributes: map[string]interface{}{"BootMode": "Bios"},
+			},
+			expectedRequests: []testutil.Request{{
+				Method: http.MethodPatch,
+				Path:   "/redfish/v1/Systems/1/Bios/Settings",
+				Body:   map[string]interface{}{"Attributes": map[string]interface{}{"BootMode": "Bios"}},
+			}},
+		},
+		{
+			name:             "nothing to set",
+			expectedRequests: []testutil.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.NewServer(t, biosResources(tt.settingsObject))
+			client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+				systemRebootDelay)
+			require.NoError(t, err)
+
+			require.NoError(t, client.SetBIOSSettings(context.Background(), tt.settings))
+			assert.Equal(t, tt.expectedRequests, server.Requests())
+		})
+	}
+}
+
+func TestSetBIOSSettingsError(t *testing.T) {
+	server := testutil.NewServer(t, biosResources(false))
+	server.Fail(http.MethodPatch, "/redfish/v1/Systems/1/Bios/Settings", http.StatusBadRequest)
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	err = client.SetBIOSSettings(context.Background(), hardware.BIOSSettings{
+		Attributes: map[string]interface{}{"BootMode": "Bios"},
+		BootOrder:  []string{"Boot0002"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PATCH /redfish/v1/Systems/1/Bios/Settings failed")
+	assert.Empty(t, server.Requests())
+}
@@ -312,6 +312,38 @@ func (c *Client) SystemPowerStatus(ctx context.Context) (power.Status, error) {
 	}
 }
 
+// VirtualMediaInserted reports whether any virtual media device of a host has media inserted.
+func (c *Client) VirtualMediaInserted(ctx context.Context) (bool, error) {
+	ctx = SetAuth(ctx, c.username, c.password)
+	managerID, err := getManagerID(ctx, c.RedfishAPI, c.nodeID)
+	if err != nil {
+		return false, err
+	}
+
+	listMediaReq := c.RedfishAPI.ListManagerVirtualMedia(ctx, managerID)
+	mediaCollection, httpResp, err := c.RedfishAPI.ListManagerVirtualMediaExecute(listMediaReq)
+	if err = ScreenRedfishError(httpResp, err); err != nil {
+		return false, err
+	}
+
+	for _, mediaURI := range mediaCollection.Members {
+		mediaID := GetResourceIDFromURL(*mediaURI.OdataId)
+
+		getMediaReq := c.RedfishAPI.GetManagerVirtualMedia(ctx, managerID, mediaID)
+		vMediaMgr, httpResp, err := c.RedfishAPI.GetManagerVirtualMediaExecute(getMediaReq)
+		if err = ScreenRedfishError(httpResp, err); err != nil {
+			return false, err
+		}
+
+		if vMediaMgr.GetInserted() {
+			log.Debugf("'%s' has virtual media inserted.", vMediaMgr.Name)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // RemoteDirect implements remote direct interface
 func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
 	return RemoteDirect(ctx, isoURL, c.redfishURL, c)
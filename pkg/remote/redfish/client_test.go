@@ -785,6 +785,76 @@ func TestSystemPowerStatusGetSystemError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVirtualMediaInserted(t *testing.T) {
+	m := &redfishMocks.RedfishAPI{}
+	defer m.AssertExpectations(t)
+
+	client, err := NewClient(nodeName, redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	client.nodeID = nodeID
+	ctx := SetAuth(context.Background(), "", "")
+
+	testMedia := testutil.GetVirtualMedia([]string{"CD"})
+	testMedia.SetInserted(true)
+
+	httpResp := &http.Response{StatusCode: 200}
+	testutil.MockOnGetSystem(ctx, m, client.nodeID, testutil.GetTestSystem(), httpResp, nil, 1)
+	testutil.MockOnListManagerVirtualMedia(ctx, m, testutil.ManagerID,
+		testutil.GetMediaCollection([]string{"Floppy", "Cd"}), httpResp, nil, 1)
+	testutil.MockOnGetManagerVirtualMedia(ctx, m, testutil.ManagerID,
+		"Floppy", testutil.GetVirtualMedia([]string{"Floppy"}), httpResp, nil)
+	testutil.MockOnGetManagerVirtualMedia(ctx, m, testutil.ManagerID, "Cd", testMedia, httpResp, nil)
+
+	client.RedfishAPI = m
+
+	inserted, err := client.VirtualMediaInserted(ctx)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+}
+
+func TestVirtualMediaNotInserted(t *testing.T) {
+	m := &redfishMocks.RedfishAPI{}
+	defer m.AssertExpectations(t)
+
+	client, err := NewClient(nodeName, redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	client.nodeID = nodeID
+	ctx := SetAuth(context.Background(), "", "")
+
+	httpResp := &http.Response{StatusCode: 200}
+	testutil.MockOnGetSystem(ctx, m, client.nodeID, testutil.GetTestSystem(), httpResp, nil, 1)
+	testutil.MockOnListManagerVirtualMedia(ctx, m, testutil.ManagerID,
+		testutil.GetMediaCollection([]string{"Cd"}), httpResp, nil, 1)
+	testutil.MockOnGetManagerVirtualMedia(ctx, m, testutil.ManagerID,
+		"Cd", testutil.GetVirtualMedia([]string{"CD"}), httpResp, nil)
+
+	client.RedfishAPI = m
+
+	inserted, err := client.VirtualMediaInserted(ctx)
+	require.NoError(t, err)
+	assert.False(t, inserted)
+}
+
+func TestVirtualMediaInsertedGetSystemError(t *testing.T) {
+	m := &redfishMocks.RedfishAPI{}
+	defer m.AssertExpectations(t)
+
+	client, err := NewClient(nodeName, redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	client.nodeID = nodeID
+	ctx := SetAuth(context.Background(), "", "")
+	testutil.MockOnGetSystem(ctx, m, client.nodeID, redfishClient.ComputerSystem{},
+		&http.Response{StatusCode: 500}, redfishClient.GenericOpenAPIError{}, 1)
+
+	client.RedfishAPI = m
+
+	_, err = client.VirtualMediaInserted(ctx)
+	assert.Error(t, err)
+}
+
 func TestWaitForPowerStateGetSystemFailed(t *testing.T) {
 	m := &redfishMocks.RedfishAPI{}
 	defer m.AssertExpectations(t)
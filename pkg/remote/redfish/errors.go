@@ -21,6 +21,9 @@ import (
 // ErrRedfishClient describes an error encountered by the go-redfish client.
 type ErrRedfishClient struct {
 	Message string
+	// transient is set when the BMC is unreachable or responds with a server error, the request may succeed
+	// on retry, e.g. once the BMC completes its restart
+	transient bool
 }
 
 func (e ErrRedfishClient) Error() string {
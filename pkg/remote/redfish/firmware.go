@@ -0,0 +1,246 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+)
+
+const (
+	updateServicePath = "/redfish/v1/UpdateService"
+
+	// firmwareTaskPollInterval is the interval between the checks of the firmware update task state
+	firmwareTaskPollInterval = 10 * time.Second
+
+	taskStateCompleted = "Completed"
+	taskStateException = "Exception"
+	taskStateKilled    = "Killed"
+	taskStateCancelled = "Cancelled"
+	taskStatusCritical = "Critical"
+)
+
+// updateServiceResource is a subset of Redfish UpdateService resource
+type updateServiceResource struct {
+	FirmwareInventory ODataID `json:"FirmwareInventory"`
+	Actions           struct {
+		SimpleUpdate struct {
+			Target string `json:"target,omitempty"`
+		} `json:"#UpdateService.SimpleUpdate"`
+	} `json:"Actions"`
+}
+
+// simpleUpdatePath returns path of SimpleUpdate action, BMCs which don't advertise it are expected to follow
+// the path convention of the Redfish specification
+func (s updateServiceResource) simpleUpdatePath() string {
+	if s.Actions.SimpleUpdate.Target != "" {
+		return s.Actions.SimpleUpdate.Target
+	}
+	return updateServicePath + "/Actions/UpdateService.SimpleUpdate"
+}
+
+// firmwareResource is a subset of Redfish SoftwareInventory resource
+type firmwareResource struct {
+	ID      string `json:"Id,omitempty"`
+	Name    string `json:"Name,omitempty"`
+	Version string `json:"Version,omitempty"`
+}
+
+// taskResource is a subset of Redfish Task resource
+type taskResource struct {
+	ODataID
+	TaskState       string `json:"TaskState,omitempty"`
+	TaskStatus      string `json:"TaskStatus,omitempty"`
+	PercentComplete int    `json:"PercentComplete,omitempty"`
+	Messages        []struct {
+		Message string `json:"Message,omitempty"`
+	} `json:"Messages,omitempty"`
+}
+
+// failed reports whether the task has ended without completing its work
+func (t taskResource) failed() bool {
+	switch t.TaskState {
+	case taskStateException, taskStateKilled, taskStateCancelled:
+		return true
+	case taskStateCompleted:
+		return t.TaskStatus == taskStatusCritical
+	default:
+		return false
+	}
+}
+
+func (t taskResource) messages() string {
+	messages := make([]string, 0, len(t.Messages))
+	for _, m := range t.Messages {
+		messages = append(messages, m.Message)
+	}
+	return strings.Join(messages, " ")
+}
+
+// FirmwareInventory retrieves firmware components of the host and their versions from the firmware inventory
+// of the UpdateService resource.
+func (c *Client) FirmwareInventory(ctx context.Context) ([]hardware.Firmware, error) {
+	var service updateServiceResource
+	if err := c.Request(ctx, http.MethodGet, updateServicePath, nil, &service); err != nil {
+		return nil, err
+	}
+	if service.FirmwareInventory.ID == "" {
+		return nil, ErrRedfishClient{Message: "update service doesn't reference firmware inventory"}
+	}
+
+	var collection collectionResource
+	if err := c.Request(ctx, http.MethodGet, service.FirmwareInventory.ID, nil, &collection); err != nil {
+		return nil, err
+	}
+
+	components := make([]hardware.Firmware, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		var fw firmwareResource
+		if err := c.Request(ctx, http.MethodGet, member.ID, nil, &fw); err != nil {
+			return nil, err
+		}
+		if fw.ID == "" {
+			fw.ID = GetResourceIDFromURL(member.ID)
+		}
+		components = append(components, hardware.Firmware{ID: fw.ID, Name: fw.Name, Version: fw.Version})
+	}
+	return components, nil
+}
+
+// UpdateFirmware makes the BMC download the firmware image and apply it using SimpleUpdate action of the
+// UpdateService resource. The task started by the action is polled until it ends or the context is done.
+func (c *Client) UpdateFirmware(ctx context.Context, imageURL string) error {
+	if u, err := url.Parse(imageURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return ErrRedfishClient{Message: fmt.Sprintf("firmware image '%s' is not a URL", imageURL)}
+	}
+
+	var service updateServiceResource
+	if err := c.Request(ctx, http.MethodGet, updateServicePath, nil, &service); err != nil {
+		return err
+	}
+
+	log.Debugf("Updating firmware of node '%s' from image '%s'.", c.nodeName, imageURL)
+	var task taskResource
+	body := map[string]interface{}{"ImageURI": imageURL}
+	resp, err := c.request(ctx, http.MethodPost, service.simpleUpdatePath(), body, &task)
+	if err != nil {
+		return err
+	}
+
+	path, err := taskPath(task, resp.Header)
+	if err != nil {
+		return err
+	}
+	switch {
+	case path != "":
+		return c.waitForTask(ctx, path)
+	case resp.StatusCode == http.StatusAccepted:
+		return ErrRedfishClient{Message: fmt.Sprintf("BMC of node '%s' accepted the firmware update without "+
+			"referencing a task, the update can't be followed", c.nodeName)}
+	default:
+		log.Debugf("BMC of node '%s' completed the firmware update synchronously.", c.nodeName)
+		return nil
+	}
+}
+
+// taskPath returns path of the task started by an action, the response to the action either contains the task
+// or references it, or the task monitor, by Location header
+func taskPath(task taskResource, header http.Header) (string, error) {
+	if task.ID != "" {
+		return task.ID, nil
+	}
+	location := header.Get("Location")
+	if location == "" {
+		return "", nil
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", ErrRedfishClient{Message: fmt.Sprintf("malformed task location '%s': %v", location, err)}
+	}
+	return u.RequestURI(), nil
+}
+
+// waitForTask polls the task until it ends or the context is done. A task monitor responds with 202 Accepted,
+// often without a body, while the task is running and with the result of the operation instead of the task once
+// the task ends, so a 200 OK or 204 No Content response without the task state means that the task has ended.
+// BMCs often restart or drop connections while they apply firmware, so the task is polled through transient
+// errors.
+func (c *Client) waitForTask(ctx context.Context, path string) error {
+	for {
+		var task taskResource
+		resp, err := c.request(ctx, http.MethodGet, path, nil, &task)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return c.errTaskNotEnded(ctx, path)
+		case err != nil && !isTransient(err):
+			return err
+		case err != nil:
+			log.Debugf("Unable to get task '%s' of node '%s', retrying: %v", path, c.nodeName, err)
+		case task.failed():
+			return ErrRedfishClient{Message: fmt.Sprintf("task '%s' of node '%s' ended in state '%s' with status "+
+				"'%s'. %s", path, c.nodeName, task.TaskState, task.TaskStatus, task.messages())}
+		case task.TaskState == taskStateCompleted || (task.TaskState == "" && taskEnded(resp.StatusCode)):
+			log.Debugf("Task '%s' of node '%s' is completed.", path, c.nodeName)
+			return nil
+		case task.TaskState == "":
+			log.Debugf("Task '%s' of node '%s' is running.", path, c.nodeName)
+		default:
+			log.Debugf("Task '%s' of node '%s' is in state '%s', %d%% complete.", path, c.nodeName,
+				task.TaskState, task.PercentComplete)
+		}
+
+		if err = c.sleepContext(ctx, firmwareTaskPollInterval); err != nil {
+			return c.errTaskNotEnded(ctx, path)
+		}
+	}
+}
+
+// taskEnded reports whether the response of the task monitor without the task state means that the task has ended
+func taskEnded(status int) bool {
+	return status == http.StatusOK || status == http.StatusNoContent
+}
+
+func (c *Client) errTaskNotEnded(ctx context.Context, path string) error {
+	return ErrRedfishClient{Message: fmt.Sprintf("task '%s' of node '%s' didn't end: %v", path, c.nodeName,
+		ctx.Err())}
+}
+
+// sleepContext sleeps like c.Sleep does, it returns the error of the context as soon as the context is done
+func (c *Client) sleepContext(ctx context.Context, d time.Duration) error {
+	slept := make(chan struct{})
+	go func() {
+		c.Sleep(d)
+		close(slept)
+	}()
+	select {
+	case <-slept:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isTransient reports whether the request has failed because the BMC is unreachable or has a server error
+func isTransient(err error) bool {
+	redfishErr, ok := err.(ErrRedfishClient)
+	return ok && redfishErr.transient
+}
@@ -0,0 +1,298 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+const (
+	imageURL      = "http://localhost:8099/bios-2.12.2.exe"
+	simpleUpdate  = "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+	firmwareTask  = "/redfish/v1/TaskService/Tasks/JID_001"
+	inventoryPath = "/redfish/v1/UpdateService/FirmwareInventory"
+)
+
+func firmwareResources() map[string]interface{} {
+	resources := testutil.SystemResources("1", "Contoso")
+	resources[updateServicePath] = map[string]interface{}{
+		"FirmwareInventory": map[string]interface{}{"@odata.id": inventoryPath},
+	}
+	resources[inventoryPath] = map[string]interface{}{
+		"Members": []interface{}{
+			map[string]interface{}{"@odata.id": inventoryPath + "/Installed-159-2.10.2"},
+			map[string]interface{}{"@odata.id": inventoryPath + "/BMC"},
+		},
+	}
+	resources[inventoryPath+"/Installed-159-2.10.2"] = map[string]interface{}{
+		"Id":      "Installed-159-2.10.2",
+		"Name":    "BIOS",
+		"Version": "2.10.2",
+	}
+	resources[inventoryPath+"/BMC"] = map[string]interface{}{"Name": "BMC Firmware", "Version": "4.40.00.00"}
+	return resources
+}
+
+func task(state, status string, messages ...string) map[string]interface{} {
+	taskMessages := []interface{}{}
+	for _, m := range messages {
+		taskMessages = append(taskMessages, map[string]interface{}{"Message": m})
+	}
+	return map[string]interface{}{
+		"@odata.id":  firmwareTask,
+		"TaskState":  state,
+		"TaskStatus": status,
+		"Messages":   taskMessages,
+	}
+}
+
+func TestFirmwareInventory(t *testing.T) {
+	server := testutil.NewServer(t, firmwareResources())
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	components, err := client.FirmwareInventory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []hardware.Firmware{
+		{ID: "Installed-159-2.10.2", Name: "BIOS", Version: "2.10.2"},
+		{ID: "BMC", Name: "BMC Firmware", Version: "4.40.00.00"},
+	}, components)
+}
+
+func TestFirmwareInventoryNotReferenced(t *testing.T) {
+	resources := firmwareResources()
+	resources[updateServicePath] = map[string]interface{}{}
+	server := testutil.NewServer(t, resources)
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	_, err = client.FirmwareInventory(context.Background())
+	assert.Equal(t, ErrRedfishClient{Message: "update service doesn't reference firmware inventory"}, err)
+}
+
+func TestUpdateFirmware(t *testing.T) {
+	tests := []struct {
+		name         string
+		actionTarget string
+		response     testutil.Response
+		initialTask  map[string]interface{}
+		finalTask    map[string]interface{}
+		expectedErr  string
+		expectedPath string
+		expectedPoll int
+	}{
+		{
+			name:         "task in response",
+			response:     testutil.Response{Status: http.StatusAccepted, Body: task("Running", "OK")},
+			initialTask:  task("Running", "OK"),
+			finalTask:    task("Completed", "OK"),
+			expectedPath: simpleUpdate,
+			expectedPoll: 1,
+		},
+		{
+			name:         "task location and advertised action",
+			actionTarget: "/redfish/v1/UpdateService/Actions/Oem/SimpleUpdate",
+			response:     testutil.Response{Status: http.StatusAccepted, Location: "https://bmc" + firmwareTask},
+			initialTask:  task("Completed", "OK"),
+			expectedPath: "/redfish/v1/UpdateService/Actions/Oem/SimpleUpdate",
+		},
+		{
+			name:         "no task",
+			response:     testutil.Response{Status: http.StatusNoContent},
+			expectedPath: simpleUpdate,
+		},
+		{
+			name:         "accepted without task",
+			response:     testutil.Response{Status: http.StatusAccepted},
+			expectedPath: simpleUpdate,
+			expectedErr: "BMC of node 'node-0' accepted the firmware update without referencing a task, " +
+				"the update can't be followed",
+		},
+		{
+			name:         "task exception",
+			response:     testutil.Response{Status: http.StatusAccepted, Location: firmwareTask},
+			initialTask:  task("Running", "OK"),
+			finalTask:    task("Exception", "Critical", "Unable to download the image.", "Job failed."),
+			expectedPath: simpleUpdate,
+			expectedPoll: 1,
+			expectedErr: "task '" + firmwareTask + "' of node 'node-0' ended in state 'Exception' with status " +
+				"'Critical'. Unable to download the image. Job failed.",
+		},
+		{
+			name:         "task completed with critical status",
+			response:     testutil.Response{Status: http.StatusAccepted, Location: firmwareTask},
+			initialTask:  task("Completed", "Critical", "Image is corrupted."),
+			expectedPath: simpleUpdate,
+			expectedErr:  "ended in state 'Completed' with status 'Critical'. Image is corrupted.",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resources := firmwareResources()
+			if tt.actionTarget != "" {
+				resources[updateServicePath] = map[string]interface{}{
+					"Actions": map[string]interface{}{
+						"#UpdateService.SimpleUpdate": map[string]interface{}{"target": tt.actionTarget},
+					},
+				}
+			}
+			if tt.initialTask != nil {
+				resources[firmwareTask] = tt.initialTask
+			}
+			server := testutil.NewServer(t, resources)
+			server.Respond(http.MethodPost, tt.expectedPath, tt.response)
+			client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+				systemRebootDelay)
+			require.NoError(t, err)
+
+			polls := 0
+			client.Sleep = func(d time.Duration) {
+				assert.Equal(t, firmwareTaskPollInterval, d)
+				polls++
+				server.SetResource(firmwareTask, tt.finalTask)
+			}
+
+			err = client.UpdateFirmware(context.Background(), imageURL)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedPoll, polls)
+			assert.Equal(t, []testutil.Request{{
+				Method: http.MethodPost,
+				Path:   tt.expectedPath,
+				Body:   map[string]interface{}{"ImageURI": imageURL},
+			}}, server.Requests())
+		})
+	}
+}
+
+func TestUpdateFirmwareErrors(t *testing.T) {
+	server := testutil.NewServer(t, firmwareResources())
+	server.Fail(http.MethodPost, simpleUpdate, http.StatusBadRequest)
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	err = client.UpdateFirmware(context.Background(), "bios-2.12.2.exe")
+	assert.Equal(t, ErrRedfishClient{Message: "firmware image 'bios-2.12.2.exe' is not a URL"}, err)
+
+	err = client.UpdateFirmware(context.Background(), imageURL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "POST "+simpleUpdate+" failed")
+	assert.Empty(t, server.Requests())
+}
+
+func TestUpdateFirmwareTransientErrors(t *testing.T) {
+	resources := firmwareResources()
+	resources[firmwareTask] = task("Running", "OK")
+	server := testutil.NewServer(t, resources)
+	server.Respond(http.MethodPost, simpleUpdate, testutil.Response{Status: http.StatusAccepted, Location: firmwareTask})
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	// BMC is unavailable while it restarts to apply the firmware
+	polls := 0
+	client.Sleep = func(time.Duration) {
+		polls++
+		switch polls {
+		case 1:
+			server.Fail(http.MethodGet, firmwareTask, http.StatusServiceUnavailable)
+		case 3:
+			server.Recover(http.MethodGet, firmwareTask)
+			server.SetResource(firmwareTask, task("Completed", "OK"))
+		}
+	}
+
+	require.NoError(t, client.UpdateFirmware(context.Background(), imageURL))
+	assert.Equal(t, 3, polls)
+}
+
+func TestUpdateFirmwareTaskMonitor(t *testing.T) {
+	server := testutil.NewServer(t, firmwareResources())
+	server.Respond(http.MethodPost, simpleUpdate, testutil.Response{Status: http.StatusAccepted, Location: firmwareTask})
+	// task monitor answers without a body while the task is running
+	server.Respond(http.MethodGet, firmwareTask, testutil.Response{Status: http.StatusAccepted})
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	polls := 0
+	client.Sleep = func(time.Duration) {
+		polls++
+		if polls == 2 {
+			server.Respond(http.MethodGet, firmwareTask, testutil.Response{Status: http.StatusNoContent})
+		}
+	}
+
+	require.NoError(t, client.UpdateFirmware(context.Background(), imageURL))
+	assert.Equal(t, 2, polls)
+}
+
+func TestUpdateFirmwareTaskErrors(t *testing.T) {
+	t.Run("task not found", func(t *testing.T) {
+		server := testutil.NewServer(t, firmwareResources())
+		server.Respond(http.MethodPost, simpleUpdate,
+			testutil.Response{Status: http.StatusAccepted, Location: firmwareTask})
+		client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+			systemRebootDelay)
+		require.NoError(t, err)
+		client.Sleep = func(time.Duration) { t.Error("task must not be polled again") }
+
+		err = client.UpdateFirmware(context.Background(), imageURL)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "GET "+firmwareTask+" failed. BMC returned status '404 Not Found'")
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		resources := firmwareResources()
+		resources[firmwareTask] = task("Running", "OK")
+		server := testutil.NewServer(t, resources)
+		server.Respond(http.MethodPost, simpleUpdate,
+			testutil.Response{Status: http.StatusAccepted, Location: firmwareTask})
+		client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+			systemRebootDelay)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+		client.Sleep = func(time.Duration) {
+			cancel()
+			<-release
+		}
+
+		err = client.UpdateFirmware(ctx, imageURL)
+		assert.Equal(t, ErrRedfishClient{
+			Message: "task '" + firmwareTask + "' of node 'node-0' didn't end: context canceled",
+		}, err)
+	})
+}
@@ -0,0 +1,198 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+)
+
+// statusResource is Redfish Status object common to most of the resources
+type statusResource struct {
+	Health       string `json:"Health,omitempty"`
+	HealthRollup string `json:"HealthRollup,omitempty"`
+}
+
+// health returns the rollup health of the resource and its subordinate resources when it's reported
+func (s statusResource) health() string {
+	return hardware.WorstHealth(s.Health, s.HealthRollup)
+}
+
+// collectionResource is Redfish resource collection
+type collectionResource struct {
+	Members []ODataID `json:"Members"`
+}
+
+// inventorySystemResource is a subset of Redfish ComputerSystem resource describing its hardware
+type inventorySystemResource struct {
+	Manufacturer     string `json:"Manufacturer,omitempty"`
+	Model            string `json:"Model,omitempty"`
+	SerialNumber     string `json:"SerialNumber,omitempty"`
+	BiosVersion      string `json:"BiosVersion,omitempty"`
+	ProcessorSummary struct {
+		Count int    `json:"Count"`
+		Model string `json:"Model,omitempty"`
+	} `json:"ProcessorSummary"`
+	MemorySummary struct {
+		TotalSystemMemoryGiB float64 `json:"TotalSystemMemoryGiB"`
+	} `json:"MemorySummary"`
+	Status             statusResource `json:"Status"`
+	EthernetInterfaces ODataID        `json:"EthernetInterfaces"`
+	Storage            ODataID        `json:"Storage"`
+	Links              struct {
+		Chassis   []ODataID `json:"Chassis,omitempty"`
+		ManagedBy []ODataID `json:"ManagedBy,omitempty"`
+	} `json:"Links"`
+}
+
+// HardwareInventory retrieves model, serial number, firmware versions, processors, memory, network interfaces,
+// drives and health of the system from its ComputerSystem, Chassis and Manager resources.
+func (c *Client) HardwareInventory(ctx context.Context) (hardware.Inventory, error) {
+	var system inventorySystemResource
+	if err := c.Request(ctx, http.MethodGet, c.SystemPath(), nil, &system); err != nil {
+		return hardware.Inventory{}, err
+	}
+
+	inventory := hardware.Inventory{
+		Manufacturer: system.Manufacturer,
+		Model:        system.Model,
+		SerialNumber: system.SerialNumber,
+		BIOSVersion:  system.BiosVersion,
+		Processors: hardware.Processors{
+			Count: system.ProcessorSummary.Count,
+			Model: system.ProcessorSummary.Model,
+		},
+		MemoryGiB: system.MemorySummary.TotalSystemMemoryGiB,
+	}
+	health := []string{system.Status.health()}
+
+	if len(system.Links.Chassis) != 0 {
+		var chassis struct {
+			SerialNumber string         `json:"SerialNumber,omitempty"`
+			Status       statusResource `json:"Status"`
+		}
+		if err := c.Request(ctx, http.MethodGet, system.Links.Chassis[0].ID, nil, &chassis); err != nil {
+			return hardware.Inventory{}, err
+		}
+		if inventory.SerialNumber == "" {
+			inventory.SerialNumber = chassis.SerialNumber
+		}
+		health = append(health, chassis.Status.health())
+	}
+
+	if len(system.Links.ManagedBy) != 0 {
+		var manager struct {
+			FirmwareVersion string         `json:"FirmwareVersion,omitempty"`
+			Status          statusResource `json:"Status"`
+		}
+		if err := c.Request(ctx, http.MethodGet, system.Links.ManagedBy[0].ID, nil, &manager); err != nil {
+			return hardware.Inventory{}, err
+		}
+		inventory.BMCFirmwareVersion = manager.FirmwareVersion
+		health = append(health, manager.Status.health())
+	}
+
+	var err error
+	if inventory.NICs, err = c.networkInterfaces(ctx, system.EthernetInterfaces.ID); err != nil {
+		return hardware.Inventory{}, err
+	}
+	if inventory.Disks, err = c.drives(ctx, system.Storage.ID); err != nil {
+		return hardware.Inventory{}, err
+	}
+
+	inventory.Health = hardware.WorstHealth(health...)
+	return inventory, nil
+}
+
+// members retrieves the resource collection, nothing is returned if the system doesn't link the collection
+func (c *Client) members(ctx context.Context, collectionPath string) ([]ODataID, error) {
+	if collectionPath == "" {
+		return nil, nil
+	}
+
+	var collection collectionResource
+	err := c.Request(ctx, http.MethodGet, collectionPath, nil, &collection)
+	return collection.Members, err
+}
+
+func (c *Client) networkInterfaces(ctx context.Context, collectionPath string) ([]hardware.NIC, error) {
+	members, err := c.members(ctx, collectionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var nics []hardware.NIC
+	for _, member := range members {
+		var nic struct {
+			ID                  string `json:"Id"`
+			MACAddress          string `json:"MACAddress,omitempty"`
+			PermanentMACAddress string `json:"PermanentMACAddress,omitempty"`
+			LinkStatus          string `json:"LinkStatus,omitempty"`
+		}
+		if err = c.Request(ctx, http.MethodGet, member.ID, nil, &nic); err != nil {
+			return nil, err
+		}
+		if nic.MACAddress == "" {
+			nic.MACAddress = nic.PermanentMACAddress
+		}
+		nics = append(nics, hardware.NIC{Name: nic.ID, MACAddress: nic.MACAddress, LinkStatus: nic.LinkStatus})
+	}
+	return nics, nil
+}
+
+func (c *Client) drives(ctx context.Context, collectionPath string) ([]hardware.Disk, error) {
+	members, err := c.members(ctx, collectionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []hardware.Disk
+	for _, member := range members {
+		var storage struct {
+			Drives []ODataID `json:"Drives,omitempty"`
+		}
+		if err = c.Request(ctx, http.MethodGet, member.ID, nil, &storage); err != nil {
+			return nil, err
+		}
+
+		for _, drivePath := range storage.Drives {
+			var drive struct {
+				ID            string         `json:"Id"`
+				Name          string         `json:"Name,omitempty"`
+				Model         string         `json:"Model,omitempty"`
+				CapacityBytes int64          `json:"CapacityBytes,omitempty"`
+				MediaType     string         `json:"MediaType,omitempty"`
+				Status        statusResource `json:"Status"`
+			}
+			if err = c.Request(ctx, http.MethodGet, drivePath.ID, nil, &drive); err != nil {
+				return nil, err
+			}
+			name := drive.Name
+			if name == "" {
+				name = drive.ID
+			}
+			disks = append(disks, hardware.Disk{
+				Name:          name,
+				Model:         drive.Model,
+				CapacityBytes: drive.CapacityBytes,
+				MediaType:     drive.MediaType,
+				Health:        drive.Status.health(),
+			})
+		}
+	}
+	return disks, nil
+}
@@ -0,0 +1,138 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+func inventoryResources() map[string]interface{} {
+	resources := testutil.SystemResources("1", "Contoso")
+	resources["/redfish/v1/Systems/1"] = map[string]interface{}{
+		"Id":               "1",
+		"Manufacturer":     "Contoso",
+		"Model":            "3500",
+		"BiosVersion":      "P79 v1.45",
+		"ProcessorSummary": map[string]interface{}{"Count": 2, "Model": "Multi-Core Intel(R) Xeon(R) processor"},
+		"MemorySummary":    map[string]interface{}{"TotalSystemMemoryGiB": 96},
+		"Status":           map[string]interface{}{"Health": "OK", "HealthRollup": "OK"},
+		"EthernetInterfaces": map[string]interface{}{
+			"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces",
+		},
+		"Storage": map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/Storage"},
+		"Links": map[string]interface{}{
+			"Chassis":   []interface{}{map[string]string{"@odata.id": "/redfish/v1/Chassis/1U"}},
+			"ManagedBy": []interface{}{map[string]string{"@odata.id": testutil.ManagerPath}},
+		},
+	}
+	resources["/redfish/v1/Chassis/1U"] = map[string]interface{}{
+		"SerialNumber": "437XR1138R2",
+		"Status":       map[string]interface{}{"Health": "OK", "HealthRollup": "Warning"},
+	}
+	resources[testutil.ManagerPath] = map[string]interface{}{
+		"FirmwareVersion": "1.00",
+		"Status":          map[string]interface{}{"Health": "OK"},
+	}
+	resources["/redfish/v1/Systems/1/EthernetInterfaces"] = map[string]interface{}{
+		"Members": []interface{}{
+			map[string]string{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/NIC.1"},
+			map[string]string{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/NIC.2"},
+		},
+	}
+	resources["/redfish/v1/Systems/1/EthernetInterfaces/NIC.1"] = map[string]interface{}{
+		"Id":         "NIC.1",
+		"MACAddress": "12:44:6A:3B:04:11",
+		"LinkStatus": "LinkUp",
+	}
+	resources["/redfish/v1/Systems/1/EthernetInterfaces/NIC.2"] = map[string]interface{}{
+		"Id":                  "NIC.2",
+		"PermanentMACAddress": "12:44:6A:3B:04:12",
+		"LinkStatus":          "LinkDown",
+	}
+	resources["/redfish/v1/Systems/1/Storage"] = map[string]interface{}{
+		"Members": []interface{}{map[string]string{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID"}},
+	}
+	resources["/redfish/v1/Systems/1/Storage/RAID"] = map[string]interface{}{
+		"Drives": []interface{}{map[string]string{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Drives/0"}},
+	}
+	resources["/redfish/v1/Systems/1/Storage/RAID/Drives/0"] = map[string]interface{}{
+		"Id":            "0",
+		"Name":          "Drive Sample",
+		"Model":         "C123",
+		"CapacityBytes": 899527000000,
+		"MediaType":     "HDD",
+		"Status":        map[string]interface{}{"Health": "OK"},
+	}
+	return resources
+}
+
+func TestHardwareInventory(t *testing.T) {
+	server := testutil.NewServer(t, inventoryResources())
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	inventory, err := client.HardwareInventory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, hardware.Inventory{
+		Manufacturer:       "Contoso",
+		Model:              "3500",
+		SerialNumber:       "437XR1138R2",
+		BIOSVersion:        "P79 v1.45",
+		BMCFirmwareVersion: "1.00",
+		Processors:         hardware.Processors{Count: 2, Model: "Multi-Core Intel(R) Xeon(R) processor"},
+		MemoryGiB:          96,
+		NICs: []hardware.NIC{
+			{Name: "NIC.1", MACAddress: "12:44:6A:3B:04:11", LinkStatus: "LinkUp"},
+			{Name: "NIC.2", MACAddress: "12:44:6A:3B:04:12", LinkStatus: "LinkDown"},
+		},
+		Disks: []hardware.Disk{
+			{Name: "Drive Sample", Model: "C123", CapacityBytes: 899527000000, MediaType: "HDD", Health: "OK"},
+		},
+		Health: hardware.HealthWarning,
+	}, inventory)
+	assert.Empty(t, server.Requests())
+}
+
+func TestHardwareInventoryMinimalSystem(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources("1", "Contoso"))
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	inventory, err := client.HardwareInventory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, hardware.Inventory{Manufacturer: "Contoso"}, inventory)
+}
+
+func TestHardwareInventoryError(t *testing.T) {
+	server := testutil.NewServer(t, inventoryResources())
+	server.Fail(http.MethodGet, "/redfish/v1/Systems/1/Storage/RAID/Drives/0", http.StatusInternalServerError)
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	_, err = client.HardwareInventory(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GET /redfish/v1/Systems/1/Storage/RAID/Drives/0 failed")
+}
@@ -0,0 +1,192 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const systemsPath = "/redfish/v1/Systems/"
+
+// ODataID is a reference to another Redfish resource
+type ODataID struct {
+	ID string `json:"@odata.id"`
+}
+
+// SystemResource is a subset of Redfish ComputerSystem resource used to identify the system and its manager
+type SystemResource struct {
+	Manufacturer string `json:"Manufacturer,omitempty"`
+	Model        string `json:"Model,omitempty"`
+	Links        struct {
+		ManagedBy []ODataID `json:"ManagedBy,omitempty"`
+	} `json:"Links"`
+}
+
+// ManagerPath returns path of the manager resource of the system
+func (s SystemResource) ManagerPath() (string, error) {
+	if len(s.Links.ManagedBy) == 0 || s.Links.ManagedBy[0].ID == "" {
+		return "", ErrRedfishClient{Message: "system doesn't reference its manager"}
+	}
+	return s.Links.ManagedBy[0].ID, nil
+}
+
+// SystemPath returns path of the Redfish system resource managed by the client.
+func (c *Client) SystemPath() string {
+	return systemsPath + c.nodeID
+}
+
+// GetSystemResource retrieves the system resource managed by the client.
+func (c *Client) GetSystemResource(ctx context.Context) (SystemResource, error) {
+	var system SystemResource
+	err := c.Request(ctx, http.MethodGet, c.SystemPath(), nil, &system)
+	return system, err
+}
+
+// Request sends raw request to the BMC. It's meant for vendor specific (OEM) resources and actions which aren't
+// covered by the Redfish API client. The path is relative to the BMC address, the body and the result are
+// encoded as JSON when given.
+func (c *Client) Request(ctx context.Context, method, path string, body, result interface{}) error {
+	_, err := c.request(ctx, method, path, body, result)
+	return err
+}
+
+// request sends raw request to the BMC like Request does and returns the response, its body is already read
+// and closed. Status code and headers of the response are needed to follow the tasks started by the
+// asynchronous actions
+func (c *Client) request(ctx context.Context, method, path string, body, result interface{}) (*http.Response,
+	error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.RedfishCFG.Servers[0].URL+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", headerUserAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.username+c.password) != 0 {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	httpResp, err := c.RedfishCFG.HTTPClient.Do(req)
+	if err != nil {
+		return nil, ErrRedfishClient{Message: fmt.Sprintf("%s %s failed: %v", method, path, err), transient: true}
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		message := fmt.Sprintf("%s %s failed. BMC returned status '%s'.", method, path, httpResp.Status)
+		if bmcResponse, decodeErr := DecodeRawError(respBody); decodeErr == nil {
+			message = fmt.Sprintf("%s\nBMC responded: '%s'", message, bmcResponse)
+		}
+		return nil, ErrRedfishClient{Message: message, transient: httpResp.StatusCode >= http.StatusInternalServerError}
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return httpResp, nil
+	}
+	if err = json.Unmarshal(respBody, result); err != nil {
+		return nil, ErrRedfishClient{Message: fmt.Sprintf("malformed response to %s %s: %v", method, path, err)}
+	}
+	return httpResp, nil
+}
+
+// VirtualMediaResource is a subset of Redfish VirtualMedia resource
+type VirtualMediaResource struct {
+	ODataID
+	MediaTypes []string `json:"MediaTypes,omitempty"`
+	Inserted   bool     `json:"Inserted"`
+	Image      string   `json:"Image,omitempty"`
+}
+
+// IsCD reports whether the virtual media device supports CD or DVD media.
+func (m VirtualMediaResource) IsCD() bool {
+	for _, mediaType := range m.MediaTypes {
+		if mediaType == "CD" || mediaType == "DVD" {
+			return true
+		}
+	}
+	return false
+}
+
+// ListVirtualMediaResources retrieves all virtual media devices of the manager of the system.
+func (c *Client) ListVirtualMediaResources(ctx context.Context) ([]VirtualMediaResource, error) {
+	system, err := c.GetSystemResource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managerPath, err := system.ManagerPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var collection struct {
+		Members []ODataID `json:"Members"`
+	}
+	if err = c.Request(ctx, http.MethodGet, managerPath+"/VirtualMedia", nil, &collection); err != nil {
+		return nil, err
+	}
+
+	media := make([]VirtualMediaResource, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		var m VirtualMediaResource
+		if err = c.Request(ctx, http.MethodGet, member.ID, nil, &m); err != nil {
+			return nil, err
+		}
+		m.ID = member.ID
+		media = append(media, m)
+	}
+	return media, nil
+}
+
+// SetBootSourceOverride makes the system boot from the given boot source once on the next boot.
+func (c *Client) SetBootSourceOverride(ctx context.Context, target string) error {
+	log.Debugf("Setting boot device of node '%s' to '%s'.", c.nodeID, target)
+	body := map[string]interface{}{
+		"Boot": map[string]string{
+			"BootSourceOverrideEnabled": "Once",
+			"BootSourceOverrideTarget":  target,
+		},
+	}
+	if err := c.Request(ctx, http.MethodPatch, c.SystemPath(), body, nil); err != nil {
+		return err
+	}
+
+	log.Debug("Successfully set boot device.")
+	return nil
+}
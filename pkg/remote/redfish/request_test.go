@@ -0,0 +1,107 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+func TestGetSystemResource(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources("1", "Contoso"))
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	system, err := client.GetSystemResource(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Contoso", system.Manufacturer)
+
+	managerPath, err := system.ManagerPath()
+	require.NoError(t, err)
+	assert.Equal(t, testutil.ManagerPath, managerPath)
+
+	_, err = SystemResource{}.ManagerPath()
+	assert.Equal(t, ErrRedfishClient{Message: "system doesn't reference its manager"}, err)
+}
+
+func TestRequestError(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources("1", "Contoso"))
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = client.Request(ctx, http.MethodGet, "/redfish/v1/Systems/2", nil, nil)
+	assert.Equal(t, ErrRedfishClient{
+		Message: "GET /redfish/v1/Systems/2 failed. BMC returned status '404 Not Found'.",
+	}, err)
+
+	server.Fail(http.MethodPost, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", http.StatusBadRequest)
+	err = client.Request(ctx, http.MethodPost, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset",
+		map[string]string{"ResetType": "On"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset failed. "+
+		"BMC returned status '400 Bad Request'.\nBMC responded: 'Request failed Retry")
+
+	var result []string
+	err = client.Request(ctx, http.MethodGet, client.SystemPath(), nil, &result)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "malformed response to GET /redfish/v1/Systems/1")
+}
+
+func TestListVirtualMediaResources(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources("1", "Contoso",
+		testutil.VirtualMedia{ID: "Floppy1", MediaTypes: []string{"Floppy"}},
+		testutil.VirtualMedia{ID: "CD1", MediaTypes: []string{"CD", "DVD"}, Inserted: true}))
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	media, err := client.ListVirtualMediaResources(context.Background())
+	require.NoError(t, err)
+	require.Len(t, media, 2)
+	assert.Equal(t, testutil.ManagerPath+"/VirtualMedia/Floppy1", media[0].ID)
+	assert.False(t, media[0].IsCD())
+	assert.False(t, media[0].Inserted)
+	assert.Equal(t, testutil.ManagerPath+"/VirtualMedia/CD1", media[1].ID)
+	assert.True(t, media[1].IsCD())
+	assert.True(t, media[1].Inserted)
+}
+
+func TestSetBootSourceOverride(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources("1", "Contoso"))
+	client, err := NewClient(nodeName, server.RedfishURL("1"), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetBootSourceOverride(context.Background(), "Cd"))
+	assert.Equal(t, []testutil.Request{{
+		Method: http.MethodPatch,
+		Path:   "/redfish/v1/Systems/1",
+		Body: map[string]interface{}{
+			"Boot": map[string]interface{}{
+				"BootSourceOverrideEnabled": "Once",
+				"BootSourceOverrideTarget":  "Cd",
+			},
+		},
+	}}, server.Requests())
+}
@@ -0,0 +1,305 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auto selects the vendor specific Redfish client by the manufacturer of the system reported by the BMC.
+package auto
+
+import (
+	"context"
+	"strings"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
+)
+
+const (
+	// ClientType is used by other packages as the identifier of the Redfish client which detects the vendor of
+	// the system.
+	ClientType = "redfish-auto"
+)
+
+// vendor is a vendor specific Redfish client used for the systems of the manufacturer
+type vendor struct {
+	// manufacturer is the lower case prefix of Manufacturer field of Redfish ComputerSystem resource
+	manufacturer string
+	clientType   string
+	factory      ifc.ClientFactory
+}
+
+var vendors = []vendor{
+	{manufacturer: "dell", clientType: dell.ClientType, factory: dell.ClientFactory},
+	{manufacturer: "hp", clientType: hpe.ClientType, factory: hpe.ClientFactory},
+	{manufacturer: "hewlett", clientType: hpe.ClientType, factory: hpe.ClientFactory},
+	{manufacturer: "supermicro", clientType: supermicro.ClientType, factory: supermicro.ClientFactory},
+}
+
+// vendorByManufacturer returns the client matching the manufacturer, the standard Redfish client is used for
+// unknown manufacturers
+func vendorByManufacturer(manufacturer string) vendor {
+	manufacturer = strings.ToLower(strings.TrimSpace(manufacturer))
+	for _, v := range vendors {
+		if strings.HasPrefix(manufacturer, v.manufacturer) {
+			return v
+		}
+	}
+	return vendor{clientType: redfish.ClientType, factory: redfish.ClientFactory}
+}
+
+// Client detects the manufacturer of the system on the first request and delegates all the requests to the
+// vendor specific Redfish client.
+type Client struct {
+	generic *redfish.Client
+	vendor  ifc.Client
+
+	nodeName            string
+	redfishURL          string
+	insecure            bool
+	useProxy            bool
+	username            string
+	password            string
+	systemActionRetries int
+	systemRebootDelay   int
+}
+
+// NodeID retrieves the ephemeral node ID.
+func (c *Client) NodeID() string {
+	return c.generic.NodeID()
+}
+
+// NodeName retrieves the ephemeral node name.
+func (c *Client) NodeName() string {
+	return c.generic.NodeName()
+}
+
+// EjectVirtualMedia ejects a virtual media device attached to a host.
+func (c *Client) EjectVirtualMedia(ctx context.Context) error {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.EjectVirtualMedia(ctx)
+}
+
+// RebootSystem power cycles a host.
+func (c *Client) RebootSystem(ctx context.Context) error {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.RebootSystem(ctx)
+}
+
+// SetBootSourceByType sets the boot source of the ephemeral node to the virtual media.
+func (c *Client) SetBootSourceByType(ctx context.Context) error {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.SetBootSourceByType(ctx)
+}
+
+// SetVirtualMedia injects a virtual media device to a host.
+func (c *Client) SetVirtualMedia(ctx context.Context, isoPath string) error {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.SetVirtualMedia(ctx, isoPath)
+}
+
+// SystemPowerOff shuts down a host.
+func (c *Client) SystemPowerOff(ctx context.Context) error {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.SystemPowerOff(ctx)
+}
+
+// SystemPowerOn powers on a host.
+func (c *Client) SystemPowerOn(ctx context.Context) error {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.SystemPowerOn(ctx)
+}
+
+// SystemPowerStatus retrieves the power status of a host.
+func (c *Client) SystemPowerStatus(ctx context.Context) (power.Status, error) {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return power.StatusUnknown, err
+	}
+	return client.SystemPowerStatus(ctx)
+}
+
+// VirtualMediaInserted reports whether any virtual media device of a host has media inserted.
+func (c *Client) VirtualMediaInserted(ctx context.Context) (bool, error) {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return false, err
+	}
+	return client.VirtualMediaInserted(ctx)
+}
+
+// RemoteDirect implements remote direct interface
+func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.RemoteDirect(ctx, isoURL)
+}
+
+// HardwareInventory retrieves the hardware inventory of a host.
+func (c *Client) HardwareInventory(ctx context.Context) (hardware.Inventory, error) {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return hardware.Inventory{}, err
+	}
+	inventoryClient, ok := client.(ifc.HardwareInventoryClient)
+	if !ok {
+		return hardware.Inventory{}, hardware.ErrInventoryNotSupported{NodeName: c.NodeName()}
+	}
+	return inventoryClient.HardwareInventory(ctx)
+}
+
+// BIOSSettings retrieves the current BIOS settings of a host.
+func (c *Client) BIOSSettings(ctx context.Context) (hardware.BIOSSettings, error) {
+	biosClient, err := c.biosClient(ctx)
+	if err != nil {
+		return hardware.BIOSSettings{}, err
+	}
+	return biosClient.BIOSSettings(ctx)
+}
+
+// SetBIOSSettings changes BIOS settings of a host.
+func (c *Client) SetBIOSSettings(ctx context.Context, settings hardware.BIOSSettings) error {
+	biosClient, err := c.biosClient(ctx)
+	if err != nil {
+		return err
+	}
+	return biosClient.SetBIOSSettings(ctx, settings)
+}
+
+func (c *Client) biosClient(ctx context.Context) (ifc.BIOSClient, error) {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	biosClient, ok := client.(ifc.BIOSClient)
+	if !ok {
+		return nil, hardware.ErrBIOSNotSupported{NodeName: c.NodeName()}
+	}
+	return biosClient, nil
+}
+
+// FirmwareInventory retrieves versions of the firmware components of a host.
+func (c *Client) FirmwareInventory(ctx context.Context) ([]hardware.Firmware, error) {
+	firmwareClient, err := c.firmwareClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return firmwareClient.FirmwareInventory(ctx)
+}
+
+// UpdateFirmware updates firmware of a host from the image.
+func (c *Client) UpdateFirmware(ctx context.Context, imageURL string) error {
+	firmwareClient, err := c.firmwareClient(ctx)
+	if err != nil {
+		return err
+	}
+	return firmwareClient.UpdateFirmware(ctx, imageURL)
+}
+
+func (c *Client) firmwareClient(ctx context.Context) (ifc.FirmwareClient, error) {
+	client, err := c.vendorClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	firmwareClient, ok := client.(ifc.FirmwareClient)
+	if !ok {
+		return nil, hardware.ErrFirmwareUpdateNotSupported{NodeName: c.NodeName()}
+	}
+	return firmwareClient, nil
+}
+
+// vendorClient returns the vendor specific client, the manufacturer of the system is requested from the BMC
+// only once
+func (c *Client) vendorClient(ctx context.Context) (ifc.Client, error) {
+	if c.vendor != nil {
+		return c.vendor, nil
+	}
+
+	system, err := c.generic.GetSystemResource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v := vendorByManufacturer(system.Manufacturer)
+	log.Debugf("Node '%s' is manufactured by '%s', using '%s' management type.", c.NodeName(),
+		system.Manufacturer, v.clientType)
+	client, err := v.factory(c.nodeName, c.redfishURL, c.insecure, c.useProxy, c.username, c.password,
+		c.systemActionRetries, c.systemRebootDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	c.vendor = client
+	return client, nil
+}
+
+// NewClient returns a client which selects the vendor specific Redfish client on the first request.
+func NewClient(nodeName string, redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (*Client, error) {
+	genericClient, err := redfish.NewClient(nodeName, redfishURL, insecure, useProxy, username, password,
+		systemActionRetries, systemRebootDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		generic:             genericClient,
+		nodeName:            nodeName,
+		redfishURL:          redfishURL,
+		insecure:            insecure,
+		useProxy:            useProxy,
+		username:            username,
+		password:            password,
+		systemActionRetries: systemActionRetries,
+		systemRebootDelay:   systemRebootDelay,
+	}, nil
+}
+
+// ClientFactory is a constructor for redfish ifc.Client implementation
+var ClientFactory ifc.ClientFactory = func(nodeName, redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (ifc.Client, error) {
+	return NewClient(nodeName, redfishURL, insecure, useProxy,
+		username, password, systemActionRetries, systemRebootDelay)
+}
@@ -0,0 +1,176 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+const (
+	nodeName            = "node-0"
+	systemID            = "1"
+	systemActionRetries = 0
+	systemRebootDelay   = 0
+)
+
+func TestVendorClient(t *testing.T) {
+	tests := []struct {
+		manufacturer string
+		expectedType interface{}
+	}{
+		{manufacturer: "Dell Inc.", expectedType: &dell.Client{}},
+		{manufacturer: "HPE", expectedType: &hpe.Client{}},
+		{manufacturer: "HP", expectedType: &hpe.Client{}},
+		{manufacturer: "Hewlett Packard Enterprise", expectedType: &hpe.Client{}},
+		{manufacturer: "Supermicro", expectedType: &supermicro.Client{}},
+		{manufacturer: "Contoso", expectedType: &redfish.Client{}},
+		{manufacturer: "", expectedType: &redfish.Client{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.manufacturer, func(t *testing.T) {
+			server := testutil.NewServer(t, testutil.SystemResources(systemID, tt.manufacturer))
+			client, err := NewClient(nodeName, server.RedfishURL(systemID), false, false, "", "",
+				systemActionRetries, systemRebootDelay)
+			require.NoError(t, err)
+			assert.Equal(t, systemID, client.NodeID())
+			assert.Equal(t, nodeName, client.NodeName())
+
+			vendorClient, err := client.vendorClient(context.Background())
+			require.NoError(t, err)
+			assert.IsType(t, tt.expectedType, vendorClient)
+		})
+	}
+}
+
+func TestVendorClientDetectedOnce(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources(systemID, "Supermicro"))
+	client, err := NewClient(nodeName, server.RedfishURL(systemID), false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.SetBootSourceByType(ctx))
+	server.Fail(http.MethodGet, "/redfish/v1/Systems/1", http.StatusServiceUnavailable)
+	require.NoError(t, client.SetBootSourceByType(ctx))
+	assert.Len(t, server.Requests(), 2)
+}
+
+func TestVendorClientError(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources(systemID, "Supermicro"))
+	server.Fail(http.MethodGet, "/redfish/v1/Systems/1", http.StatusServiceUnavailable)
+	client, err := NewClient(nodeName, server.RedfishURL(systemID), false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	err = client.SetBootSourceByType(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BMC returned status '503 Service Unavailable'")
+	assert.Nil(t, client.vendor)
+}
+
+func TestNewClientInterface(t *testing.T) {
+	c, err := ClientFactory(nodeName, "redfish+https://localhost/redfish/v1/Systems/1", false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+
+	_, err = ClientFactory(nodeName, "", false, false, "", "", systemActionRetries, systemRebootDelay)
+	assert.Equal(t, redfish.ErrRedfishMissingConfig{What: "Redfish URL"}, err)
+}
+
+func TestHardwareInventory(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources(systemID, "Supermicro"))
+	client, err := NewClient(nodeName, server.RedfishURL(systemID), false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	inventory, err := client.HardwareInventory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Supermicro", inventory.Manufacturer)
+	assert.IsType(t, &supermicro.Client{}, client.vendor)
+}
+
+func TestBIOSSettings(t *testing.T) {
+	resources := testutil.SystemResources(systemID, "HPE")
+	resources["/redfish/v1/Systems/1"] = map[string]interface{}{
+		"Manufacturer": "HPE",
+		"Bios":         map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/Bios"},
+	}
+	resources["/redfish/v1/Systems/1/Bios"] = map[string]interface{}{
+		"Attributes": map[string]interface{}{"BootMode": "Uefi"},
+	}
+	server := testutil.NewServer(t, resources)
+	client, err := NewClient(nodeName, server.RedfishURL(systemID), false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	settings, err := client.BIOSSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"BootMode": "Uefi"}, settings.Attributes)
+	assert.IsType(t, &hpe.Client{}, client.vendor)
+
+	require.NoError(t, client.SetBIOSSettings(ctx, hardware.BIOSSettings{
+		Attributes: map[string]interface{}{"BootMode": "LegacyBios"},
+	}))
+	assert.Equal(t, []testutil.Request{{
+		Method: http.MethodPatch,
+		Path:   "/redfish/v1/Systems/1/Bios/Settings",
+		Body:   map[string]interface{}{"Attributes": map[string]interface{}{"BootMode": "LegacyBios"}},
+	}}, server.Requests())
+}
+
+func TestUpdateFirmware(t *testing.T) {
+	resources := testutil.SystemResources(systemID, "Dell Inc.")
+	resources["/redfish/v1/UpdateService"] = map[string]interface{}{
+		"FirmwareInventory": map[string]interface{}{"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory"},
+	}
+	resources["/redfish/v1/UpdateService/FirmwareInventory"] = map[string]interface{}{
+		"Members": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BIOS"}},
+	}
+	resources["/redfish/v1/UpdateService/FirmwareInventory/BIOS"] = map[string]interface{}{
+		"Id":      "BIOS",
+		"Version": "2.10.2",
+	}
+	server := testutil.NewServer(t, resources)
+	client, err := NewClient(nodeName, server.RedfishURL(systemID), false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	components, err := client.FirmwareInventory(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []hardware.Firmware{{ID: "BIOS", Version: "2.10.2"}}, components)
+	assert.IsType(t, &dell.Client{}, client.vendor)
+
+	require.NoError(t, client.UpdateFirmware(ctx, "http://localhost:8099/bios-2.12.2.exe"))
+	assert.Equal(t, []testutil.Request{{
+		Method: http.MethodPost,
+		Path:   "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate",
+		Body:   map[string]interface{}{"ImageURI": "http://localhost:8099/bios-2.12.2.exe"},
+	}}, server.Requests())
+}
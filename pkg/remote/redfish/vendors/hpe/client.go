@@ -0,0 +1,143 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hpe wraps the standard Redfish client in order to provide additional functionality required to perform
+// actions on HPE iLO servers.
+package hpe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+)
+
+const (
+	// ClientType is used by other packages as the identifier of the HPE iLO Redfish client.
+	ClientType = "redfish-hpe"
+
+	// OEM virtual media actions are available on all iLO 5 firmware versions unlike the standard ones
+	actionInsertVirtualMedia = "/Actions/Oem/Hpe/HpeiLOVirtualMedia.InsertVirtualMedia"
+	actionEjectVirtualMedia  = "/Actions/Oem/Hpe/HpeiLOVirtualMedia.EjectVirtualMedia"
+	bootSourceCD             = "Cd"
+)
+
+// Client is a wrapper around the standard airshipctl Redfish client. This allows vendor specific Redfish clients to
+// override methods without duplicating the entire client.
+type Client struct {
+	redfishURL string
+	redfish.Client
+}
+
+// EjectVirtualMedia ejects all virtual media attached to a host.
+func (c *Client) EjectVirtualMedia(ctx context.Context) error {
+	media, err := c.ListVirtualMediaResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range media {
+		if !m.Inserted {
+			continue
+		}
+
+		log.Debugf("'%s' has virtual media inserted. Attempting to eject.", m.ID)
+		if err = c.Request(ctx, http.MethodPost, m.ID+actionEjectVirtualMedia, struct{}{}, nil); err != nil {
+			return err
+		}
+	}
+
+	log.Debugf("Successfully ejected virtual media.")
+	return nil
+}
+
+// SetVirtualMedia attaches the ISO image to the CD/DVD virtual media device and makes the host boot from it on
+// the next server reset.
+func (c *Client) SetVirtualMedia(ctx context.Context, isoPath string) error {
+	log.Debugf("Inserting virtual media '%s'.", isoPath)
+	if err := c.EjectVirtualMedia(ctx); err != nil {
+		return err
+	}
+
+	media, err := c.ListVirtualMediaResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range media {
+		if !m.IsCD() {
+			continue
+		}
+
+		insertReq := map[string]string{"Image": isoPath}
+		if err = c.Request(ctx, http.MethodPost, m.ID+actionInsertVirtualMedia, insertReq, nil); err != nil {
+			return err
+		}
+
+		bootReq := map[string]interface{}{
+			"Oem": map[string]interface{}{
+				"Hpe": map[string]bool{"BootOnNextServerReset": true},
+			},
+		}
+		if err = c.Request(ctx, http.MethodPatch, m.ID, bootReq, nil); err != nil {
+			return err
+		}
+
+		log.Debug("Successfully set virtual media.")
+		return nil
+	}
+
+	return redfish.ErrRedfishClient{Message: fmt.Sprintf("iLO of node '%s' does not have virtual media type CD or DVD.",
+		c.NodeID())}
+}
+
+// SetBootSourceByType sets the boot source of the ephemeral node to the virtual CD for the next boot.
+func (c *Client) SetBootSourceByType(ctx context.Context) error {
+	return c.SetBootSourceOverride(ctx, bootSourceCD)
+}
+
+// RemoteDirect implements remote direct interface
+func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
+	return redfish.RemoteDirect(ctx, isoURL, c.redfishURL, c)
+}
+
+// newClient returns a client with the capability to make Redfish requests.
+func newClient(nodeName string, redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (*Client, error) {
+	genericClient, err := redfish.NewClient(nodeName, redfishURL, insecure, useProxy, username, password,
+		systemActionRetries, systemRebootDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{redfishURL: redfishURL, Client: *genericClient}, nil
+}
+
+// ClientFactory is a constructor for redfish ifc.Client implementation
+var ClientFactory ifc.ClientFactory = func(nodeName, redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (ifc.Client, error) {
+	return newClient(nodeName, redfishURL, insecure, useProxy,
+		username, password, systemActionRetries, systemRebootDelay)
+}
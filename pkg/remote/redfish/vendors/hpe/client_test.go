@@ -0,0 +1,121 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpe
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+const (
+	nodeName            = "node-0"
+	systemID            = "1"
+	isoPath             = "http://localhost:8099/ubuntu-focal.iso"
+	systemActionRetries = 0
+	systemRebootDelay   = 0
+)
+
+func testServer(t *testing.T, cdInserted bool) *testutil.Server {
+	return testutil.NewServer(t, testutil.SystemResources(systemID, "HPE",
+		testutil.VirtualMedia{ID: "1", MediaTypes: []string{"Floppy", "USBStick"}},
+		testutil.VirtualMedia{ID: "2", MediaTypes: []string{"CD", "DVD"}, Inserted: cdInserted}))
+}
+
+func TestNewClientInterface(t *testing.T) {
+	c, err := ClientFactory(nodeName, "redfish+https://localhost/redfish/v1/Systems/1", false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestSetVirtualMedia(t *testing.T) {
+	server := testServer(t, true)
+	client, err := newClient(nodeName, server.RedfishURL(systemID), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetVirtualMedia(context.Background(), isoPath))
+	assert.Equal(t, []testutil.Request{
+		{
+			Method: http.MethodPost,
+			Path:   testutil.ManagerPath + "/VirtualMedia/2" + actionEjectVirtualMedia,
+			Body:   map[string]interface{}{},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   testutil.ManagerPath + "/VirtualMedia/2" + actionInsertVirtualMedia,
+			Body:   map[string]interface{}{"Image": isoPath},
+		},
+		{
+			Method: http.MethodPatch,
+			Path:   testutil.ManagerPath + "/VirtualMedia/2",
+			Body: map[string]interface{}{
+				"Oem": map[string]interface{}{
+					"Hpe": map[string]interface{}{"BootOnNextServerReset": true},
+				},
+			},
+		},
+	}, server.Requests())
+}
+
+func TestSetVirtualMediaNoCD(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources(systemID, "HPE",
+		testutil.VirtualMedia{ID: "1", MediaTypes: []string{"Floppy", "USBStick"}}))
+	client, err := newClient(nodeName, server.RedfishURL(systemID), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	err = client.SetVirtualMedia(context.Background(), isoPath)
+	assert.Equal(t, redfish.ErrRedfishClient{Message: "iLO of node '1' does not have virtual media type CD or DVD."},
+		err)
+	assert.Empty(t, server.Requests())
+}
+
+func TestEjectVirtualMediaError(t *testing.T) {
+	server := testServer(t, true)
+	server.Fail(http.MethodPost, testutil.ManagerPath+"/VirtualMedia/2"+actionEjectVirtualMedia,
+		http.StatusInternalServerError)
+	client, err := newClient(nodeName, server.RedfishURL(systemID), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	err = client.EjectVirtualMedia(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BMC returned status '500 Internal Server Error'")
+}
+
+func TestSetBootSourceByType(t *testing.T) {
+	server := testServer(t, false)
+	client, err := newClient(nodeName, server.RedfishURL(systemID), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetBootSourceByType(context.Background()))
+	assert.Equal(t, []testutil.Request{{
+		Method: http.MethodPatch,
+		Path:   "/redfish/v1/Systems/1",
+		Body: map[string]interface{}{
+			"Boot": map[string]interface{}{
+				"BootSourceOverrideEnabled": "Once",
+				"BootSourceOverrideTarget":  "Cd",
+			},
+		},
+	}}, server.Requests())
+}
@@ -0,0 +1,149 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package supermicro wraps the standard Redfish client in order to provide additional functionality required to
+// perform actions on Supermicro servers.
+package supermicro
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+)
+
+const (
+	// ClientType is used by other packages as the identifier of the Supermicro Redfish client.
+	ClientType = "redfish-supermicro"
+
+	actionInsertMedia = "/Actions/VirtualMedia.InsertMedia"
+	actionEjectMedia  = "/Actions/VirtualMedia.EjectMedia"
+	// cdMediaID is the virtual CD device of Supermicro BMCs, some firmware versions don't report its media types
+	cdMediaID = "CD1"
+	// bootSourceCD is the boot source of the virtual CD, Supermicro BMCs attach virtual media as USB devices
+	bootSourceCD = "UsbCd"
+)
+
+// Client is a wrapper around the standard airshipctl Redfish client. This allows vendor specific Redfish clients to
+// override methods without duplicating the entire client.
+type Client struct {
+	redfishURL string
+	redfish.Client
+}
+
+// EjectVirtualMedia ejects all virtual media attached to a host.
+func (c *Client) EjectVirtualMedia(ctx context.Context) error {
+	media, err := c.ListVirtualMediaResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range media {
+		if !m.Inserted {
+			continue
+		}
+
+		log.Debugf("'%s' has virtual media inserted. Attempting to eject.", m.ID)
+		// Supermicro BMCs reject the action without a JSON object in the request body
+		if err = c.Request(ctx, http.MethodPost, m.ID+actionEjectMedia, struct{}{}, nil); err != nil {
+			return err
+		}
+	}
+
+	log.Debugf("Successfully ejected virtual media.")
+	return nil
+}
+
+// SetVirtualMedia attaches the ISO image to the virtual CD device of a host.
+func (c *Client) SetVirtualMedia(ctx context.Context, isoPath string) error {
+	log.Debugf("Inserting virtual media '%s'.", isoPath)
+	isoURL, err := url.Parse(isoPath)
+	if err != nil || isoURL.Scheme == "" {
+		return redfish.ErrRedfishClient{Message: fmt.Sprintf("virtual media image '%s' is not a URL", isoPath)}
+	}
+
+	if err = c.EjectVirtualMedia(ctx); err != nil {
+		return err
+	}
+
+	media, err := c.ListVirtualMediaResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range media {
+		if !m.IsCD() && !strings.HasSuffix(m.ID, "/"+cdMediaID) {
+			continue
+		}
+
+		// Supermicro BMCs require the transfer protocol to be set explicitly
+		insertReq := map[string]interface{}{
+			"Image":                isoPath,
+			"Inserted":             true,
+			"WriteProtected":       true,
+			"TransferProtocolType": strings.ToUpper(isoURL.Scheme),
+		}
+		if err = c.Request(ctx, http.MethodPost, m.ID+actionInsertMedia, insertReq, nil); err != nil {
+			return err
+		}
+
+		log.Debug("Successfully set virtual media.")
+		return nil
+	}
+
+	return redfish.ErrRedfishClient{Message: fmt.Sprintf("BMC of node '%s' does not have virtual CD device.",
+		c.NodeID())}
+}
+
+// SetBootSourceByType sets the boot source of the ephemeral node to the virtual CD for the next boot.
+func (c *Client) SetBootSourceByType(ctx context.Context) error {
+	return c.SetBootSourceOverride(ctx, bootSourceCD)
+}
+
+// RemoteDirect implements remote direct interface
+func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
+	return redfish.RemoteDirect(ctx, isoURL, c.redfishURL, c)
+}
+
+// newClient returns a client with the capability to make Redfish requests.
+func newClient(nodeName string, redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (*Client, error) {
+	genericClient, err := redfish.NewClient(nodeName, redfishURL, insecure, useProxy, username, password,
+		systemActionRetries, systemRebootDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{redfishURL: redfishURL, Client: *genericClient}, nil
+}
+
+// ClientFactory is a constructor for redfish ifc.Client implementation
+var ClientFactory ifc.ClientFactory = func(nodeName, redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (ifc.Client, error) {
+	return newClient(nodeName, redfishURL, insecure, useProxy,
+		username, password, systemActionRetries, systemRebootDelay)
+}
@@ -0,0 +1,124 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supermicro
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+const (
+	nodeName            = "node-0"
+	systemID            = "1"
+	isoPath             = "https://localhost:8099/ubuntu-focal.iso"
+	systemActionRetries = 0
+	systemRebootDelay   = 0
+)
+
+func TestNewClientInterface(t *testing.T) {
+	c, err := ClientFactory(nodeName, "redfish+https://localhost/redfish/v1/Systems/1", false, false, "", "",
+		systemActionRetries, systemRebootDelay)
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestSetVirtualMedia(t *testing.T) {
+	// media types of the virtual CD aren't reported by some firmware versions
+	server := testutil.NewServer(t, testutil.SystemResources(systemID, "Supermicro",
+		testutil.VirtualMedia{ID: "USBStick1"},
+		testutil.VirtualMedia{ID: "CD1", Inserted: true}))
+	client, err := newClient(nodeName, server.RedfishURL(systemID), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetVirtualMedia(context.Background(), isoPath))
+	assert.Equal(t, []testutil.Request{
+		{
+			Method: http.MethodPost,
+			Path:   testutil.ManagerPath + "/VirtualMedia/CD1" + actionEjectMedia,
+			Body:   map[string]interface{}{},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   testutil.ManagerPath + "/VirtualMedia/CD1" + actionInsertMedia,
+			Body: map[string]interface{}{
+				"Image":                isoPath,
+				"Inserted":             true,
+				"WriteProtected":       true,
+				"TransferProtocolType": "HTTPS",
+			},
+		},
+	}, server.Requests())
+}
+
+func TestSetVirtualMediaErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		isoPath     string
+		media       []testutil.VirtualMedia
+		expectedErr error
+	}{
+		{
+			name:        "image is not a URL",
+			isoPath:     "ubuntu-focal.iso",
+			media:       []testutil.VirtualMedia{{ID: "CD1"}},
+			expectedErr: redfish.ErrRedfishClient{Message: "virtual media image 'ubuntu-focal.iso' is not a URL"},
+		},
+		{
+			name:        "no virtual CD",
+			isoPath:     isoPath,
+			media:       []testutil.VirtualMedia{{ID: "USBStick1", MediaTypes: []string{"USBStick"}}},
+			expectedErr: redfish.ErrRedfishClient{Message: "BMC of node '1' does not have virtual CD device."},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.NewServer(t, testutil.SystemResources(systemID, "Supermicro", tt.media...))
+			client, err := newClient(nodeName, server.RedfishURL(systemID), false, false, "", "",
+				systemActionRetries, systemRebootDelay)
+			require.NoError(t, err)
+
+			err = client.SetVirtualMedia(context.Background(), tt.isoPath)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Empty(t, server.Requests())
+		})
+	}
+}
+
+func TestSetBootSourceByType(t *testing.T) {
+	server := testutil.NewServer(t, testutil.SystemResources(systemID, "Supermicro"))
+	client, err := newClient(nodeName, server.RedfishURL(systemID), false, false, "", "", systemActionRetries,
+		systemRebootDelay)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetBootSourceByType(context.Background()))
+	assert.Equal(t, []testutil.Request{{
+		Method: http.MethodPatch,
+		Path:   "/redfish/v1/Systems/1",
+		Body: map[string]interface{}{
+			"Boot": map[string]interface{}{
+				"BootSourceOverrideEnabled": "Once",
+				"BootSourceOverrideTarget":  "UsbCd",
+			},
+		},
+	}}, server.Requests())
+}
@@ -18,6 +18,7 @@ import (
 	"io"
 	"reflect"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/kubectl/pkg/cmd/get"
 	"k8s.io/kubectl/pkg/scheme"
@@ -31,6 +32,14 @@ const (
 		"EXECUTOR:config.executorRef.kind,DOC ENTRYPOINT:config.documentEntryPoint"
 	// PlanListFormat is used to print tables with plan list
 	PlanListFormat = "NAMESPACE:metadata.namespace,NAME:metadata.name,DESCRIPTION:description"
+	// PlanRunFormat is used to print tables with phases recorded during the plan run
+	PlanRunFormat = "NAME:name,RESULT:result,START TIME:startTime,END TIME:endTime,ERROR:error"
+	// PhaseStatusFormat is used to print tables with phase status
+	PhaseStatusFormat = "NAMESPACE:metadata.namespace,NAME:metadata.name,EXECUTOR:executor,STATE:state," +
+		"LAST TRANSITION TIME:lastTransitionTime,MESSAGE:message"
+	// ResourceStatusFormat is used to print tables with status of the resources managed by the phase
+	ResourceStatusFormat = "NAMESPACE:namespace,NAME:name,KIND:kind,EXISTS:exists,STATE:state,DRIFT:drift," +
+		"MESSAGE:message"
 	// HostListFormat is used to print tables with host list
 	HostListFormat = "NodeName:nodename,NodeID:nodeid"
 )
@@ -64,19 +73,24 @@ func runtimeObjectsConverter(object interface{}) []runtime.Object {
 	case reflect.Slice:
 		resources = make([]runtime.Object, value.Len())
 		for i := 0; i < value.Len(); i++ {
-			printable, ok := value.Index(i).Interface().(runtime.Object)
-			if !ok {
-				log.Debugf("resource %#v is not printable, skipping", value.Index(i).Interface())
-				continue
-			}
-			resources[i] = printable
+			resources[i] = printableObject(value.Index(i).Interface())
 		}
 	default:
-		res, ok := value.Interface().(runtime.Object)
-		if !ok {
-			log.Debugf("resource %#v is not printable, skipping", value.Interface())
-		}
-		resources = []runtime.Object{res}
+		resources = []runtime.Object{printableObject(value.Interface())}
 	}
 	return resources
 }
+
+// printableObject returns the resource as runtime object, pointers to plain structs which aren't runtime objects
+// are converted to unstructured objects, so they are printed by their json field names as well
+func printableObject(resource interface{}) runtime.Object {
+	if printable, ok := resource.(runtime.Object); ok {
+		return printable
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resource)
+	if err != nil {
+		log.Debugf("resource %#v is not printable, skipping", resource)
+		return nil
+	}
+	return &unstructured.Unstructured{Object: content}
+}
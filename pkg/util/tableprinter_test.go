@@ -62,6 +62,15 @@ func TestNewTabPrinter(t *testing.T) {
 			template: "NAME:metadata.name",
 			expected: "NAME\nphase1\nphase2\n",
 		},
+		{
+			name: "success plain objects",
+			objects: []*v1alpha1.ResourceStatus{{
+				Kind: "ConfigMap",
+				Name: "test",
+			}},
+			template: "NAME:name,NAMESPACE:namespace,EXISTS:exists",
+			expected: "NAME   NAMESPACE   EXISTS\ntest   <none>      false\n",
+		},
 	}
 
 	for _, tt := range tests {
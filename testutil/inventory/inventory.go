@@ -20,7 +20,9 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/hardware"
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
 )
 
 var _ ifc.Inventory = &MockInventory{}
@@ -70,11 +72,75 @@ func (i *MockBMHInventory) SelectOne(ifc.BaremetalHostSelector) (remoteifc.Clien
 	return host, nil
 }
 
-// RunOperation mock
+// RunOperation mock, results passed as the second return value are stored in the results of the options
 func (i *MockBMHInventory) RunOperation(
-	context.Context,
-	ifc.BaremetalOperation,
-	ifc.BaremetalHostSelector,
-	ifc.BaremetalBatchRunOptions) error {
-	return i.Called().Error(0)
+	_ context.Context,
+	_ ifc.BaremetalOperation,
+	_ ifc.BaremetalHostSelector,
+	opts ifc.BaremetalBatchRunOptions) error {
+	args := i.Called()
+	if len(args) > 1 && opts.Results != nil {
+		if results, ok := args.Get(1).(ifc.BaremetalBatchRunResults); ok {
+			*opts.Results = results
+		}
+	}
+	return args.Error(0)
+}
+
+var _ ifc.BaremetalHost = &MockBaremetalHost{}
+
+// MockBaremetalHost mocks ifc.BaremetalHost
+type MockBaremetalHost struct {
+	redfishutils.MockClient
+}
+
+// BootMACAddress mock
+func (h *MockBaremetalHost) BootMACAddress() string {
+	args := h.Called()
+	return args.String(0)
+}
+
+// HardwareInventory mock
+func (h *MockBaremetalHost) HardwareInventory(context.Context) (hardware.Inventory, error) {
+	args := h.Called()
+	err := args.Error(1)
+	inventory, ok := args.Get(0).(hardware.Inventory)
+	if !ok {
+		return hardware.Inventory{}, err
+	}
+	return inventory, err
+}
+
+// BIOSSettings mock
+func (h *MockBaremetalHost) BIOSSettings(context.Context) (hardware.BIOSSettings, error) {
+	args := h.Called()
+	err := args.Error(1)
+	settings, ok := args.Get(0).(hardware.BIOSSettings)
+	if !ok {
+		return hardware.BIOSSettings{}, err
+	}
+	return settings, err
+}
+
+// SetBIOSSettings mock
+func (h *MockBaremetalHost) SetBIOSSettings(_ context.Context, settings hardware.BIOSSettings) error {
+	args := h.Called(settings)
+	return args.Error(0)
+}
+
+// FirmwareInventory mock
+func (h *MockBaremetalHost) FirmwareInventory(context.Context) ([]hardware.Firmware, error) {
+	args := h.Called()
+	err := args.Error(1)
+	components, ok := args.Get(0).([]hardware.Firmware)
+	if !ok {
+		return nil, err
+	}
+	return components, err
+}
+
+// UpdateFirmware mock
+func (h *MockBaremetalHost) UpdateFirmware(_ context.Context, imageURL string) error {
+	args := h.Called(imageURL)
+	return args.Error(0)
 }
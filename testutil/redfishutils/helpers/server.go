@@ -0,0 +1,189 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfishutils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+const (
+	// ManagerPath is the path of the manager of the systems returned by SystemResources
+	ManagerPath = "/redfish/v1/Managers/1"
+)
+
+// VirtualMedia describes virtual media device of the manager returned by SystemResources
+type VirtualMedia struct {
+	ID         string
+	MediaTypes []string
+	Inserted   bool
+}
+
+// SystemResources returns Redfish resources of the system and its manager with the virtual media devices
+func SystemResources(systemID, manufacturer string, media ...VirtualMedia) map[string]interface{} {
+	members := []interface{}{}
+	resources := map[string]interface{}{
+		"/redfish/v1/Systems/" + systemID: map[string]interface{}{
+			"Id":           systemID,
+			"Manufacturer": manufacturer,
+			"Links": map[string]interface{}{
+				"ManagedBy": []interface{}{map[string]string{"@odata.id": ManagerPath}},
+			},
+		},
+		ManagerPath: map[string]interface{}{"Id": "1"},
+	}
+	for _, m := range media {
+		path := ManagerPath + "/VirtualMedia/" + m.ID
+		members = append(members, map[string]string{"@odata.id": path})
+		resources[path] = map[string]interface{}{
+			"Id":         m.ID,
+			"MediaTypes": m.MediaTypes,
+			"Inserted":   m.Inserted,
+		}
+	}
+	resources[ManagerPath+"/VirtualMedia"] = map[string]interface{}{"Members": members}
+	return resources
+}
+
+// Request is a request received by the fake Redfish server, the body is decoded from JSON
+type Request struct {
+	Method string
+	Path   string
+	Body   interface{}
+}
+
+// Response is a response of the fake Redfish server set by Respond
+type Response struct {
+	Status   int
+	Location string
+	Body     interface{}
+}
+
+// Server is a fake Redfish server, it returns the resources on GET requests and records the other requests
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	resources map[string]interface{}
+	failures  map[string]int
+	responses map[string]Response
+	requests  []Request
+}
+
+// NewServer starts the fake Redfish server serving the resources by their paths, the server is closed
+// when the test completes
+func NewServer(t *testing.T, resources map[string]interface{}) *Server {
+	s := &Server{
+		resources: resources,
+		failures:  make(map[string]int),
+		responses: make(map[string]Response),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// RedfishURL returns the BMC address of the system in the format expected by airshipctl
+func (s *Server) RedfishURL(systemID string) string {
+	return "redfish+" + s.URL + "/redfish/v1/Systems/" + systemID
+}
+
+// Fail makes the server respond to the requests with the method and the path with the status code
+func (s *Server) Fail(method, path string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method+" "+path] = status
+}
+
+// Recover makes the server respond to the requests with the method and the path as usual after Fail
+func (s *Server) Recover(method, path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.failures, method+" "+path)
+}
+
+// Respond makes the server respond to the requests with the method and the path with the response instead of
+// the resource or no content, e.g. to answer the polls of a task monitor while the task is running. The requests
+// other than GET are still recorded
+func (s *Server) Respond(method, path string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method+" "+path] = resp
+}
+
+// SetResource adds the resource or replaces it, e.g. to change state of a task
+func (s *Server) SetResource(path string, resource interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources[path] = resource
+}
+
+// Requests returns the requests other than GET received by the server
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status, ok := s.failures[r.Method+" "+r.URL.Path]; ok {
+		w.WriteHeader(status)
+		//nolint:errcheck
+		w.Write([]byte(`{"error":{"@Message.ExtendedInfo":[{"Message":"Request failed","Resolution":"Retry"}]}}`))
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		req := Request{Method: r.Method, Path: r.URL.Path}
+		if body, err := ioutil.ReadAll(r.Body); err == nil && len(body) != 0 {
+			json.Unmarshal(body, &req.Body) //nolint:errcheck
+		}
+		s.requests = append(s.requests, req)
+	}
+
+	if resp, ok := s.responses[r.Method+" "+r.URL.Path]; ok {
+		if resp.Location != "" {
+			w.Header().Set("Location", resp.Location)
+		}
+		if resp.Body == nil {
+			w.WriteHeader(resp.Status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.Status)
+		json.NewEncoder(w).Encode(resp.Body) //nolint:errcheck
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	resource, ok := s.resources[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resource) //nolint:errcheck
+}
@@ -151,6 +151,19 @@ func (m *MockClient) SystemPowerStatus(ctx context.Context) (power.Status, error
 	return powerStatus, args.Error(1)
 }
 
+// VirtualMediaInserted provides a stubbed method that can be mocked to test functions that use the
+// Redfish client without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("VirtualMediaInserted").Return(<return values>)
+//
+//         inserted, err := client.VirtualMediaInserted(<args>)
+func (m *MockClient) VirtualMediaInserted(ctx context.Context) (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
 // RemoteDirect mocks remote client interface
 func (m *MockClient) RemoteDirect(ctx context.Context, isoURL string) error {
 	if isoURL == "" {